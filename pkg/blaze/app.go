@@ -31,8 +31,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -52,6 +55,9 @@ import (
 //
 // Thread Safety: App is designed to be thread-safe for concurrent operations
 // during the setup phase, but should not be modified after starting the server.
+// Routes are the exception: they can be added, removed (RemoveRoute) or
+// swapped in batches (ReplaceRoutes) while serving, and in-flight requests
+// keep the routes they started with.
 type App struct {
 	router      *Router          // HTTP router for handling request routing and parameter extraction
 	middleware  []MiddlewareFunc // Global middleware stack applied to all requests in reverse order
@@ -61,6 +67,34 @@ type App struct {
 	http2Config *HTTP2Config     // HTTP/2 protocol configuration
 	http2Server *HTTP2Server     // HTTP/2 server instance when HTTP/2 is enabled
 
+	// Fallback handlers
+	notFoundHandler         HandlerFunc // Handler for requests that match no route (default NotFoundHandler)
+	methodNotAllowedHandler HandlerFunc // Handler for paths that exist under other methods (default MethodNotAllowedHandler)
+
+	// Virtual hosts registered with Host, checked before the app's own routes
+	hosts []*virtualHost
+
+	// Mounted sub-applications, longest prefix first
+	mounts      []*mountedApp
+	parent      *App   // App this app is mounted on, nil at the root
+	mountPrefix string // Prefix under parent, empty at the root
+
+	// Body codecs registered with RegisterCodec, on top of the built-in ones
+	codecs   []codecEntry
+	codecsMu sync.RWMutex
+
+	// JSON engine set with SetJSONEncoder / SetJSONDecoder, nil to inherit
+	jsonEncoder JSONEncoder
+	jsonDecoder JSONDecoder
+
+	// Config.TrustedProxies, parsed on first use
+	trustedProxies     []*net.IPNet
+	trustedProxiesOnce sync.Once
+
+	// Result of ValidateRoutes on the first call to Handler or HTTPHandler
+	routesErr      error
+	validateRoutes sync.Once
+
 	// State management
 	state   map[string]interface{}
 	stateMu sync.RWMutex
@@ -80,6 +114,7 @@ type App struct {
 // Performance Settings:
 // - ReadTimeout/WriteTimeout: Prevent slow-loris attacks and resource exhaustion
 // - MaxRequestBodySize: Limits request payload size (default 4MB)
+// - StreamRequestBody: Lets WithStreamingBody routes read large uploads as a stream
 // - Concurrency: Controls FastHTTP worker goroutines (default 256*1024)
 //
 // Protocol Settings:
@@ -87,6 +122,9 @@ type App struct {
 // - EnableTLS: Activates HTTPS with configurable cipher suites
 // - RedirectHTTPToTLS: Automatically redirects HTTP traffic to HTTPS
 //
+// Proxy Settings:
+// - TrustedProxies: Only requests from these addresses may override the scheme with X-Forwarded-Proto
+//
 // Development vs Production:
 // - Development mode enables debug features and relaxed security
 // - Production mode enforces strict security and optimal performance
@@ -102,14 +140,18 @@ type Config struct {
 	WriteTimeout time.Duration // Maximum time to write response
 
 	// Resource Limits
-	MaxRequestBodySize int // Maximum size in bytes for request body (prevents memory exhaustion)
-	Concurrency        int // Maximum number of concurrent connections (FastHTTP worker pool size)
+	MaxRequestBodySize int  // Maximum size in bytes for request body (prevents memory exhaustion)
+	Concurrency        int  // Maximum number of concurrent connections (FastHTTP worker pool size)
+	StreamRequestBody  bool // Stream request bodies of WithStreamingBody routes instead of buffering them
 
 	// Protocol Configuration
 	EnableHTTP2       bool // Enable HTTP/2 support with multiplexing and server push
 	EnableTLS         bool // Enable TLS/HTTPS support
 	RedirectHTTPToTLS bool // Automatically redirect HTTP requests to HTTPS
 
+	// Proxy Configuration
+	TrustedProxies []string // Proxy IPs or CIDR ranges allowed to set forwarded headers (X-Forwarded-Proto)
+
 	// Development Settings
 	Development bool // Enable development mode (relaxed security, debug features)
 }
@@ -217,13 +259,19 @@ func DevelopmentConfig() *Config {
 func New() *App {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &App{
-		router:         NewRouter(),
-		middleware:     make([]MiddlewareFunc, 0),
-		config:         DefaultConfig(),
-		shutdownCtx:    ctx,
-		shutdownCancel: cancel,
+	app := &App{
+		router:                  NewRouter(),
+		middleware:              make([]MiddlewareFunc, 0),
+		config:                  DefaultConfig(),
+		notFoundHandler:         NotFoundHandler(),
+		methodNotAllowedHandler: MethodNotAllowedHandler(),
+		shutdownCtx:             ctx,
+		shutdownCancel:          cancel,
 	}
+	app.router.setOuter(app.outerMiddleware)
+	app.router.nameOwner = app.nameOwner(app.router)
+
+	return app
 }
 
 // NewWithConfig creates a new Blaze application with custom configuration.
@@ -247,12 +295,16 @@ func New() *App {
 func NewWithConfig(config *Config) *App {
 	ctx, cancel := context.WithCancel(context.Background())
 	app := &App{
-		router:         NewRouter(),
-		middleware:     make([]MiddlewareFunc, 0),
-		config:         config,
-		shutdownCtx:    ctx,
-		shutdownCancel: cancel,
+		router:                  NewRouter(),
+		middleware:              make([]MiddlewareFunc, 0),
+		config:                  config,
+		notFoundHandler:         NotFoundHandler(),
+		methodNotAllowedHandler: MethodNotAllowedHandler(),
+		shutdownCtx:             ctx,
+		shutdownCancel:          cancel,
 	}
+	app.router.setOuter(app.outerMiddleware)
+	app.router.nameOwner = app.nameOwner(app.router)
 
 	// Configure TLS and HTTP/2 based on config
 	if config.EnableTLS {
@@ -479,9 +531,9 @@ func (a *App) RegisterGracefulTask(task func(ctx context.Context) error) {
 // Shutdown gracefully shuts down the server with a configurable timeout.
 //
 // The graceful shutdown process follows these steps:
-// 1. Mark the application as shutting down (new requests get 503)
-// 2. Cancel the shutdown context to notify all components
-// 3. Wait for all registered graceful tasks to complete
+// 1. Mark the application and mounted apps as shutting down (new requests get 503)
+// 2. Cancel the shutdown contexts to notify all components
+// 3. Wait for all registered graceful tasks, including mounted apps', to complete
 // 4. Shutdown HTTP/2 server if enabled
 // 5. Shutdown HTTP/1.1 server
 // 6. Force shutdown if timeout is exceeded
@@ -511,10 +563,10 @@ func (a *App) Shutdown(ctx context.Context) error {
 
 	a.shutdownOnce.Do(func() {
 		log.Println("🛑 Initiating graceful shutdown...")
-		a.setShuttingDown(true)
 
-		// Cancel shutdown context to notify all tasks
-		a.shutdownCancel()
+		// Mark shutting down and cancel shutdown contexts to notify all
+		// tasks, including those of mounted apps
+		a.beginShutdown()
 
 		// Create a channel to handle shutdown completion
 		done := make(chan error, 1)
@@ -522,7 +574,7 @@ func (a *App) Shutdown(ctx context.Context) error {
 		go func() {
 			// Wait for all graceful tasks to complete
 			log.Println("⏳ Waiting for graceful tasks to complete...")
-			a.shutdownWg.Wait()
+			a.waitGracefulTasks()
 
 			// Shutdown HTTP/2 server first
 			if a.http2Server != nil {
@@ -626,6 +678,11 @@ func (a *App) startHTTPRedirectServer() {
 
 // ListenAndServe starts the appropriate server based on configuration
 func (a *App) ListenAndServe() error {
+	// Refuse to start with routes that failed to register
+	if err := a.ValidateRoutes(); err != nil {
+		return err
+	}
+
 	// Setup server
 	addr := fmt.Sprintf("%s:%d", a.config.Host, a.config.Port)
 	tlsAddr := fmt.Sprintf("%s:%d", a.config.Host, a.config.TLSPort)
@@ -637,6 +694,12 @@ func (a *App) ListenAndServe() error {
 		WriteTimeout:       a.config.WriteTimeout,
 		MaxRequestBodySize: a.config.MaxRequestBodySize,
 		Concurrency:        a.config.Concurrency,
+		StreamRequestBody:  a.config.StreamRequestBody,
+
+		// Multipart bodies are parsed by the app on demand: streamed
+		// uploads are not spooled to disk before routing, and buffered
+		// bodies keep their part order for MultipartReader
+		DisablePreParseMultipartForm: true,
 	}
 
 	// Start HTTP redirect server if needed
@@ -649,6 +712,8 @@ func (a *App) ListenAndServe() error {
 	if a.config.EnableHTTP2 && a.http2Server != nil {
 		// HTTP/2 Server
 		a.http2Server.SetFastHTTPHandler(a.handler)
+		a.http2Server.maxBodySize = a.config.MaxRequestBodySize
+		a.http2Server.streamBody = a.config.StreamRequestBody
 
 		if a.config.EnableTLS && a.tlsConfig != nil {
 			log.Printf("🚀 Blaze HTTP/2 server starting with TLS on https://%s", addr)
@@ -805,6 +870,64 @@ func (a *App) UseErrorHandler(config *ErrorHandlerConfig) *App {
 	return a
 }
 
+// SetRouterConfig replaces the router configuration.
+//
+// Settings such as CaseSensitive affect how routes are stored, so this
+// should be called before any routes are registered.
+//
+// Example:
+//
+//	config := blaze.DefaultRouterConfig()
+//	config.HandleMethodNotAllowed = false // Unknown methods get 404
+//	app.SetRouterConfig(config)
+func (a *App) SetRouterConfig(config RouterConfig) *App {
+	a.router.config = config
+	for _, vh := range a.hosts {
+		vh.router.config = config
+	}
+	return a
+}
+
+// SetNotFoundHandler sets the handler used when no route matches the request.
+//
+// The handler runs inside the global middleware stack, so error handling,
+// logging and CORS middleware apply to 404 responses as well.
+//
+// Example:
+//
+//	app.SetNotFoundHandler(func(c *blaze.Context) error {
+//	    return c.Status(404).HTML("<h1>Page not found</h1>")
+//	})
+func (a *App) SetNotFoundHandler(handler HandlerFunc) *App {
+	if handler == nil {
+		handler = NotFoundHandler()
+	}
+	a.notFoundHandler = handler
+	return a
+}
+
+// SetMethodNotAllowedHandler sets the handler used when the path exists but
+// not for the requested method.
+//
+// The Allow header listing the permitted methods is set before the handler
+// runs. Only used when RouterConfig.HandleMethodNotAllowed is enabled.
+//
+// Example:
+//
+//	app.SetMethodNotAllowedHandler(func(c *blaze.Context) error {
+//	    return c.Status(405).JSON(blaze.Map{
+//	        "error": "method not allowed",
+//	        "allow": c.GetResponseHeader("Allow"),
+//	    })
+//	})
+func (a *App) SetMethodNotAllowedHandler(handler HandlerFunc) *App {
+	if handler == nil {
+		handler = MethodNotAllowedHandler()
+	}
+	a.methodNotAllowedHandler = handler
+	return a
+}
+
 // SetState sets a global application state value
 func (a *App) SetState(key string, value interface{}) *App {
 	a.stateMu.Lock()
@@ -943,6 +1066,7 @@ func (a *App) File(path, filepath string) *App {
 //	    Use(customMiddleware)
 func (a *App) Use(middleware MiddlewareFunc) *App {
 	a.middleware = append(a.middleware, middleware)
+	a.compileChains()
 	return a
 }
 
@@ -1313,6 +1437,43 @@ func (a *App) Group(prefix string, configure ...func(*Group)) *Group {
 // 	}
 // }
 
+// Handler returns the FastHTTP request handler that drives the application.
+//
+// The returned handler performs routing, middleware execution and error
+// handling exactly like the server started by ListenAndServe. It is useful
+// for serving the app on a custom listener or driving it from tests without
+// binding a real port (see the blazetest package).
+//
+// Like ListenAndServe, the first call runs ValidateRoutes. Handler cannot
+// return an error, so it panics with the rejected routes instead of serving
+// an incomplete route table; call ValidateRoutes first to handle the error
+// yourself. Routes added later are not validated again.
+//
+// Example:
+//
+//	server := &fasthttp.Server{Handler: app.Handler()}
+//	log.Fatal(server.Serve(listener))
+func (a *App) Handler() fasthttp.RequestHandler {
+	a.mustValidateRoutes()
+	return a.handler
+}
+
+// mustValidateRoutes runs ValidateRoutes once, when the app is first handed
+// out as a handler, and panics with the rejected routes
+func (a *App) mustValidateRoutes() {
+	a.validateRoutes.Do(func() {
+		a.routesErr = a.ValidateRoutes()
+	})
+	if a.routesErr != nil {
+		panic(a.routesErr)
+	}
+}
+
+// GetConfig returns the application's configuration
+func (a *App) GetConfig() *Config {
+	return a.config
+}
+
 // handler is the main request handler that applies middleware and routing
 func (a *App) handler(ctx *fasthttp.RequestCtx) {
 	// Check if server is shutting down
@@ -1322,11 +1483,26 @@ func (a *App) handler(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	blazeCtx := &Context{
-		RequestCtx: ctx,
-		params:     make(map[string]string),
-		locals:     make(map[string]interface{}),
+	blazeCtx := acquireContext(ctx)
+	if ctx.Request.IsBodyStream() {
+		// Config.StreamRequestBody: the body is read by the route
+		blazeCtx.body = newRequestBody(blazeCtx, int64(a.config.MaxRequestBodySize), a.config.ReadTimeout)
+	}
+	a.serve(blazeCtx)
+	blazeCtx.finishBody()
+
+	// Hijacked connections (WebSocket upgrades) keep using the context
+	if ctx.Hijacked() {
+		blazeCtx.retain()
 	}
+	releaseContext(blazeCtx)
+}
+
+// serve routes the request through this app's hosts, mounts, routes and
+// middleware, and writes any error left unhandled by the middleware chain.
+// Mounted apps are served through the same method with the prefix stripped.
+func (a *App) serve(blazeCtx *Context) {
+	ctx := blazeCtx.RequestCtx
 
 	// Set shutdown context in locals
 	blazeCtx.SetLocals("shutdown_ctx", a.shutdownCtx)
@@ -1334,39 +1510,168 @@ func (a *App) handler(ctx *fasthttp.RequestCtx) {
 	// Inject app reference for state access
 	blazeCtx.SetLocals("__app__", a)
 
+	// Select the virtual host, falling back to mounted apps and then the
+	// app's own routes. Every handler returned here already runs inside
+	// the global (and host) middleware.
 	var handler HandlerFunc
-	var err error
+	if vh := a.matchHost(string(ctx.Host()), &blazeCtx.params); vh != nil {
+		handler = routeHandler(blazeCtx, vh.router, vh.notFoundHandler, vh.methodNotAllowedHandler, a.mountPath())
+	} else if m := a.matchMount(blazeCtx.Path()); m != nil {
+		handler = *m.chain.Load()
+	} else {
+		handler = routeHandler(blazeCtx, a.router, a.notFoundHandler, a.methodNotAllowedHandler, a.mountPath())
+	}
+
+	// Execute handler
+	if err := handler(blazeCtx); err != nil {
+		// Errors not consumed by error middleware still keep their HTTP status
+		if httpErr, ok := err.(*HTTPError); ok {
+			httpErr.Path = blazeCtx.Path()
+			httpErr.Method = blazeCtx.Method()
+			if httpErr.Problem {
+				blazeCtx.Status(httpErr.StatusCode).Problem(httpErr)
+				return
+			}
+			blazeCtx.Status(httpErr.StatusCode).JSON(httpErr.ToErrorResponse(blazeCtx, false))
+			return
+		}
+		blazeCtx.Status(500).JSON(Map{"error": err.Error()})
+	}
+}
 
-	// Use advanced router
-	route, params, found := a.router.FindRoute(
-		string(ctx.Method()),
-		string(ctx.Path()),
+// routeHandler matches the request against router and returns the
+// compiled chain of the matched route, or the not found / 405 / OPTIONS /
+// canonical redirect handler wrapped in the router's outer middleware.
+// prefix is the mount path of the app owning router.
+func routeHandler(blazeCtx *Context, router *Router, notFound, methodNotAllowed HandlerFunc, prefix string) HandlerFunc {
+	// Use one routing snapshot for the whole request, so routes swapped
+	// while it is in flight do not affect it
+	table := router.table.Load()
+	route, found := router.findRoute(
+		table,
+		blazeCtx.Method(),
+		blazeCtx.Path(),
+		&blazeCtx.params,
 	)
 
+	if err := prepareBody(blazeCtx, route, found); err != nil {
+		return table.wrapHandler(func(c *Context) error {
+			return err
+		})
+	}
+
 	if !found {
-		handler = NotFoundHandler()
-	} else {
-		// Set route parameters
-		for key, value := range params {
-			blazeCtx.SetParam(key, value)
+		return table.wrapHandler(noRouteHandler(blazeCtx, router, table, notFound, methodNotAllowed))
+	}
+	if redirect := canonicalRedirect(blazeCtx, router, route, blazeCtx.params, prefix); redirect != nil {
+		return table.wrapHandler(redirect)
+	}
+	return table.chains[route.index]
+}
+
+// prepareBody readies a body streamed by the server for the matched route
+// Streaming routes read it through Context.BodyStream under their own body
+// limit; every other request gets it buffered, within
+// Config.MaxRequestBodySize, as if the server had read it.
+func prepareBody(c *Context, route *Route, found bool) error {
+	if c.body == nil {
+		return nil
+	}
+	if found && route.Policy.StreamingBody {
+		if route.Policy.BodyLimit > 0 {
+			c.body.setLimit(route.Policy.BodyLimit)
+		}
+		return nil
+	}
+	return c.bufferBody()
+}
+
+// canonicalRedirect returns a handler redirecting to the registered casing of
+// the matched route, or nil when the request already uses it or
+// RedirectCanonicalCase is disabled. prefix is the mount path of the app
+// owning router, which is not part of the matched path.
+func canonicalRedirect(c *Context, router *Router, route *Route, params paramList, prefix string) HandlerFunc {
+	config := router.config
+	if config.CaseSensitive || !config.RedirectCanonicalCase {
+		return nil
+	}
+
+	path := c.Path()
+	canonical := route.canonicalPath(params)
+	if canonical == path || !strings.EqualFold(canonical, path) {
+		return nil
+	}
+
+	return func(c *Context) error {
+		location := joinMountPath(prefix, canonical)
+		if query := c.URI().QueryString(); len(query) > 0 {
+			location += "?" + string(query)
 		}
 
-		// Apply route-specific middleware
-		handler = route.Handler
-		for i := len(route.Middleware) - 1; i >= 0; i-- {
-			handler = route.Middleware[i](handler)
+		// 308 keeps the method and body for non-idempotent requests
+		status := fasthttp.StatusMovedPermanently
+		if method := c.Method(); method != "GET" && method != "HEAD" {
+			status = fasthttp.StatusPermanentRedirect
 		}
+
+		c.Redirect(location, status)
+		return nil
 	}
+}
 
-	// Apply global middleware
-	for i := len(a.middleware) - 1; i >= 0; i-- {
-		handler = a.middleware[i](handler)
+// noRouteHandler selects the handler for a request that matched no route.
+// The not found and 405 handlers and error handling of the longest matching
+// group scope replace the given defaults.
+func noRouteHandler(c *Context, router *Router, table *routeTable, notFound, methodNotAllowed HandlerFunc) HandlerFunc {
+	notFound, methodNotAllowed, errors := table.scopeHandlers(c.Path(), router.config.CaseSensitive, notFound, methodNotAllowed)
+
+	handler := unmatchedHandler(c, router, table, notFound, methodNotAllowed)
+	if errors != nil {
+		handler = errors(handler)
 	}
+	return handler
+}
 
-	// Execute handler
-	if err = handler(blazeCtx); err != nil {
-		blazeCtx.Status(500).JSON(Map{"error": err.Error()})
+// unmatchedHandler selects between the not found, 405 and OPTIONS handlers.
+//
+// When the path is registered under other methods, OPTIONS requests are
+// answered automatically and other methods get 405 Method Not Allowed, both
+// with an Allow header. CORS preflight requests are answered with the CORS
+// policy of the requested route. Otherwise the not found handler is used.
+func unmatchedHandler(c *Context, router *Router, table *routeTable, notFound, methodNotAllowed HandlerFunc) HandlerFunc {
+	config := router.config
+	if !config.HandleMethodNotAllowed && !config.HandleOPTIONS {
+		return notFound
+	}
+
+	allowed := router.allowedMethods(table, c.Path())
+	if len(allowed) == 0 {
+		return notFound
+	}
+	allow := strings.Join(allowed, ", ")
+
+	if config.HandleOPTIONS && c.Method() == "OPTIONS" {
+		options := func(c *Context) error {
+			c.SetHeader("Allow", allow)
+			return c.SendStatus(fasthttp.StatusNoContent)
+		}
+
+		// CORS preflight requests get the policy of the route they ask about
+		if method := c.Header("Access-Control-Request-Method"); method != "" {
+			var params paramList
+			if route, ok := router.findRoute(table, method, c.Path(), &params); ok && route.policies.cors != nil {
+				return route.policies.cors(options)
+			}
+		}
+		return options
+	}
+
+	if config.HandleMethodNotAllowed {
+		c.SetHeader("Allow", allow)
+		return methodNotAllowed
 	}
+
+	return notFound
 }
 
 // GetServerInfo returns server information including TLS and HTTP/2 status
@@ -1408,25 +1713,188 @@ type Group struct {
 	app        *App
 	prefix     string
 	middleware []MiddlewareFunc
-	parent     *Group // For nested groups
+	options    []RouteOption // Route options applied to every route in the group
+	parent     *Group        // For nested groups
+	host       *virtualHost  // Virtual host for groups created with App.Host
 }
 
 // Use adds middleware to the group
+// On a group returned by App.Host the middleware applies to every request
+// on that host, including 404 and 405 responses
 func (g *Group) Use(middleware MiddlewareFunc) *Group {
+	if g.isHostRoot() {
+		g.host.middleware = append(g.host.middleware, middleware)
+		g.host.router.recompile()
+		return g
+	}
 	g.middleware = append(g.middleware, middleware)
 	return g
 }
 
+// WithOptions applies route options to every route registered in the group
+// afterwards, including routes of nested groups created afterwards. Options
+// passed to a route are applied after group options, so the route can
+// override a group policy (e.g. a longer WithTimeout for one endpoint).
+//
+// Parameters:
+//   - options: Route options such as WithTimeout, WithRateLimit or WithTags
+//
+// Returns:
+//   - *Group: Group instance for method chaining
+//
+// Example:
+//
+//	api := app.Group("/api").WithOptions(
+//	    blaze.WithTimeout(5*time.Second),
+//	    blaze.WithBodyLimit(1<<20),
+//	    blaze.WithCORS(corsOpts),
+//	)
+//	api.GET("/users", listUsers)
+//	api.POST("/import", importUsers, blaze.WithTimeout(time.Minute))
+func (g *Group) WithOptions(options ...RouteOption) *Group {
+	g.options = append(g.options, options...)
+	return g
+}
+
+// SetNotFoundHandler sets the 404 handler for requests under the group prefix
+// Used when no route matches a path equal to or below the prefix; the
+// longest matching group prefix wins, so nested groups can override their
+// parent. On a group returned by App.Host it replaces the host's 404 handler.
+//
+// The handler runs inside the global (and host) middleware and the group's
+// error handling (UseErrorHandler). Passing nil removes the group handler,
+// falling back to the parent group or the app.
+//
+// Parameters:
+//   - handler: Handler for unmatched requests under the prefix
+//
+// Returns:
+//   - *Group: Group instance for method chaining
+//
+// Example:
+//
+//	api := app.Group("/api")
+//	api.SetNotFoundHandler(func(c *blaze.Context) error {
+//	    return c.Status(404).JSON(blaze.Map{"error": "unknown endpoint"})
+//	})
+//
+//	web := app.Group("/site")
+//	web.SetNotFoundHandler(func(c *blaze.Context) error {
+//	    return c.Status(404).HTML("<h1>Page not found</h1>")
+//	})
+func (g *Group) SetNotFoundHandler(handler HandlerFunc) *Group {
+	if g.isHostRoot() {
+		if handler == nil {
+			handler = NotFoundHandler()
+		}
+		g.host.notFoundHandler = handler
+		return g
+	}
+
+	g.router().setScope(g.prefix, func(s *routeScope) {
+		s.notFound = handler
+	})
+	return g
+}
+
+// SetMethodNotAllowedHandler sets the 405 handler for requests under the
+// group prefix
+// Resolved like SetNotFoundHandler. The Allow header is set before the
+// handler runs. Only used when RouterConfig.HandleMethodNotAllowed is enabled.
+//
+// Parameters:
+//   - handler: Handler for paths registered under other methods
+//
+// Returns:
+//   - *Group: Group instance for method chaining
+func (g *Group) SetMethodNotAllowedHandler(handler HandlerFunc) *Group {
+	if g.isHostRoot() {
+		if handler == nil {
+			handler = MethodNotAllowedHandler()
+		}
+		g.host.methodNotAllowedHandler = handler
+		return g
+	}
+
+	g.router().setScope(g.prefix, func(s *routeScope) {
+		s.methodNotAllowed = handler
+	})
+	return g
+}
+
+// UseErrorHandler sets up error handling for requests under the group prefix
+// Like App.UseErrorHandler, but scoped: errors and panics from routes whose
+// pattern lies under the prefix, and from the group's 404 and 405 handlers,
+// are handled with config. The longest matching group prefix wins. Group
+// error handling runs inside the global middleware, so errors it handles
+// never reach global error handling.
+//
+// Applies to routes registered before and after the call.
+//
+// Parameters:
+//   - config: Error handler configuration (nil for defaults)
+//
+// Returns:
+//   - *Group: Group instance for method chaining
+//
+// Example - JSON for the API, HTML for the site:
+//
+//	api := app.Group("/api")
+//	api.UseErrorHandler(blaze.DefaultErrorHandlerConfig())
+//
+//	web := app.Group("/site")
+//	web.UseErrorHandler(&blaze.ErrorHandlerConfig{
+//	    LogErrors: true,
+//	    CustomHandler: func(c *blaze.Context, err error) error {
+//	        status := 500
+//	        var httpErr *blaze.HTTPError
+//	        if errors.As(err, &httpErr) {
+//	            status = httpErr.StatusCode
+//	        }
+//	        return c.Status(status).HTML(renderErrorPage(status))
+//	    },
+//	})
+func (g *Group) UseErrorHandler(config *ErrorHandlerConfig) *Group {
+	if config == nil {
+		if g.app.config.Development {
+			config = DevelopmentErrorHandlerConfig()
+		} else {
+			config = DefaultErrorHandlerConfig()
+		}
+	}
+
+	errors := errorScopeMiddleware(config)
+	g.router().setScope(g.prefix, func(s *routeScope) {
+		s.errors = errors
+	})
+	return g
+}
+
+// router returns the router routes of this group are registered in
+func (g *Group) router() *Router {
+	if g.host != nil {
+		return g.host.router
+	}
+	return g.app.router
+}
+
+// isHostRoot reports whether the group was returned by App.Host
+func (g *Group) isHostRoot() bool {
+	return g.host != nil && g.parent == nil && g.prefix == ""
+}
+
 // Group creates a nested group
 func (g *Group) Group(prefix string, configure ...func(*Group)) *Group {
 	nestedGroup := &Group{
 		app:        g.app,
 		prefix:     g.prefix + prefix,
 		middleware: make([]MiddlewareFunc, len(g.middleware)),
+		options:    slices.Clone(g.options),
 		parent:     g,
+		host:       g.host,
 	}
 
-	// Inherit parent middleware
+	// Inherit parent middleware and route options
 	copy(nestedGroup.middleware, g.middleware)
 
 	// Apply configuration if provided
@@ -1439,114 +1907,104 @@ func (g *Group) Group(prefix string, configure ...func(*Group)) *Group {
 
 // GET registers a GET route in the group
 func (g *Group) GET(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("GET", fullPath, wrappedHandler, options...)
+	g.add("GET", path, handler, options)
 	return g
 }
 
 // POST registers a POST route in the group
 func (g *Group) POST(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("POST", fullPath, wrappedHandler, options...)
+	g.add("POST", path, handler, options)
 	return g
 }
 
 // PUT registers a PUT route in the group
 func (g *Group) PUT(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("PUT", fullPath, wrappedHandler, options...)
+	g.add("PUT", path, handler, options)
 	return g
 }
 
 // DELETE registers a DELETE route in the group
 func (g *Group) DELETE(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("DELETE", fullPath, wrappedHandler, options...)
+	g.add("DELETE", path, handler, options)
 	return g
 }
 
 // PATCH registers a PATCH route in the group
 func (g *Group) PATCH(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("PATCH", fullPath, wrappedHandler, options...)
+	g.add("PATCH", path, handler, options)
 	return g
 }
 
 // New HTTP methods for groups
 func (g *Group) CONNECT(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("CONNECT", fullPath, wrappedHandler, options...)
+	g.add("CONNECT", path, handler, options)
 	return g
 }
 
 func (g *Group) TRACE(path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
-	g.app.router.AddRoute("TRACE", fullPath, wrappedHandler, options...)
+	g.add("TRACE", path, handler, options)
 	return g
 }
 
 // ANY registers a route for all HTTP methods in the group
 func (g *Group) ANY(path string, handler HandlerFunc, options ...RouteOption) *Group {
 	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD", "CONNECT", "TRACE"}
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
 	for _, method := range methods {
-		g.app.router.AddRoute(method, fullPath, wrappedHandler, options...)
+		g.add(method, path, handler, options)
 	}
 	return g
 }
 
 // Match registers a route for specific HTTP methods in the group
 func (g *Group) Match(methods []string, path string, handler HandlerFunc, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
-	wrappedHandler := g.wrapHandler(handler)
 	for _, method := range methods {
-		g.app.router.AddRoute(method, fullPath, wrappedHandler, options...)
+		g.add(method, path, handler, options)
 	}
 	return g
 }
 
 // WebSocket registers a WebSocket route in the group
 func (g *Group) WebSocket(path string, handler WebSocketHandler, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
 	upgrader := NewWebSocketUpgrader()
 
 	wsHandler := func(c *Context) error {
 		return upgrader.Upgrade(c, handler)
 	}
 
-	wrappedHandler := g.wrapHandler(wsHandler)
-	g.app.router.AddRoute("GET", fullPath, wrappedHandler, options...)
+	g.add("GET", path, wsHandler, options)
 	return g
 }
 
 // WebSocketWithConfig registers a WebSocket route with custom config in the group
 func (g *Group) WebSocketWithConfig(path string, handler WebSocketHandler, config *WebSocketConfig, options ...RouteOption) *Group {
-	fullPath := g.prefix + path
 	upgrader := NewWebSocketUpgrader(config)
 
 	wsHandler := func(c *Context) error {
 		return upgrader.Upgrade(c, handler)
 	}
 
-	wrappedHandler := g.wrapHandler(wsHandler)
-	g.app.router.AddRoute("GET", fullPath, wrappedHandler, options...)
+	g.add("GET", path, wsHandler, options)
 	return g
 }
 
-// wrapHandler applies group middleware to the handler
-func (g *Group) wrapHandler(handler HandlerFunc) HandlerFunc {
-	for i := len(g.middleware) - 1; i >= 0; i-- {
-		handler = g.middleware[i](handler)
+// add registers a route in the group
+// Group middleware and options are captured when the route is added;
+// route options are applied after group options and take precedence.
+func (g *Group) add(method, path string, handler HandlerFunc, options []RouteOption) {
+	all := make([]RouteOption, 0, len(g.options)+len(options)+1)
+	all = append(all, withGroupMiddleware(g.middleware))
+	all = append(all, g.options...)
+	all = append(all, options...)
+
+	g.router().AddRoute(method, g.prefix+path, handler, all...)
+}
+
+// withGroupMiddleware sets the group middleware of a route
+func withGroupMiddleware(middleware []MiddlewareFunc) RouteOption {
+	middleware = slices.Clone(middleware)
+	return func(r *Route) {
+		r.groupMiddleware = middleware
 	}
-	return handler
 }
 
 // GetAllMiddleware returns all middleware including inherited from parents
@@ -0,0 +1,423 @@
+package blaze
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ==================== Tagged Request Binding ====================
+
+// bindSource describes a part of the request that struct fields can be
+// bound from with a struct tag
+type bindSource struct {
+	// tag is the struct tag key ("query")
+	tag string
+
+	// kind names the source in error messages ("query parameter")
+	kind string
+
+	// lookup returns all values for name, nil when it is absent
+	lookup func(c *Context, name string) []string
+
+	// split fills slice fields from comma-separated values (?ids=1,2)
+	// Off for sources whose values may contain commas (Date, quoted
+	// cookies); the split tag option enables it per field.
+	split bool
+}
+
+var (
+	paramSource  = bindSource{tag: "param", kind: "path parameter", lookup: lookupParam}
+	querySource  = bindSource{tag: "query", kind: "query parameter", lookup: lookupQuery, split: true}
+	headerSource = bindSource{tag: "header", kind: "header", lookup: lookupHeader}
+	cookieSource = bindSource{tag: "cookie", kind: "cookie", lookup: lookupCookie}
+)
+
+// bindSources lists the tagged sources in binding order
+// A field is bound from the first source it has a tag for.
+var bindSources = []bindSource{paramSource, querySource, headerSource, cookieSource}
+
+var (
+	// timeType is the reflect type of time.Time
+	timeType = reflect.TypeOf(time.Time{})
+
+	// durationType is the reflect type of time.Duration
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// textUnmarshalerType is the reflect type of encoding.TextUnmarshaler
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// BindQuery binds query parameters to the query-tagged fields of a struct
+// Tags use the form tag syntax: query:"name,required,default=value".
+// Untagged fields are left untouched.
+//
+// Supported Types:
+//   - Basic types: string, int, uint, float, bool
+//   - time.Time (formats of form binding) and time.Duration ("1m30s")
+//   - Types implementing encoding.TextUnmarshaler
+//   - Pointers, allocated only when a value is present
+//   - Slices from repeated (?id=1&id=2) or comma-separated (?ids=1,2) values
+//   - Embedded and untagged nested structs, bound field by field
+//
+// Parameters:
+//   - v: Pointer to struct to bind into
+//
+// Returns:
+//   - error: Binding error naming the field, nil on success
+//
+// Example:
+//
+//	type ListUsersQuery struct {
+//	    Page    int           `query:"page,default=1"`
+//	    IDs     []int         `query:"id"`
+//	    Since   *time.Time    `query:"since"`
+//	    Timeout time.Duration `query:"timeout,default=5s"`
+//	}
+//
+//	var q ListUsersQuery
+//	if err := c.BindQuery(&q); err != nil {
+//	    return blaze.ErrBadRequest(err.Error())
+//	}
+func (c *Context) BindQuery(v interface{}) error {
+	return c.bindSources(v, querySource)
+}
+
+// BindParams binds route parameters to the param-tagged fields of a struct
+//
+// Parameters:
+//   - v: Pointer to struct to bind into
+//
+// Returns:
+//   - error: Binding error naming the field, nil on success
+//
+// Example:
+//
+//	// Route: /orgs/:org/users/:id
+//	type UserPath struct {
+//	    Org string `param:"org"`
+//	    ID  int    `param:"id"`
+//	}
+//
+//	var p UserPath
+//	if err := c.BindParams(&p); err != nil {
+//	    return blaze.ErrBadRequest(err.Error())
+//	}
+func (c *Context) BindParams(v interface{}) error {
+	return c.bindSources(v, paramSource)
+}
+
+// BindHeaders binds request headers to the header-tagged fields of a struct
+// Header names are case-insensitive. Slice fields receive one element per
+// header line; header values may contain commas (Date, quoted strings), so
+// comma-separated lists are only split with the split tag option.
+//
+// Parameters:
+//   - v: Pointer to struct to bind into
+//
+// Returns:
+//   - error: Binding error naming the field, nil on success
+//
+// Example:
+//
+//	type ClientHeaders struct {
+//	    Tenant    string   `header:"X-Tenant-ID,required"`
+//	    RequestID *string  `header:"X-Request-ID"`
+//	    Forwarded []string `header:"X-Forwarded-For,split"`
+//	}
+//
+//	var h ClientHeaders
+//	if err := c.BindHeaders(&h); err != nil {
+//	    return blaze.ErrBadRequest(err.Error())
+//	}
+func (c *Context) BindHeaders(v interface{}) error {
+	return c.bindSources(v, headerSource)
+}
+
+// BindCookies binds cookies to the cookie-tagged fields of a struct
+//
+// Parameters:
+//   - v: Pointer to struct to bind into
+//
+// Returns:
+//   - error: Binding error naming the field, nil on success
+//
+// Example:
+//
+//	type Session struct {
+//	    ID    string `cookie:"session_id,required"`
+//	    Theme string `cookie:"theme,default=light"`
+//	}
+//
+//	var s Session
+//	if err := c.BindCookies(&s); err != nil {
+//	    return blaze.ErrUnauthorized("Missing session")
+//	}
+func (c *Context) BindCookies(v interface{}) error {
+	return c.bindSources(v, cookieSource)
+}
+
+// BindAll binds route parameters, query parameters, headers and cookies
+// to the tagged fields of a struct in one pass
+// Each field is bound from the source named by its tag; the request body
+// is not read. Handle uses the same binding for typed handlers.
+//
+// Parameters:
+//   - v: Pointer to struct to bind into
+//
+// Returns:
+//   - error: Binding error naming the field, nil on success
+//
+// Example:
+//
+//	type GetOrderRequest struct {
+//	    ID     int      `param:"id"`
+//	    Expand []string `query:"expand"`
+//	    Tenant string   `header:"X-Tenant-ID,required"`
+//	    Locale string   `cookie:"locale,default=en"`
+//	}
+//
+//	var req GetOrderRequest
+//	if err := c.BindAll(&req); err != nil {
+//	    return blaze.ErrBadRequest(err.Error())
+//	}
+func (c *Context) BindAll(v interface{}) error {
+	return c.bindSources(v, bindSources...)
+}
+
+// bindSources binds the fields tagged for sources into the struct v points to
+func (c *Context) bindSources(v interface{}, sources ...bindSource) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("binding destination must be a pointer")
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return errors.New("binding destination must be a pointer to struct")
+	}
+
+	return c.bindTagged(rv, sources)
+}
+
+func lookupParam(c *Context, name string) []string {
+	if value, ok := c.params.get(name); ok {
+		return []string{value}
+	}
+	return nil
+}
+
+func lookupQuery(c *Context, name string) []string {
+	var values []string
+	for _, value := range c.RequestCtx.QueryArgs().PeekMulti(name) {
+		values = append(values, string(value))
+	}
+	return values
+}
+
+func lookupHeader(c *Context, name string) []string {
+	var values []string
+	for _, value := range c.RequestCtx.Request.Header.PeekAll(name) {
+		values = append(values, string(value))
+	}
+	return values
+}
+
+func lookupCookie(c *Context, name string) []string {
+	if value := c.RequestCtx.Request.Header.Cookie(name); value != nil {
+		return []string{string(value)}
+	}
+	return nil
+}
+
+// bindTagged binds the fields of the struct rv tagged for sources
+// Tags use the form tag syntax (name,required,default=value,maxsize=n).
+// Fields of embedded structs and of untagged nested structs are bound as
+// well; other untagged fields are left untouched.
+func (c *Context) bindTagged(rv reflect.Value, sources []bindSource) error {
+	rt := rv.Type()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		fieldType := rt.Field(i)
+
+		// Embedded structs contribute their fields
+		if fieldType.Anonymous && field.Kind() == reflect.Struct {
+			if err := c.bindTagged(field, sources); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.CanSet() {
+			continue
+		}
+
+		// A field is bound from the first source it is tagged with,
+		// when that source is being bound
+		tagged := false
+		for _, source := range bindSources {
+			raw, ok := fieldType.Tag.Lookup(source.tag)
+			if !ok || raw == "-" {
+				continue
+			}
+			tagged = true
+			if !hasBindSource(sources, source.tag) {
+				break
+			}
+
+			if err := c.bindField(field, fieldType, source, parseStructTag(raw)); err != nil {
+				return err
+			}
+			break
+		}
+
+		// Untagged nested structs group tagged fields
+		if !tagged && isNestedBindStruct(field.Type()) {
+			if err := c.bindTagged(field, sources); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasBindSource reports whether sources contains the source for tag
+func hasBindSource(sources []bindSource, tag string) bool {
+	for _, source := range sources {
+		if source.tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// isNestedBindStruct reports whether t is a struct whose fields are bound
+// individually, as opposed to a value type such as time.Time or a file
+func isNestedBindStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && t != multipartFileType &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// bindField binds a single field from source
+func (c *Context) bindField(field reflect.Value, fieldType reflect.StructField, source bindSource, tag StructTag) error {
+	name := tag.Name
+	if name == "" {
+		name = strings.ToLower(fieldType.Name)
+	}
+
+	// An empty value counts as missing, as in form binding
+	values := source.lookup(c, name)
+	if len(values) == 1 && values[0] == "" {
+		values = nil
+	}
+	if len(values) == 0 {
+		switch {
+		case tag.Default != "":
+			values = []string{tag.Default}
+		case tag.Required:
+			return fmt.Errorf("required %s %s is missing", source.kind, name)
+		default:
+			return nil
+		}
+	}
+
+	if (source.split || tag.Split) && isListType(field.Type()) {
+		values = splitListValues(values)
+	}
+
+	// Size constraints apply to each value
+	for _, value := range values {
+		if tag.MaxSize > 0 && int64(len(value)) > tag.MaxSize {
+			return fmt.Errorf("%s %s exceeds maximum size %d", source.kind, name, tag.MaxSize)
+		}
+		if tag.MinSize > 0 && int64(len(value)) < tag.MinSize {
+			return fmt.Errorf("%s %s below minimum size %d", source.kind, name, tag.MinSize)
+		}
+	}
+
+	if err := c.setFieldValues(field, values, name); err != nil {
+		return fmt.Errorf("failed to set field %s from %s %s: %w", fieldType.Name, source.kind, name, err)
+	}
+	return nil
+}
+
+// setFieldValues converts values to the type of field and assigns them
+// Scalar fields use the first value; slice fields take every value.
+func (c *Context) setFieldValues(field reflect.Value, values []string, name string) error {
+	switch {
+	case field.Kind() == reflect.Ptr:
+		value := reflect.New(field.Type().Elem())
+		if err := c.setFieldValues(value.Elem(), values, name); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+
+	case field.Type() == timeType:
+		return c.parseTimeField(field, values[0], name)
+
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(values[0])
+		if err != nil {
+			return fmt.Errorf("invalid duration value: %s", values[0])
+		}
+		field.SetInt(int64(d))
+		return nil
+
+	case reflect.PointerTo(field.Type()).Implements(textUnmarshalerType):
+		if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0])); err != nil {
+			return fmt.Errorf("invalid %s value: %s: %w", field.Type(), values[0], err)
+		}
+		return nil
+
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		field.SetBytes([]byte(values[0]))
+		return nil
+
+	case field.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := c.setFieldValues(slice.Index(i), []string{value}, name); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+
+	default:
+		return c.setScalarValue(field, values[0], field.Type())
+	}
+}
+
+// isListType reports whether t is bound element by element from several
+// values, as setFieldValues does for slices
+func isListType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// splitListValues splits comma-separated values into separate values
+// "1,2" and "1, 2" both become "1" and "2"; empty elements are dropped.
+func splitListValues(values []string) []string {
+	split := make([]string, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, ",") {
+			split = append(split, value)
+			continue
+		}
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
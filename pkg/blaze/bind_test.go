@@ -0,0 +1,417 @@
+package blaze_test
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+// bindLevel is bound through encoding.TextUnmarshaler
+type bindLevel int
+
+func (l *bindLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %s", text)
+	}
+	return nil
+}
+
+type bindPaging struct {
+	Page int `query:"page,default=1"`
+	Size int `query:"size,default=20"`
+}
+
+type bindFilter struct {
+	Owner  string   `query:"owner"`
+	Status []string `query:"status"`
+}
+
+type bindQueryRequest struct {
+	bindPaging
+
+	Name    string        `query:"name,required,minsize=2,maxsize=8"`
+	IDs     []int         `query:"id"`
+	Active  *bool         `query:"active"`
+	Limit   *int          `query:"limit"`
+	Since   time.Time     `query:"since"`
+	Until   *time.Time    `query:"until"`
+	Timeout time.Duration `query:"timeout,default=5s"`
+	Level   bindLevel     `query:"level"`
+	Raw     []byte        `query:"raw"`
+	Filter  bindFilter
+	Skipped string `query:"-"`
+	Plain   string
+}
+
+type bindHeaderRequest struct {
+	Tenant    string        `header:"X-Tenant-ID,required"`
+	Accept    []string      `header:"Accept"`
+	Forwarded []string      `header:"X-Forwarded-For,split"`
+	Retry     time.Duration `header:"Retry-After"`
+}
+
+type bindCookieRequest struct {
+	Session string   `cookie:"session,required"`
+	Theme   string   `cookie:"theme,default=light"`
+	Flags   []string `cookie:"flags,split"`
+	Raw     []string `cookie:"raw"`
+	Visits  *uint    `cookie:"visits"`
+}
+
+type bindParamsRequest struct {
+	Org string `param:"org"`
+	ID  int64  `param:"id,required"`
+}
+
+type bindAllRequest struct {
+	Org     string `param:"org"`
+	ID      int    `param:"id"`
+	Page    int    `query:"page,default=1"`
+	Tenant  string `header:"X-Tenant-ID"`
+	Session string `cookie:"session"`
+
+	// Bound from the path only, although the query has a value too
+	Scope string `param:"org" query:"scope"`
+}
+
+// bindRequest sends the request built by build to a route binding into
+// dest with bind and returns the binding error
+func bindRequest(t *testing.T, bind func(*blaze.Context, interface{}) error, dest interface{}, build func(*blazetest.Server) *blazetest.Request) error {
+	t.Helper()
+
+	var bindErr error
+	app := blaze.New()
+	app.GET("/orgs/:org/items/:id", func(c *blaze.Context) error {
+		bindErr = bind(c, dest)
+		return c.Text("ok")
+	})
+
+	build(blazetest.New(t, app)).Do().AssertStatus(http.StatusOK)
+	return bindErr
+}
+
+// get returns a request builder for path
+func get(path string) func(*blazetest.Server) *blazetest.Request {
+	return func(srv *blazetest.Server) *blazetest.Request {
+		return srv.Get(path)
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	active, limit := true, 0
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  bindQueryRequest
+	}{
+		{
+			name:  "defaults",
+			query: "name=ada",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Timeout: 5 * time.Second},
+		},
+		{
+			name:  "empty values use defaults",
+			query: "name=ada&page=&timeout=",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Timeout: 5 * time.Second},
+		},
+		{
+			name:  "embedded struct",
+			query: "name=ada&page=3&size=50",
+			want:  bindQueryRequest{bindPaging: bindPaging{3, 50}, Name: "ada", Timeout: 5 * time.Second},
+		},
+		{
+			name:  "repeated values",
+			query: "name=ada&id=1&id=2&id=3",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", IDs: []int{1, 2, 3}, Timeout: 5 * time.Second},
+		},
+		{
+			name:  "comma-separated values",
+			query: "name=ada&id=1,2,%203,",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", IDs: []int{1, 2, 3}, Timeout: 5 * time.Second},
+		},
+		{
+			name:  "repeated and comma-separated values",
+			query: "name=ada&id=1,2&id=3",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", IDs: []int{1, 2, 3}, Timeout: 5 * time.Second},
+		},
+		{
+			name:  "pointers",
+			query: "name=ada&active=true&limit=0",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Active: &active, Limit: &limit, Timeout: 5 * time.Second},
+		},
+		{
+			name:  "times and durations",
+			query: "name=ada&since=2024-01-02&until=2024-03-04T05:06:07Z&timeout=1m30s",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Since: since, Until: &until, Timeout: 90 * time.Second},
+		},
+		{
+			name:  "text unmarshaler",
+			query: "name=ada&level=high",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Level: 2, Timeout: 5 * time.Second},
+		},
+		{
+			name:  "bytes are not split",
+			query: "name=ada&raw=a,b",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Raw: []byte("a,b"), Timeout: 5 * time.Second},
+		},
+		{
+			name:  "nested struct",
+			query: "name=ada&owner=bob&status=open,closed",
+			want: bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Timeout: 5 * time.Second,
+				Filter: bindFilter{Owner: "bob", Status: []string{"open", "closed"}}},
+		},
+		{
+			name:  "untagged and ignored fields",
+			query: "name=ada&skipped=x&plain=x",
+			want:  bindQueryRequest{bindPaging: bindPaging{1, 20}, Name: "ada", Timeout: 5 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bindQueryRequest
+			if err := bindRequest(t, (*blaze.Context).BindQuery, &got, get("/orgs/acme/items/7?"+tt.query)); err != nil {
+				t.Fatalf("BindQuery: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BindQuery = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	var got bindParamsRequest
+	if err := bindRequest(t, (*blaze.Context).BindParams, &got, get("/orgs/acme/items/7")); err != nil {
+		t.Fatalf("BindParams: %v", err)
+	}
+	if want := (bindParamsRequest{Org: "acme", ID: 7}); got != want {
+		t.Errorf("BindParams = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindHeaders(t *testing.T) {
+	var got bindHeaderRequest
+	err := bindRequest(t, (*blaze.Context).BindHeaders, &got, func(srv *blazetest.Server) *blazetest.Request {
+		return srv.Get("/orgs/acme/items/7").
+			WithHeader("X-Tenant-ID", "t1").
+			WithHeader("Accept", "text/html, application/json").
+			WithHeader("X-Forwarded-For", "10.0.0.1, 10.0.0.2").
+			WithHeader("Retry-After", "2s")
+	})
+	if err != nil {
+		t.Fatalf("BindHeaders: %v", err)
+	}
+
+	// Header values are only split with the split option
+	want := bindHeaderRequest{
+		Tenant:    "t1",
+		Accept:    []string{"text/html, application/json"},
+		Forwarded: []string{"10.0.0.1", "10.0.0.2"},
+		Retry:     2 * time.Second,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BindHeaders = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindCookies(t *testing.T) {
+	var got bindCookieRequest
+	err := bindRequest(t, (*blaze.Context).BindCookies, &got, func(srv *blazetest.Server) *blazetest.Request {
+		return srv.Get("/orgs/acme/items/7").
+			WithCookie("session", "abc").
+			WithCookie("flags", "beta,dark").
+			WithCookie("raw", "a,b").
+			WithCookie("visits", "3")
+	})
+	if err != nil {
+		t.Fatalf("BindCookies: %v", err)
+	}
+
+	visits := uint(3)
+	want := bindCookieRequest{
+		Session: "abc",
+		Theme:   "light",
+		Flags:   []string{"beta", "dark"},
+		Raw:     []string{"a,b"},
+		Visits:  &visits,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BindCookies = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindAll(t *testing.T) {
+	var got bindAllRequest
+	err := bindRequest(t, (*blaze.Context).BindAll, &got, func(srv *blazetest.Server) *blazetest.Request {
+		return srv.Get("/orgs/acme/items/7").
+			WithQuery("page", "2").
+			WithQuery("scope", "query").
+			WithHeader("X-Tenant-ID", "t1").
+			WithCookie("session", "abc")
+	})
+	if err != nil {
+		t.Fatalf("BindAll: %v", err)
+	}
+
+	want := bindAllRequest{Org: "acme", ID: 7, Page: 2, Tenant: "t1", Session: "abc", Scope: "acme"}
+	if got != want {
+		t.Errorf("BindAll = %+v, want %+v", got, want)
+	}
+
+	// A field tagged for another source first is not bound from the query
+	var query bindAllRequest
+	if err := bindRequest(t, (*blaze.Context).BindQuery, &query, get("/orgs/acme/items/7?scope=query")); err != nil {
+		t.Fatalf("BindQuery: %v", err)
+	}
+	if query.Scope != "" || query.Page != 1 {
+		t.Errorf("BindQuery = %+v, want only the page default", query)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		bind  func(*blaze.Context, interface{}) error
+		dest  interface{}
+		build func(*blazetest.Server) *blazetest.Request
+		want  string
+	}{
+		{
+			name:  "required query parameter",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7"),
+			want:  "required query parameter name is missing",
+		},
+		{
+			name:  "empty required query parameter",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name="),
+			want:  "required query parameter name is missing",
+		},
+		{
+			name:  "maximum size",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=abcdefghi"),
+			want:  "query parameter name exceeds maximum size 8",
+		},
+		{
+			name:  "minimum size",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=a"),
+			want:  "query parameter name below minimum size 2",
+		},
+		{
+			name:  "invalid integer",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=ada&page=x"),
+			want:  "failed to set field Page from query parameter page: invalid integer value: x",
+		},
+		{
+			name:  "invalid slice element",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=ada&id=1,x"),
+			want:  "failed to set field IDs from query parameter id: invalid integer value: x",
+		},
+		{
+			name:  "invalid boolean pointer",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=ada&active=maybe"),
+			want:  "failed to set field Active from query parameter active: invalid boolean value: maybe",
+		},
+		{
+			name:  "invalid duration",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=ada&timeout=soon"),
+			want:  "failed to set field Timeout from query parameter timeout: invalid duration value: soon",
+		},
+		{
+			name:  "invalid text",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  &bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=ada&level=mid"),
+			want:  "failed to set field Level from query parameter level: invalid blaze_test.bindLevel value: mid: unknown level mid",
+		},
+		{
+			name:  "invalid path parameter",
+			bind:  (*blaze.Context).BindParams,
+			dest:  &bindParamsRequest{},
+			build: get("/orgs/acme/items/x"),
+			want:  "failed to set field ID from path parameter id: invalid integer value: x",
+		},
+		{
+			name: "required path parameter",
+			bind: (*blaze.Context).BindParams,
+			dest: &struct {
+				Team string `param:"team,required"`
+			}{},
+			build: get("/orgs/acme/items/7"),
+			want:  "required path parameter team is missing",
+		},
+		{
+			name:  "required header",
+			bind:  (*blaze.Context).BindHeaders,
+			dest:  &bindHeaderRequest{},
+			build: get("/orgs/acme/items/7"),
+			want:  "required header X-Tenant-ID is missing",
+		},
+		{
+			name:  "required cookie",
+			bind:  (*blaze.Context).BindCookies,
+			dest:  &bindCookieRequest{},
+			build: get("/orgs/acme/items/7"),
+			want:  "required cookie session is missing",
+		},
+		{
+			name:  "first failing source",
+			bind:  (*blaze.Context).BindAll,
+			dest:  &bindParamsRequest{},
+			build: get("/orgs/acme/items/x"),
+			want:  "failed to set field ID from path parameter id: invalid integer value: x",
+		},
+		{
+			name:  "not a pointer",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  bindQueryRequest{},
+			build: get("/orgs/acme/items/7?name=ada"),
+			want:  "binding destination must be a pointer",
+		},
+		{
+			name:  "not a struct",
+			bind:  (*blaze.Context).BindQuery,
+			dest:  new(int),
+			build: get("/orgs/acme/items/7?name=ada"),
+			want:  "binding destination must be a pointer to struct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bindRequest(t, tt.bind, tt.dest, tt.build)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -92,7 +92,7 @@ func DefaultBodyLimitConfig() BodyLimitConfig {
 //	app.Use(BodyLimit(5 * 1024 * 1024))
 //
 //	// Apply to specific routes
-//	app.POST("/api/upload", uploadHandler, BodyLimit(10 * 1024 * 1024))
+//	app.POST("/api/upload", uploadHandler, WithBodyLimit(10 * 1024 * 1024))
 func BodyLimit(maxSize int64) MiddlewareFunc {
 	config := DefaultBodyLimitConfig()
 	config.MaxSize = maxSize
@@ -152,7 +152,7 @@ func BodyLimitWithConfig(config BodyLimitConfig) MiddlewareFunc {
 			}
 
 			// Check actual body size (for chunked encoding)
-			bodySize := int64(len(c.Body()))
+			bodySize := c.bodySizeWithin(config.MaxSize)
 			if bodySize > config.MaxSize {
 				return c.Status(413).JSON(Map{
 					"error":      config.ErrorMessage,
@@ -233,7 +233,9 @@ func BodyLimitGB(gb int) MiddlewareFunc {
 
 // BodyLimitForRoute creates a body limit middleware for specific routes.
 // This allows different body size limits for different URL paths without
-// affecting global configuration.
+// affecting global configuration. Paths are compared with the exact request
+// path; prefer the WithBodyLimit route option, which is declared on the
+// route itself and also covers parameterized patterns.
 //
 // Parameters:
 //   - maxSize: Maximum body size in bytes
@@ -286,7 +288,7 @@ func BodyLimitForRoute(maxSize int64, paths ...string) MiddlewareFunc {
 				})
 			}
 
-			bodySize := int64(len(c.Body()))
+			bodySize := c.bodySizeWithin(config.MaxSize)
 			if bodySize > config.MaxSize {
 				return c.Status(413).JSON(Map{
 					"error":      config.ErrorMessage,
@@ -357,7 +359,7 @@ func BodyLimitByContentType(limits map[string]int64) MiddlewareFunc {
 				})
 			}
 
-			bodySize := int64(len(c.Body()))
+			bodySize := c.bodySizeWithin(maxSize)
 			if bodySize > maxSize {
 				return c.Status(413).JSON(Map{
 					"error":       "Request body too large for content type",
@@ -398,7 +400,7 @@ func (c *Context) ValidateBodySize(maxSize int64) error {
 		return fmt.Errorf("content length %d exceeds maximum %d", contentLength, maxSize)
 	}
 
-	bodySize := int64(len(c.Body()))
+	bodySize := c.bodySizeWithin(maxSize)
 	if bodySize > maxSize {
 		return fmt.Errorf("body size %d exceeds maximum %d", bodySize, maxSize)
 	}
@@ -406,6 +408,17 @@ func (c *Context) ValidateBodySize(maxSize int64) error {
 	return nil
 }
 
+// bodySizeWithin returns the size of the request body for a limit check
+// A body streamed to a WithStreamingBody route is not read here; maxSize
+// is enforced while the handler reads it instead.
+func (c *Context) bodySizeWithin(maxSize int64) int64 {
+	if c.body != nil {
+		c.body.lowerLimit(maxSize)
+		return 0
+	}
+	return int64(len(c.Body()))
+}
+
 // GetBodySize returns the size of the request body.
 // This is a utility method for handlers that need to check body size.
 //
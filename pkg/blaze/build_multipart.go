@@ -54,6 +54,11 @@ type StructTag struct {
 	// Default is the default value to use if field is empty or missing.
 	// The value is parsed according to the field's type.
 	Default string
+
+	// Split fills slice fields from comma-separated values.
+	// Query parameters are always split; headers and cookies only with
+	// this option, since their values may contain commas.
+	Split bool
 }
 
 // parseStructTag parses a struct field tag into a StructTag configuration.
@@ -96,6 +101,8 @@ func parseStructTag(tag string) StructTag {
 		switch {
 		case part == "required":
 			result.Required = true
+		case part == "split":
+			result.Split = true
 		case strings.HasPrefix(part, "maxsize="):
 			if size, err := strconv.ParseInt(part[8:], 10, 64); err == nil {
 				result.MaxSize = size
@@ -214,6 +221,18 @@ func (c *Context) bindMultipartFormToStruct(form *MultipartForm, v interface{})
 		return errors.New("binding destination must be a pointer to struct")
 	}
 
+	// Bracket and dot notation keys become nested paths
+	form, err := nestForm(form, GetFormBindingConfig())
+	if err != nil {
+		return err
+	}
+
+	return c.bindFormStruct(form, rv, "")
+}
+
+// bindFormStruct binds the fields of the struct rv from the form keys
+// under prefix ("" for top-level fields, "user.address" for nested ones)
+func (c *Context) bindFormStruct(form *MultipartForm, rv reflect.Value, prefix string) error {
 	rt := rv.Type()
 
 	// Iterate through struct fields
@@ -237,6 +256,7 @@ func (c *Context) bindMultipartFormToStruct(form *MultipartForm, v interface{})
 		if fieldName == "-" {
 			continue
 		}
+		fieldName = joinFormKey(prefix, fieldName)
 
 		// Handle different field types
 		switch field.Kind() {
@@ -270,18 +290,26 @@ func (c *Context) bindMultipartFormToStruct(form *MultipartForm, v interface{})
 				return fmt.Errorf("failed to set slice field %s: %w", fieldType.Name, err)
 			}
 
+		case reflect.Map:
+			if err := c.setMapField(form, field, fieldName, tag); err != nil {
+				return fmt.Errorf("failed to set map field %s: %w", fieldType.Name, err)
+			}
+
 		case reflect.Ptr:
 			if err := c.setPointerField(form, field, fieldName, tag); err != nil {
 				return fmt.Errorf("failed to set pointer field %s: %w", fieldType.Name, err)
 			}
 
 		case reflect.Struct:
-			if err := c.setStructField(form, field, fieldName, tag); err != nil {
+			if err := c.setStructField(form, field, fieldName, prefix, tag); err != nil {
 				return fmt.Errorf("failed to set struct field %s: %w", fieldType.Name, err)
 			}
 
 		default:
-			return fmt.Errorf("unsupported field type %s for field %s", field.Kind(), fieldType.Name)
+			// Fields the form does not mention are left untouched
+			if form.HasValue(fieldName) || form.HasFile(fieldName) || form.hasNested(fieldName) {
+				return fmt.Errorf("unsupported field type %s for field %s", field.Kind(), fieldType.Name)
+			}
 		}
 	}
 
@@ -610,9 +638,20 @@ func (c *Context) setBoolField(form *MultipartForm, field reflect.Value, fieldNa
 func (c *Context) setSliceField(form *MultipartForm, field reflect.Value, fieldName string, tag StructTag) error {
 	elemType := field.Type().Elem()
 
+	// Indexed elements: items[0][sku]=A or items.0.sku=A
+	if isNestedBindStruct(elemType) || (elemType.Kind() == reflect.Ptr && isNestedBindStruct(elemType.Elem())) {
+		return c.setStructSliceField(form, field, fieldName, tag)
+	}
+
 	// Handle file slices specially
 	if elemType == reflect.TypeOf(&MultipartFile{}) {
 		files := form.GetFiles(fieldName)
+		if files == nil {
+			var err error
+			if files, err = form.indexedFiles(fieldName); err != nil {
+				return err
+			}
+		}
 		if files == nil {
 			if tag.Required {
 				return fmt.Errorf("required file field %s is missing", fieldName)
@@ -630,6 +669,11 @@ func (c *Context) setSliceField(form *MultipartForm, field reflect.Value, fieldN
 	}
 
 	values := form.GetValues(fieldName)
+	if indexed, err := form.indexedValues(fieldName); err != nil {
+		return err
+	} else {
+		values = append(values, indexed...)
+	}
 	if values == nil {
 		if tag.Required {
 			return fmt.Errorf("required field %s is missing", fieldName)
@@ -683,19 +727,19 @@ func (c *Context) setScalarValue(field reflect.Value, value string, fieldType re
 	case reflect.String:
 		field.SetString(value)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		intVal, err := strconv.ParseInt(value, 10, 64)
+		intVal, err := strconv.ParseInt(value, 10, field.Type().Bits())
 		if err != nil {
 			return fmt.Errorf("invalid integer value: %s", value)
 		}
 		field.SetInt(intVal)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		uintVal, err := strconv.ParseUint(value, 10, 64)
+		uintVal, err := strconv.ParseUint(value, 10, field.Type().Bits())
 		if err != nil {
 			return fmt.Errorf("invalid unsigned integer value: %s", value)
 		}
 		field.SetUint(uintVal)
 	case reflect.Float32, reflect.Float64:
-		floatVal, err := strconv.ParseFloat(value, 64)
+		floatVal, err := strconv.ParseFloat(value, field.Type().Bits())
 		if err != nil {
 			return fmt.Errorf("invalid float value: %s", value)
 		}
@@ -725,64 +769,32 @@ func (c *Context) BindForm(v interface{}) error {
 }
 
 // bindURLEncodedForm binds URL-encoded form data to struct
+// Values come from the body and the query string, with query values taking
+// precedence as in FormValue. Binding follows the multipart rules, including
+// bracket and dot notation for nested fields.
 func (c *Context) bindURLEncodedForm(v interface{}) error {
-	rv := reflect.ValueOf(v)
-
-	if rv.Kind() != reflect.Ptr {
-		return errors.New("binding destination must be a pointer")
+	if err := c.bufferBody(); err != nil {
+		return err
 	}
 
-	rv = rv.Elem()
-	if rv.Kind() != reflect.Struct {
-		return errors.New("binding destination must be a pointer to struct")
+	form := &MultipartForm{
+		Value: make(map[string][]string),
+		File:  make(map[string][]*MultipartFile),
 	}
 
-	rt := rv.Type()
+	c.RequestCtx.PostArgs().VisitAll(func(key, value []byte) {
+		form.Value[string(key)] = append(form.Value[string(key)], string(value))
+	})
 
-	for i := 0; i < rv.NumField(); i++ {
-		field := rv.Field(i)
-		fieldType := rt.Field(i)
-
-		if !field.CanSet() {
-			continue
-		}
-
-		tag := parseStructTag(fieldType.Tag.Get("form"))
-		fieldName := tag.Name
-		if fieldName == "" {
-			fieldName = strings.ToLower(fieldType.Name)
-		}
-
-		if fieldName == "-" {
-			continue
-		}
-
-		// Get form value
-		value := string(c.RequestCtx.FormValue(fieldName))
-
-		// Handle required fields
-		if tag.Required && value == "" {
-			if tag.Default != "" {
-				value = tag.Default
-			} else {
-				return fmt.Errorf("required field %s is missing", fieldName)
-			}
-		}
-
-		// Set default if empty
-		if value == "" && tag.Default != "" {
-			value = tag.Default
-		}
-
-		// Set the field value
-		if value != "" || field.Kind() == reflect.Bool {
-			if err := c.setScalarValue(field, value, field.Type()); err != nil {
-				return fmt.Errorf("failed to set field %s: %w", fieldType.Name, err)
-			}
-		}
+	query := make(map[string][]string)
+	c.RequestCtx.QueryArgs().VisitAll(func(key, value []byte) {
+		query[string(key)] = append(query[string(key)], string(value))
+	})
+	for key, values := range query {
+		form.Value[key] = values
 	}
 
-	return nil
+	return c.bindMultipartFormToStruct(form, v)
 }
 
 // setStructField sets struct field value (like time.Time or nested structs).
@@ -790,7 +802,8 @@ func (c *Context) bindURLEncodedForm(v interface{}) error {
 //
 // Struct Types:
 //   - time.Time: Parses using multiple common formats
-//   - Nested structs: Recursive binding with form tags
+//   - Nested structs: Bound from user[address][city] or user.address.city
+//     keys, or from flat keys when the form has no nested keys for them
 //   - Custom types: Requires form tags on nested fields
 //
 // Time Format Support:
@@ -806,6 +819,7 @@ func (c *Context) bindURLEncodedForm(v interface{}) error {
 //   - form: Multipart form data source
 //   - field: Reflect value of the field to set
 //   - fieldName: Name of the form field to read from
+//   - prefix: Key prefix of the struct containing the field
 //   - tag: Parsed struct tag with validation rules
 //
 // Returns:
@@ -816,34 +830,38 @@ func (c *Context) bindURLEncodedForm(v interface{}) error {
 //	// Struct field: CreatedAt time.Time `form:"created"`
 //	// Form data: created=2024-01-15T10:30:00Z
 //	// Result: field contains parsed time value
-func (c *Context) setStructField(form *MultipartForm, field reflect.Value, fieldName string, tag StructTag) error {
-	value := form.GetValue(fieldName)
+func (c *Context) setStructField(form *MultipartForm, field reflect.Value, fieldName, prefix string, tag StructTag) error {
+	// Handle time.Time specially with comprehensive format support
+	if field.Type() == timeType {
+		value := form.GetValue(fieldName)
 
-	if value == "" {
-		if tag.Required {
-			if tag.Default != "" {
+		if value == "" {
+			if tag.Required {
+				if tag.Default != "" {
+					value = tag.Default
+				} else {
+					return fmt.Errorf("required field %s is missing", fieldName)
+				}
+			} else if tag.Default != "" {
 				value = tag.Default
 			} else {
-				return fmt.Errorf("required field %s is missing", fieldName)
+				return nil
 			}
-		} else if tag.Default != "" {
-			value = tag.Default
-		} else {
-			return nil
 		}
-	}
 
-	// Handle time.Time specially with comprehensive format support
-	if field.Type() == reflect.TypeOf(time.Time{}) {
 		return c.parseTimeField(field, value, fieldName)
 	}
 
-	// For other struct types, try to recursively bind if it has form fields
-	if field.CanAddr() {
-		return c.bindMultipartFormToStruct(form, field.Addr().Interface())
+	// Nested keys (user[address][city], user.address.city) bind under the
+	// field name; otherwise the struct's fields are read from the same
+	// level as the struct itself
+	if form.hasNested(fieldName) {
+		return c.bindFormStruct(form, field, fieldName)
 	}
-
-	return fmt.Errorf("unsupported struct type %s for field %s", field.Type(), fieldName)
+	if tag.Required {
+		return fmt.Errorf("required field %s is missing", fieldName)
+	}
+	return c.bindFormStruct(form, field, prefix)
 }
 
 // parseTimeField parses a time string into a time.Time field.
@@ -1008,6 +1026,22 @@ func (c *Context) setPointerField(form *MultipartForm, field reflect.Value, fiel
 		return nil
 	}
 
+	// Nested structs are allocated only when the form has keys for them
+	if isNestedBindStruct(elemType) {
+		if !form.hasNested(fieldName) {
+			if tag.Required {
+				return fmt.Errorf("required field %s is missing", fieldName)
+			}
+			return nil
+		}
+		newElem := reflect.New(elemType)
+		if err := c.bindFormStruct(form, newElem.Elem(), fieldName); err != nil {
+			return err
+		}
+		field.Set(newElem)
+		return nil
+	}
+
 	value := form.GetValue(fieldName)
 	if value == "" {
 		if tag.Required {
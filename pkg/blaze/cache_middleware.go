@@ -242,7 +242,10 @@ func Cache(opts *CacheOptions) MiddlewareFunc {
 // CacheResponse caches responses for specific routes with custom TTL
 // Example:
 //
-//	app.GET("/api/users", handler, blaze.CacheResponse(5*time.Minute))
+//	app.GET("/api/users", handler, blaze.WithMiddleware(blaze.CacheResponse(5*time.Minute)))
+//
+//	// Or declare the cache as a route policy
+//	app.GET("/api/users", handler, blaze.WithCache(5*time.Minute))
 func CacheResponse(ttl time.Duration, opts ...*CacheOptions) MiddlewareFunc {
 	var cacheOpts *CacheOptions
 	if len(opts) > 0 {
@@ -463,6 +466,11 @@ func serveCachedResponse(c *Context, entry *CacheEntry, opts *CacheOptions, notM
 
 // shouldCacheResponse determines if a response should be cached
 func shouldCacheResponse(c *Context, opts *CacheOptions) bool {
+	// Streamed responses (SSE) are never complete when the handler returns
+	if c.Response().IsBodyStream() {
+		return false
+	}
+
 	// Check custom should cache function
 	if opts.ShouldCache != nil {
 		return opts.ShouldCache(c)
@@ -0,0 +1,145 @@
+package blaze
+
+import "slices"
+
+// ==================== Middleware Chains ====================
+
+// Route handlers are wrapped in their middleware once, when the route is
+// registered, rather than on every request. A compiled chain contains the
+// route's policies, group and route middleware and the outer middleware of
+// the app owning the router: global middleware, plus host middleware for
+// virtual hosts. Use rebuilds the chains so middleware added after routes
+// still applies to them.
+//
+// Chain Order (outermost first):
+//  1. Global and host middleware
+//  2. Error handling of the longest matching group scope (Group.UseErrorHandler)
+//  3. Request policies: CORS, rate limit, body limit, timeout
+//  4. Route middleware (WithMiddleware)
+//  5. Group middleware
+//  6. Response cache (WithCache)
+//  7. Handler
+
+// compile builds the handler chain of a route in table t
+// The table's scopes and outer middleware must be final; the caller
+// stores the result in t.chains before publishing t.
+func (r *Router) compile(t *routeTable, route *Route) HandlerFunc {
+	handler := route.endpoint()
+	for i := len(route.Middleware) - 1; i >= 0; i-- {
+		handler = route.Middleware[i](handler)
+	}
+	for i := len(route.policies.request) - 1; i >= 0; i-- {
+		handler = route.policies.request[i](handler)
+	}
+	if errors := t.scopeErrors(route.Pattern, r.config.CaseSensitive); errors != nil {
+		handler = errors(handler)
+	}
+	return t.wrapHandler(handler)
+}
+
+// addChain compiles the chain of a new route into unpublished table t
+func (t *routeTable) addChain(r *Router, route *Route) {
+	route.index = len(t.chains)
+	t.chains = append(t.chains, r.compile(t, route))
+}
+
+// endpoint returns Handler wrapped in the response cache and group middleware
+func (r *Route) endpoint() HandlerFunc {
+	handler := r.Handler
+	if r.policies.cache != nil {
+		handler = r.policies.cache(handler)
+	}
+	for i := len(r.groupMiddleware) - 1; i >= 0; i-- {
+		handler = r.groupMiddleware[i](handler)
+	}
+	return handler
+}
+
+// setOuter attaches the router to the middleware of its app
+// Existing chains are rebuilt with the new outer middleware.
+func (r *Router) setOuter(outer func() MiddlewareFunc) {
+	r.outer = outer
+	r.recompile()
+}
+
+// recompile rebuilds the chain of every route after the outer middleware changed
+// The rebuilt chains are published in a new table, so requests in flight
+// keep the chains they started with.
+func (r *Router) recompile() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.table.Load().clone()
+	r.recompileLocked(t)
+	r.table.Store(t)
+}
+
+// recompileLocked rebuilds the chains of table t, which must not be
+// published yet
+// The caller must hold r.mu.
+func (r *Router) recompileLocked(t *routeTable) {
+	t.wrap = nil
+	if r.outer != nil {
+		t.wrap = r.outer()
+	}
+
+	// Published tables share the old slice, so fill a new one
+	chains := make([]HandlerFunc, len(t.chains))
+	for _, route := range t.routes {
+		chains[route.index] = r.compile(t, route)
+	}
+
+	// Merged master routes only exist in the tree
+	for _, entry := range t.entries {
+		if entry.method == "*" {
+			chains[entry.route.index] = r.compile(t, entry.route)
+		}
+	}
+	t.chains = chains
+}
+
+// wrapHandler wraps handler in the outer middleware the table was compiled with
+// Used for route chains and for handlers that are not routes (404, 405,
+// OPTIONS and canonical redirects).
+func (t *routeTable) wrapHandler(handler HandlerFunc) HandlerFunc {
+	if t.wrap == nil {
+		return handler
+	}
+	return t.wrap(handler)
+}
+
+// chainMiddleware combines middleware into one, the first being outermost
+func chainMiddleware(middleware []MiddlewareFunc) MiddlewareFunc {
+	return func(handler HandlerFunc) HandlerFunc {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			handler = middleware[i](handler)
+		}
+		return handler
+	}
+}
+
+// outerMiddleware returns a copy of the app's global middleware as one
+// middleware
+func (a *App) outerMiddleware() MiddlewareFunc {
+	return chainMiddleware(slices.Clone(a.middleware))
+}
+
+// compile wraps the mounted app in outer and publishes the chain
+func (m *mountedApp) compile(outer MiddlewareFunc) {
+	chain := outer(m.serve)
+	m.chain.Store(&chain)
+}
+
+// compileChains rebuilds every compiled chain of the app
+// Called when global middleware is added
+func (a *App) compileChains() {
+	a.router.recompile()
+	for _, vh := range a.hosts {
+		vh.router.recompile()
+	}
+
+	outer := a.outerMiddleware()
+	for _, m := range a.mounts {
+		m.compile(outer)
+	}
+}
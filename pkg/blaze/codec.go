@@ -0,0 +1,470 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// ==================== Codecs ====================
+
+// Codec encodes and decodes request and response bodies of one format
+// Codecs are registered on the App with RegisterCodec and selected by
+// Bind from the Content-Type header and by Negotiate from the Accept
+// header.
+//
+// Built-in Codecs:
+//   - JSON: application/json
+//   - XML: application/xml, text/xml
+//   - YAML: application/yaml, application/x-yaml, text/yaml
+//   - MessagePack: application/msgpack, application/vnd.msgpack, application/x-msgpack
+//   - CBOR: application/cbor
+//   - Protobuf: application/x-protobuf, application/protobuf, application/vnd.google.protobuf
+//
+// Example:
+//
+//	type textCodec struct{}
+//
+//	func (textCodec) MediaTypes() []string { return []string{"text/plain"} }
+//
+//	func (textCodec) Marshal(v interface{}) ([]byte, error) {
+//	    s, ok := v.(fmt.Stringer)
+//	    if !ok {
+//	        return nil, blaze.ErrCodecUnsupported
+//	    }
+//	    return []byte(s.String()), nil
+//	}
+//
+//	func (textCodec) Unmarshal(data []byte, v interface{}) error {
+//	    s, ok := v.(*string)
+//	    if !ok {
+//	        return blaze.ErrCodecUnsupported
+//	    }
+//	    *s = string(data)
+//	    return nil
+//	}
+//
+//	app.RegisterCodec(textCodec{})
+type Codec interface {
+	// MediaTypes returns the media types the codec handles, without
+	// parameters ("application/msgpack")
+	MediaTypes() []string
+
+	// Marshal encodes v
+	// Returns an error wrapping ErrCodecUnsupported when the codec cannot
+	// represent values of this type, so Negotiate can fall back to the
+	// next acceptable format.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data into v, a pointer
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// ErrCodecUnsupported is returned by codecs for values they cannot encode
+var ErrCodecUnsupported = errors.New("codec does not support this value")
+
+// codecEntry maps a media type to its codec
+type codecEntry struct {
+	mediaType string
+	codec     Codec
+}
+
+// defaultCodecs lists the built-in codecs in order of preference
+var defaultCodecs = addCodec(nil,
+	jsonCodec{},
+	xmlCodec{},
+	yamlCodec{},
+	msgpackCodec{},
+	cborCodec{},
+	protobufCodec{},
+)
+
+// structuredSuffixes maps structured syntax suffixes ("application/vnd.api+json")
+// to the media type whose codec decodes them
+var structuredSuffixes = map[string]string{
+	"json": "application/json",
+	"xml":  "application/xml",
+	"yaml": "application/yaml",
+	"cbor": "application/cbor",
+}
+
+// formMediaTypes are bound by BindForm rather than a codec
+var formMediaTypes = []string{"application/x-www-form-urlencoded", "multipart/form-data"}
+
+// addCodec registers codecs in list under all their media types
+// A codec for an already listed media type replaces the previous one in
+// place, so the order of preference is kept.
+func addCodec(list []codecEntry, codecs ...Codec) []codecEntry {
+	for _, codec := range codecs {
+		if len(codec.MediaTypes()) == 0 {
+			panic(fmt.Sprintf("blaze: codec %T has no media types", codec))
+		}
+
+		for _, mediaType := range codec.MediaTypes() {
+			list = setCodecEntry(list, codecEntry{mediaType: mediaTypeOf([]byte(mediaType)), codec: codec})
+		}
+	}
+	return list
+}
+
+// setCodecEntry adds entry to list or replaces the entry of its media type
+func setCodecEntry(list []codecEntry, entry codecEntry) []codecEntry {
+	for i := range list {
+		if list[i].mediaType == entry.mediaType {
+			list[i] = entry
+			return list
+		}
+	}
+	return append(list, entry)
+}
+
+// findCodec returns the codec for mediaType, nil if there is none
+// Media types with a structured syntax suffix ("application/problem+json")
+// fall back to the codec of the suffix.
+func findCodec(list []codecEntry, mediaType string) Codec {
+	for _, entry := range list {
+		if entry.mediaType == mediaType {
+			return entry.codec
+		}
+	}
+
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		if base, ok := structuredSuffixes[mediaType[i+1:]]; ok {
+			return findCodec(list, base)
+		}
+	}
+	return nil
+}
+
+// codecMediaTypes lists the media types of list in order of preference
+func codecMediaTypes(list []codecEntry) []string {
+	mediaTypes := make([]string, len(list))
+	for i, entry := range list {
+		mediaTypes[i] = entry.mediaType
+	}
+	return mediaTypes
+}
+
+// RegisterCodec adds a codec for request and response bodies
+// The codec is used for each of its media types, replacing the built-in
+// codec of the same media type. Codecs registered on an app are also used
+// by the apps mounted on it, unless those register their own.
+//
+// Media types new to the app are offered after the built-in ones when the
+// Accept header has no preference.
+//
+// Parameters:
+//   - codec: Codec to register
+//
+// Returns:
+//   - *App: App instance for method chaining
+//
+// Example:
+//
+//	app.RegisterCodec(textCodec{})
+func (a *App) RegisterCodec(codec Codec) *App {
+	a.codecsMu.Lock()
+	defer a.codecsMu.Unlock()
+
+	a.codecs = addCodec(a.codecs, codec)
+	return a
+}
+
+// codecList returns the codecs available to a in order of preference:
+// built-in codecs, overridden or extended by the apps it is mounted on and
+// then by a itself
+// The built-in JSON codec uses the app's JSON engine.
+func (a *App) codecList() []codecEntry {
+	list := a.registeredCodecs()
+	if !a.hasJSONEngine() {
+		return list
+	}
+
+	engine := jsonCodec{encoder: a.JSONEncoder(), decoder: a.JSONDecoder()}
+	withEngine := make([]codecEntry, len(list))
+	for i, entry := range list {
+		if _, ok := entry.codec.(jsonCodec); ok {
+			entry.codec = engine
+		}
+		withEngine[i] = entry
+	}
+	return withEngine
+}
+
+// registeredCodecs merges the built-in codecs with the codecs registered
+// on a and the apps it is mounted on
+func (a *App) registeredCodecs() []codecEntry {
+	if a == nil {
+		return defaultCodecs
+	}
+	list := a.parent.registeredCodecs()
+
+	a.codecsMu.RLock()
+	defer a.codecsMu.RUnlock()
+
+	if len(a.codecs) == 0 {
+		return list
+	}
+
+	merged := append([]codecEntry(nil), list...)
+	for _, entry := range a.codecs {
+		merged = setCodecEntry(merged, entry)
+	}
+	return merged
+}
+
+// codecs returns the codecs of the app serving the request
+func (c *Context) codecs() []codecEntry {
+	app, _ := c.Locals("__app__").(*App)
+	return app.codecList()
+}
+
+// ==================== Content Negotiation ====================
+
+// Negotiate sends data in the format the Accept header prefers
+// Formats are ranked by q-value and specificity of the matching media
+// range; ties keep the order of the registered codecs, with JSON first.
+// A missing Accept header selects JSON. Codecs that cannot encode the
+// value (XML for maps, protobuf for non-messages) are skipped in favor of
+// the next acceptable format.
+//
+// Sets "Vary: Accept" so caches keep the formats apart.
+//
+// Parameters:
+//   - status: HTTP status code
+//   - data: Value to encode
+//
+// Returns:
+//   - error: 406 HTTPError, sent as problem details listing the available
+//     media types, when no acceptable codec can encode data, encoding
+//     error otherwise
+//
+// Example:
+//
+//	// Accept: application/msgpack, application/json;q=0.5
+//	return c.Negotiate(200, user) // MessagePack
+func (c *Context) Negotiate(status int, data interface{}) error {
+	codecs := c.codecs()
+	c.RequestCtx.Response.Header.Add("Vary", "Accept")
+
+	accept := string(c.RequestCtx.Request.Header.Peek("Accept"))
+	for _, mediaType := range acceptedTypes(accept, codecMediaTypes(codecs)) {
+		body, err := findCodec(codecs, mediaType).Marshal(data)
+		if errors.Is(err, ErrCodecUnsupported) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		c.Status(status)
+		c.SetContentType(contentTypeOf(mediaType))
+		c.SetBody(body)
+		return nil
+	}
+
+	return NewHTTPError(
+		http.StatusNotAcceptable,
+		ErrCodeNotAcceptable,
+		"No acceptable response format",
+	).WithDetails(Map{"accept": accept, "available": codecMediaTypes(codecs)}).AsProblem()
+}
+
+// unsupportedMediaType returns the 415 error for a body of mediaType,
+// sent as problem details
+func unsupportedMediaType(mediaType string, codecs []codecEntry) *HTTPError {
+	message := "Unsupported content type: " + mediaType
+	if mediaType == "" {
+		message = "Request body has no Content-Type"
+	}
+
+	supported := append(codecMediaTypes(codecs), formMediaTypes...)
+	return NewHTTPError(http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType, message).
+		WithDetails(Map{"content_type": mediaType, "supported": supported}).
+		AsProblem()
+}
+
+// acceptedTypes returns the offers the Accept header accepts, preferred
+// first
+// Each offer gets the quality of the most specific matching media range;
+// ties keep the order of offers. A missing Accept header accepts all
+// offers in order.
+func acceptedTypes(accept string, offers []string) []string {
+	if strings.TrimSpace(accept) == "" {
+		return offers
+	}
+
+	type ranked struct {
+		mediaType string
+		quality   float64
+	}
+	var accepted []ranked
+	for _, offer := range offers {
+		if quality := acceptQuality(accept, offer); quality > 0 {
+			accepted = append(accepted, ranked{offer, quality})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	mediaTypes := make([]string, len(accepted))
+	for i, offer := range accepted {
+		mediaTypes[i] = offer.mediaType
+	}
+	return mediaTypes
+}
+
+// contentTypeOf returns the Content-Type header for mediaType, with a
+// charset for text formats
+func contentTypeOf(mediaType string) string {
+	base := mediaType
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		base = structuredSuffixes[mediaType[i+1:]]
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		base == "application/json", base == "application/xml",
+		base == "application/yaml", base == "application/x-yaml":
+		return mediaType + "; charset=utf-8"
+	default:
+		return mediaType
+	}
+}
+
+// ==================== Built-in Codecs ====================
+
+// maxDecodeDepth limits the nesting of MessagePack and CBOR bodies and of
+// YAML specifications, so hostile input cannot exhaust the stack
+const maxDecodeDepth = 1000
+
+// jsonCodec encodes JSON with the App's JSON engine
+// The zero value, used by the default codecs, uses the default engine.
+type jsonCodec struct {
+	encoder JSONEncoder
+	decoder JSONDecoder
+}
+
+func (jsonCodec) MediaTypes() []string {
+	return []string{"application/json"}
+}
+
+func (j jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	if j.encoder == nil {
+		return defaultJSONEngine.Marshal(v)
+	}
+	return j.encoder.Marshal(v)
+}
+
+func (j jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	if j.decoder == nil {
+		return defaultJSONEngine.Unmarshal(data, v)
+	}
+	return j.decoder.Unmarshal(data, v)
+}
+
+// xmlCodec encodes XML with encoding/xml
+// Maps and other types encoding/xml cannot represent are unsupported.
+type xmlCodec struct{}
+
+func (xmlCodec) MediaTypes() []string {
+	return []string{"application/xml", "text/xml"}
+}
+
+func (xmlCodec) Marshal(v interface{}) ([]byte, error) {
+	data, err := xml.Marshal(v)
+	var unsupported *xml.UnsupportedTypeError
+	if errors.As(err, &unsupported) {
+		return nil, fmt.Errorf("%w: %v", ErrCodecUnsupported, err)
+	}
+	return data, err
+}
+
+func (xmlCodec) Unmarshal(data []byte, v interface{}) error {
+	return xml.Unmarshal(data, v)
+}
+
+// yamlCodec encodes YAML with gopkg.in/yaml.v3
+// Struct fields are named by their yaml tags, or their lowercased names.
+// Values keep their types, so timestamps decode into time.Time and
+// mappings with non-string keys into map[interface{}]interface{}.
+type yamlCodec struct{}
+
+func (yamlCodec) MediaTypes() []string {
+	return []string{"application/yaml", "application/x-yaml", "text/yaml"}
+}
+
+func (yamlCodec) Marshal(v interface{}) (data []byte, err error) {
+	// yaml.v3 panics on types it cannot encode, such as channels
+	defer func() {
+		if r := recover(); r != nil {
+			data, err = nil, fmt.Errorf("yaml: %v", r)
+		}
+	}()
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (yamlCodec) Unmarshal(data []byte, v interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+
+	// Bodies hold a single document
+	var next yaml.Node
+	if err := dec.Decode(&next); err != io.EOF {
+		return errors.New("yaml: unexpected document after value")
+	}
+	return nil
+}
+
+// protobufCodec encodes protobuf messages
+// Messages generated by protoc-gen-go (proto.Message) are encoded with
+// google.golang.org/protobuf; messages that marshal themselves, as
+// generated by gogo/protobuf or vtprotobuf, with their own methods.
+type protobufCodec struct{}
+
+func (protobufCodec) MediaTypes() []string {
+	return []string{"application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf"}
+}
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	switch msg := v.(type) {
+	case proto.Message:
+		return proto.Marshal(msg)
+	case interface{ Marshal() ([]byte, error) }:
+		return msg.Marshal()
+	default:
+		return nil, fmt.Errorf("%w: %T is not a protobuf message", ErrCodecUnsupported, v)
+	}
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	switch msg := v.(type) {
+	case proto.Message:
+		return proto.Unmarshal(data, msg)
+	case interface{ Unmarshal([]byte) error }:
+		return msg.Unmarshal(data)
+	default:
+		return fmt.Errorf("%w: %T is not a protobuf message", ErrCodecUnsupported, v)
+	}
+}
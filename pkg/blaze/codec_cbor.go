@@ -0,0 +1,49 @@
+package blaze
+
+import (
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ==================== CBOR ====================
+
+// cborEncMode and cborDecMode are the CBOR options of cborCodec
+var (
+	cborEncMode = mustCBOR(cbor.EncOptions{
+		Sort:    cbor.SortCoreDeterministic,
+		Time:    cbor.TimeRFC3339Nano,
+		TimeTag: cbor.EncTagRequired,
+	}.EncMode())
+
+	cborDecMode = mustCBOR(cbor.DecOptions{
+		MaxNestedLevels: maxDecodeDepth,
+		DefaultMapType:  reflect.TypeOf(map[string]interface{}(nil)),
+	}.DecMode())
+)
+
+// mustCBOR panics if the CBOR options are invalid
+func mustCBOR[T any](mode T, err error) T {
+	if err != nil {
+		panic("blaze: invalid CBOR options: " + err.Error())
+	}
+	return mode
+}
+
+// cborCodec encodes CBOR with github.com/fxamacker/cbor
+// Struct fields are named by their cbor or json tags, map keys are sorted
+// as in deterministic encoding (RFC 8949) and time.Time values are tagged
+// RFC 3339 strings. Maps decoded into interface{} values have string keys.
+type cborCodec struct{}
+
+func (cborCodec) MediaTypes() []string {
+	return []string{"application/cbor"}
+}
+
+func (cborCodec) Marshal(v interface{}) ([]byte, error) {
+	return cborEncMode.Marshal(v)
+}
+
+func (cborCodec) Unmarshal(data []byte, v interface{}) error {
+	return cborDecMode.Unmarshal(data, v)
+}
@@ -0,0 +1,97 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// ==================== MessagePack ====================
+
+// msgpackCodec encodes MessagePack with github.com/vmihailenco/msgpack
+// Struct fields are named by their json tags, so a type has the same keys
+// in JSON and MessagePack. Integers use their smallest encoding, map keys
+// are sorted and time.Time values use the timestamp extension.
+type msgpackCodec struct{}
+
+func (msgpackCodec) MediaTypes() []string {
+	return []string{"application/msgpack", "application/vnd.msgpack", "application/x-msgpack"}
+}
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := msgpack.NewEncoder(&b)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	enc.SetSortMapKeys(true)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	if err := checkMsgpackDepth(data); err != nil {
+		return err
+	}
+
+	r := bytes.NewReader(data)
+	dec := msgpack.NewDecoder(r)
+	dec.SetCustomStructTag("json")
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return errors.New("msgpack: unexpected data after value")
+	}
+	return nil
+}
+
+// checkMsgpackDepth walks the document without recursion and rejects it
+// when arrays and maps nest deeper than maxDecodeDepth
+// The decoder recurses into nested values, so this runs first.
+func checkMsgpackDepth(data []byte) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+
+	// pending holds the number of values left in each open array or map
+	pending := []int{1}
+	for len(pending) > 0 {
+		last := len(pending) - 1
+		if pending[last] == 0 {
+			pending = pending[:last]
+			continue
+		}
+		pending[last]--
+
+		code, err := dec.PeekCode()
+		if err != nil {
+			return err
+		}
+
+		var n int
+		switch {
+		case msgpcode.IsFixedArray(code), code == msgpcode.Array16, code == msgpcode.Array32:
+			n, err = dec.DecodeArrayLen()
+		case msgpcode.IsFixedMap(code), code == msgpcode.Map16, code == msgpcode.Map32:
+			n, err = dec.DecodeMapLen()
+			n *= 2
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(pending) > maxDecodeDepth {
+			return fmt.Errorf("msgpack: maximum nesting depth of %d exceeded", maxDecodeDepth)
+		}
+		pending = append(pending, n)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// codecRecord has the field types the built-in codecs must keep
+type codecRecord struct {
+	Name    string            `json:"name" yaml:"name"`
+	Count   int64             `json:"count" yaml:"count"`
+	Ratio   float64           `json:"ratio" yaml:"ratio"`
+	Tags    []string          `json:"tags" yaml:"tags"`
+	Labels  map[string]string `json:"labels" yaml:"labels"`
+	Data    []byte            `json:"data" yaml:"data"`
+	Created time.Time         `json:"created" yaml:"created"`
+	Note    *string           `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+// structuredCodecs are the built-in codecs of general values
+var structuredCodecs = map[string]Codec{
+	"json":    jsonCodec{},
+	"yaml":    yamlCodec{},
+	"msgpack": msgpackCodec{},
+	"cbor":    cborCodec{},
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	note := "é"
+	want := codecRecord{
+		Name:    "blaze",
+		Count:   -1 << 40,
+		Ratio:   0.5,
+		Tags:    []string{"a", "b c"},
+		Labels:  map[string]string{"env": "prod", "": "empty"},
+		Data:    []byte{0, 1, 0xff},
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Note:    &note,
+	}
+
+	for name, codec := range structuredCodecs {
+		t.Run(name, func(t *testing.T) {
+			data, err := codec.Marshal(want)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var got codecRecord
+			if err := codec.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal %q: %v", data, err)
+			}
+
+			// Time zones may differ, the instant must not
+			if !got.Created.Equal(want.Created) {
+				t.Errorf("created = %v, want %v", got.Created, want.Created)
+			}
+			got.Created = want.Created
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCodecFieldNames(t *testing.T) {
+	type tagged struct {
+		UserName string `json:"user_name" yaml:"login"`
+		Skipped  string `json:"-" yaml:"-"`
+		Empty    string `json:"empty,omitempty" yaml:"empty,omitempty"`
+	}
+	value := tagged{UserName: "ada", Skipped: "secret"}
+
+	tests := map[string]string{
+		"msgpack": "\x81\xa9user_name\xa3ada",
+		"cbor":    "\xa1\x69user_name\x63ada",
+		"yaml":    "login: ada\n",
+	}
+	for name, want := range tests {
+		data, err := structuredCodecs[name].Marshal(value)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if string(data) != want {
+			t.Errorf("%s: encoded %q, want %q", name, data, want)
+		}
+	}
+}
+
+func TestCodecInterfaceValues(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Values decoded into interface{} keep types JSON has no form for
+	for name, codec := range map[string]Codec{"yaml": yamlCodec{}, "msgpack": msgpackCodec{}, "cbor": cborCodec{}} {
+		t.Run(name, func(t *testing.T) {
+			data, err := codec.Marshal(map[string]interface{}{"created": created, "data": []byte{1, 2}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got interface{}
+			if err := codec.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			m, ok := got.(map[string]interface{})
+			if !ok {
+				t.Fatalf("decoded %T, want map[string]interface{}", got)
+			}
+			if value, ok := m["created"].(time.Time); !ok || !value.Equal(created) {
+				t.Errorf("created = %#v, want %v", m["created"], created)
+			}
+			if name != "yaml" && !reflect.DeepEqual(m["data"], []byte{1, 2}) {
+				t.Errorf("data = %#v", m["data"])
+			}
+		})
+	}
+
+	var got map[string]interface{}
+	if err := (yamlCodec{}).Unmarshal([]byte("when: 2024-01-02\nwhat: !!binary AQI=\n"), &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["when"].(time.Time); !ok {
+		t.Errorf("YAML timestamp decoded to %T", got["when"])
+	}
+	if got["what"] != "\x01\x02" {
+		t.Errorf("YAML binary decoded to %#v", got["what"])
+	}
+}
+
+func TestCodecInvalid(t *testing.T) {
+	tests := map[string][]string{
+		"msgpack": {
+			"",                     // empty
+			"\xa3ab",               // truncated string
+			"\x92\x01",             // truncated array
+			"\x81\xa1a",            // map without value
+			"\xc1",                 // never used
+			"\x01\x02",             // trailing data
+			"\xdd\xff\xff\xff\xff", // length past the end
+		},
+		"cbor": {
+			"",                                     // empty
+			"\x63ab",                               // truncated string
+			"\x82\x01",                             // truncated array
+			"\x9f\x01",                             // indefinite array without break
+			"\x01\x02",                             // trailing data
+			"\x62\xff\xfe",                         // invalid UTF-8
+			"\x9b\xff\xff\xff\xff\xff\xff\xff\xff", // length past the end
+		},
+		"yaml": {
+			"a: [1, 2\n",        // unclosed flow sequence
+			"a: 1\n a: 2\n",     // bad indentation
+			"a: 1\n---\nb: 2\n", // second document
+			"a: *missing\n",     // unknown alias
+		},
+	}
+
+	for name, inputs := range tests {
+		codec := structuredCodecs[name]
+		for _, input := range inputs {
+			var v interface{}
+			if err := codec.Unmarshal([]byte(input), &v); err == nil {
+				t.Errorf("%s: %q decoded to %#v, want an error", name, input, v)
+			}
+		}
+	}
+
+	// Types without an encoding fail instead of writing partial output
+	for name, codec := range structuredCodecs {
+		if _, err := codec.Marshal(make(chan int)); err == nil {
+			t.Errorf("%s: encoded a channel", name)
+		}
+	}
+}
+
+func TestCodecDepth(t *testing.T) {
+	nested := map[string]func(depth int) []byte{
+		"msgpack": func(depth int) []byte {
+			return append(bytes.Repeat([]byte{0x91}, depth), 0x01)
+		},
+		"cbor": func(depth int) []byte {
+			return append(bytes.Repeat([]byte{0x81}, depth), 0x01)
+		},
+		"yaml": func(depth int) []byte {
+			return []byte(strings.Repeat("[", depth) + strings.Repeat("]", depth))
+		},
+	}
+
+	for name, build := range nested {
+		t.Run(name, func(t *testing.T) {
+			codec := structuredCodecs[name]
+
+			var v interface{}
+			if err := codec.Unmarshal(build(100), &v); err != nil {
+				t.Errorf("nesting depth 100: %v", err)
+			}
+
+			// Hostile nesting is rejected before it can exhaust the stack
+			if err := codec.Unmarshal(build(100000), &v); err == nil {
+				t.Error("nesting depth 100000 decoded without an error")
+			}
+		})
+	}
+
+	// MessagePack and CBOR share the same limit
+	for _, name := range []string{"msgpack", "cbor"} {
+		var v interface{}
+		if err := structuredCodecs[name].Unmarshal(nested[name](maxDecodeDepth+1), &v); err == nil {
+			t.Errorf("%s: nesting depth %d decoded without an error", name, maxDecodeDepth+1)
+		}
+	}
+}
+
+func FuzzMsgpackDecode(f *testing.F) {
+	for _, seed := range []string{"\x81\xa4name\xa3ada", "\x93\x01\x92\x02\x03\x80", "\xde\x00\x01\xa1a\x91\xc0"} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v interface{}
+		_ = msgpackCodec{}.Unmarshal(data, &v)
+	})
+}
+
+// gogoMessage marshals itself, as gogo/protobuf messages do
+type gogoMessage struct {
+	data []byte
+}
+
+func (m *gogoMessage) Marshal() ([]byte, error) { return m.data, nil }
+
+func (m *gogoMessage) Unmarshal(data []byte) error {
+	m.data = append([]byte(nil), data...)
+	return nil
+}
+
+func TestProtobufCodec(t *testing.T) {
+	codec := protobufCodec{}
+
+	data, err := codec.Marshal(wrapperspb.String("ada"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := proto.Marshal(wrapperspb.String("ada")); !bytes.Equal(data, want) {
+		t.Errorf("encoded %x, want %x", data, want)
+	}
+
+	got := &wrapperspb.StringValue{}
+	if err := codec.Unmarshal(data, got); err != nil || got.GetValue() != "ada" {
+		t.Errorf("decoded %v, %v", got, err)
+	}
+	if err := codec.Unmarshal([]byte{0xff}, got); err == nil {
+		t.Error("decoded an invalid message")
+	}
+
+	gogo := &gogoMessage{}
+	if err := codec.Unmarshal([]byte{0x0a, 0x01, 0x61}, gogo); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := codec.Marshal(gogo); err != nil || !bytes.Equal(data, []byte{0x0a, 0x01, 0x61}) {
+		t.Errorf("gogo message encoded %x, %v", data, err)
+	}
+
+	// Other values are left to the next acceptable codec
+	if _, err := codec.Marshal(codecRecord{}); !errors.Is(err, ErrCodecUnsupported) {
+		t.Errorf("Marshal(struct) = %v, want ErrCodecUnsupported", err)
+	}
+	if err := codec.Unmarshal(data, &codecRecord{}); !errors.Is(err, ErrCodecUnsupported) {
+		t.Errorf("Unmarshal(struct) = %v, want ErrCodecUnsupported", err)
+	}
+}
@@ -264,6 +264,12 @@ func CompressWithConfig(config CompressionConfig) MiddlewareFunc {
 				return err
 			}
 
+			// Streamed bodies (SSE) are sent as produced; reading them
+			// here would wait for the whole stream
+			if c.Response().IsBodyStream() {
+				return nil
+			}
+
 			// Get response body
 			body := c.Response().Body()
 			if len(body) == 0 {
@@ -2,7 +2,6 @@ package blaze
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -10,14 +9,12 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/valyala/fasthttp"
 )
 
-var fastjson = jsoniter.ConfigCompatibleWithStandardLibrary
-
 // Context represents the request context for a single HTTP request
 // It wraps fasthttp.RequestCtx and provides additional functionality for:
 //   - Route parameter extraction
@@ -32,12 +29,52 @@ var fastjson = jsoniter.ConfigCompatibleWithStandardLibrary
 //   - Application state access
 //   - Graceful shutdown coordination
 //
-// Context instances are created per-request and should not be stored or reused
-// across requests. All methods are designed for single-request lifecycle.
+// Context instances are pooled and reused across requests. A Context must not
+// be stored or used after the handler returns; copy the values you need
+// (parameters, locals) instead. Hijacked connections such as WebSockets keep
+// their Context until the connection closes.
 type Context struct {
 	*fasthttp.RequestCtx                        // Underlying fasthttp request context
-	params               map[string]string      // Route parameters extracted from URL path
+	params               paramList              // Route parameters extracted from URL path
 	locals               map[string]interface{} // Request-scoped local variables
+	body                 *requestBody           // Streamed request body, nil once buffered
+	retained             bool                   // Still in use after the handler returned, never pooled
+}
+
+// contextPool recycles Context objects between requests
+var contextPool = sync.Pool{
+	New: func() interface{} {
+		return &Context{
+			params: make(paramList, 0, 8),
+			locals: make(map[string]interface{}, 8),
+		}
+	},
+}
+
+// acquireContext returns an empty Context for ctx from the pool
+func acquireContext(ctx *fasthttp.RequestCtx) *Context {
+	c := contextPool.Get().(*Context)
+	c.RequestCtx = ctx
+	return c
+}
+
+// releaseContext resets c and returns it to the pool
+// Contexts retained past their handler are left to the garbage collector.
+func releaseContext(c *Context) {
+	if c.retained {
+		return
+	}
+	c.RequestCtx = nil
+	c.params = c.params[:0]
+	clear(c.locals)
+	c.body = nil
+	contextPool.Put(c)
+}
+
+// retain keeps c out of the pool because it is used after the handler
+// returns (hijacked connections, handlers abandoned on timeout)
+func (c *Context) retain() {
+	c.retained = true
 }
 
 // Map is a shortcut for map[string]interface{}
@@ -59,7 +96,8 @@ type Map map[string]interface{}
 // Returns:
 //   - string: Parameter value or empty string if not found
 func (c *Context) Param(key string) string {
-	return c.params[key]
+	value, _ := c.params.get(key)
+	return value
 }
 
 // ParamInt returns a route parameter value as an integer
@@ -114,10 +152,7 @@ func (c *Context) ParamIntDefault(key string, defaultValue int) int {
 //   - key: Parameter name
 //   - value: Parameter value to store
 func (c *Context) SetParam(key, value string) {
-	if c.params == nil {
-		c.params = make(map[string]string)
-	}
-	c.params[key] = value
+	c.params.set(key, value)
 }
 
 // Query returns the value of a URL query parameter
@@ -250,10 +285,14 @@ func (c *Context) Response() *fasthttp.Response {
 
 // Body returns the raw request body as bytes
 // The body can only be read once; subsequent calls return the same cached data
+// On WithStreamingBody routes the body is read into memory on first use,
+// within the route's body limit (empty when it is exceeded); use
+// BodyStream to avoid buffering it.
 //
 // Returns:
 //   - []byte: Raw request body
 func (c *Context) Body() []byte {
+	c.bufferBody()
 	return c.RequestCtx.PostBody()
 }
 
@@ -263,6 +302,7 @@ func (c *Context) Body() []byte {
 // Returns:
 //   - []byte: Raw request body
 func (c *Context) PostBody() []byte {
+	c.bufferBody()
 	return c.RequestCtx.PostBody()
 }
 
@@ -276,9 +316,16 @@ func (c *Context) BodyString() string {
 }
 
 // Bind automatically binds request data to a struct based on Content-Type
+// Forms are bound with BindForm, every other body with the codec
+// registered for its media type (see RegisterCodec). Media types with a
+// structured syntax suffix such as application/vnd.api+json use the codec
+// of the suffix.
+//
 // Supports:
-//   - application/json -> BindJSON
-//   - application/x-www-form-urlencoded -> BindForm
+//   - application/json, application/xml, application/yaml,
+//     application/msgpack, application/cbor, application/x-protobuf
+//   - application/x-www-form-urlencoded, multipart/form-data -> BindForm
+//   - Media types of codecs registered with RegisterCodec
 //
 // Example:
 //
@@ -291,22 +338,31 @@ func (c *Context) BodyString() string {
 //   - v: Pointer to struct to populate with request data
 //
 // Returns:
-//   - error: Binding error or nil on success
+//   - error: 415 HTTPError listing the supported media types when no
+//     codec handles the Content-Type, decoding error otherwise
 func (c *Context) Bind(v interface{}) error {
-	contentType := string(c.RequestCtx.Request.Header.ContentType())
+	if err := c.bufferBody(); err != nil {
+		return err
+	}
 
-	switch {
-	case strings.Contains(contentType, "application/json"):
-		return c.BindJSON(v)
-	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
-		return c.BindForm(v)
-	default:
-		return fmt.Errorf("unsupported content type: %s", contentType)
+	mediaType := mediaTypeOf(c.RequestCtx.Request.Header.ContentType())
+	for _, formType := range formMediaTypes {
+		if mediaType == formType {
+			return c.BindForm(v)
+		}
 	}
+
+	codecs := c.codecs()
+	codec := findCodec(codecs, mediaType)
+	if codec == nil {
+		return unsupportedMediaType(mediaType, codecs)
+	}
+	return codec.Unmarshal(c.Body(), v)
 }
 
 // BindJSON binds JSON request body to a struct
-// Uses json-iterator for high-performance JSON parsing
+// Uses the App's JSON decoder (json-iterator by default, see
+// SetJSONDecoder)
 //
 // Example:
 //
@@ -324,7 +380,10 @@ func (c *Context) Bind(v interface{}) error {
 // Returns:
 //   - error: JSON parsing error or nil on success
 func (c *Context) BindJSON(v interface{}) error {
-	return fastjson.Unmarshal(c.Body(), v)
+	if err := c.bufferBody(); err != nil {
+		return err
+	}
+	return c.jsonDecoder().Unmarshal(c.Body(), v)
 }
 
 // Cookie returns the value of a cookie by name
@@ -447,23 +506,26 @@ func (c *Context) UserAgent() string {
 }
 
 // MarshalJSON converts blaze.Map to JSON bytes
-// Implements json.Marshaler interface for Map type
+// Implements json.Marshaler interface for Map type. Encodes with the
+// default JSON engine; the app's engine writes Map values in responses
+// itself (see NewStdJSONEngine for the exception).
 //
 // Returns:
 //   - []byte: JSON representation
 //   - error: Marshaling error or nil
 func (m Map) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]interface{}(m))
+	return defaultJSONEngine.Marshal(map[string]interface{}(m))
 }
 
 // ToJSON converts blaze.Map to JSON string
-// Convenient method for debugging and logging
+// Convenient method for debugging and logging. Encodes with the default
+// JSON engine.
 //
 // Returns:
 //   - string: JSON string representation
 //   - error: Marshaling error or nil
 func (m Map) ToJSON() (string, error) {
-	data, err := json.Marshal(map[string]interface{}(m))
+	data, err := m.ToJSONBytes()
 	if err != nil {
 		return "", err
 	}
@@ -471,13 +533,14 @@ func (m Map) ToJSON() (string, error) {
 }
 
 // ToJSONBytes converts blaze.Map to JSON byte slice
-// Efficient method for direct byte manipulation
+// Efficient method for direct byte manipulation. Encodes with the default
+// JSON engine.
 //
 // Returns:
 //   - []byte: JSON bytes
 //   - error: Marshaling error or nil
 func (m Map) ToJSONBytes() ([]byte, error) {
-	return json.Marshal(map[string]interface{}(m))
+	return defaultJSONEngine.Marshal(map[string]interface{}(m))
 }
 
 // ShutdownContext returns the application's shutdown context
@@ -761,6 +824,10 @@ func (c *Context) MultipartForm() (*MultipartForm, error) {
 //   - *MultipartForm: Parsed multipart form data
 //   - error: Parsing error or nil
 func (c *Context) MultipartFormWithConfig(config *MultipartConfig) (*MultipartForm, error) {
+	if err := c.bufferBody(); err != nil {
+		return nil, err
+	}
+
 	// Get the fasthttp multipart form
 	form, err := c.RequestCtx.MultipartForm()
 	if err != nil {
@@ -958,6 +1025,7 @@ func (c *Context) FormValue(name string) string {
 	}
 
 	// Fall back to regular form value
+	c.bufferBody()
 	return string(c.RequestCtx.FormValue(name))
 }
 
@@ -980,6 +1048,7 @@ func (c *Context) FormValues(name string) []string {
 	}
 
 	// Fall back to regular form values
+	c.bufferBody()
 	args := c.RequestCtx.PostArgs()
 	var values []string
 	args.VisitAll(func(key, value []byte) {
@@ -447,6 +447,7 @@ func (opts *CSRFOptions) extractToken(c *Context) (string, error) {
 		case "header":
 			token = c.Header(key)
 		case "form":
+			c.bufferBody()
 			token = string(c.RequestCtx.PostArgs().Peek(key))
 		case "query":
 			token = string(c.RequestCtx.QueryArgs().Peek(key))
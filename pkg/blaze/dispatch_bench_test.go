@@ -0,0 +1,120 @@
+package blaze
+
+import (
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+// maxDispatchAllocs is the allocation budget of one request through
+// App.Handler, independent of the route and its middleware depth
+const maxDispatchAllocs = 1
+
+// dispatchApp returns an app with a static route, a parameterized route
+// and a parameterized route behind ten no-op middleware
+func dispatchApp() *App {
+	app := New()
+
+	ok := func(c *Context) error {
+		c.SetStatusCode(fasthttp.StatusOK)
+		return nil
+	}
+	noop := func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			return next(c)
+		}
+	}
+
+	app.GET("/static/path", ok)
+	app.GET("/users/:id/posts/:post", func(c *Context) error {
+		if c.Param("id") == "" || c.Param("post") == "" {
+			c.SetStatusCode(fasthttp.StatusBadRequest)
+		}
+		return nil
+	})
+
+	heavy := app.Group("/heavy")
+	for i := 0; i < 10; i++ {
+		heavy.Use(noop)
+	}
+	heavy.GET("/users/:id/posts/:post", ok)
+
+	return app
+}
+
+// dispatchRequest returns a GET request context for path
+func dispatchRequest(path string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+	ctx.Request.SetRequestURI(path)
+	return ctx
+}
+
+func benchmarkDispatch(b *testing.B, path string) {
+	handler := dispatchApp().Handler()
+	ctx := dispatchRequest(path)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		handler(ctx)
+		if ctx.Response.StatusCode() != fasthttp.StatusOK {
+			b.Fatalf("%s: unexpected status %d", path, ctx.Response.StatusCode())
+		}
+		ctx.Response.Reset()
+	}
+}
+
+func BenchmarkDispatchStatic(b *testing.B) {
+	benchmarkDispatch(b, "/static/path")
+}
+
+func BenchmarkDispatchParam(b *testing.B) {
+	benchmarkDispatch(b, "/users/42/posts/7")
+}
+
+func BenchmarkDispatchMiddleware(b *testing.B) {
+	benchmarkDispatch(b, "/heavy/users/42/posts/7")
+}
+
+func TestDispatchAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts differ under the race detector")
+	}
+
+	handler := dispatchApp().Handler()
+
+	for _, path := range []string{"/static/path", "/users/42/posts/7", "/heavy/users/42/posts/7"} {
+		ctx := dispatchRequest(path)
+		allocs := testing.AllocsPerRun(100, func() {
+			handler(ctx)
+			ctx.Response.Reset()
+		})
+		if allocs > maxDispatchAllocs {
+			t.Errorf("%s: %.0f allocations per request, want at most %d", path, allocs, maxDispatchAllocs)
+		}
+	}
+}
+
+func TestRouteLookupAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts differ under the race detector")
+	}
+
+	app := dispatchApp()
+	router := app.router
+	table := router.table.Load()
+	params := make(paramList, 0, 8)
+
+	for _, path := range []string{"/static/path", "/users/42/posts/7", "/heavy/users/42/posts/7"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			params = params[:0]
+			if _, found := router.findRoute(table, "GET", path, &params); !found {
+				t.Fatalf("%s: route not found", path)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("%s: route lookup made %.0f allocations, want 0", path, allocs)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package blaze
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"runtime"
@@ -78,6 +77,16 @@ const (
 	// Common causes: Large file uploads, excessive JSON payload
 	ErrCodePayloadTooLarge ErrorCode = "PAYLOAD_TOO_LARGE"
 
+	// ErrCodeUnsupportedMediaType indicates the request body format is not supported
+	// HTTP Status: 415
+	// Common causes: Missing or wrong Content-Type header
+	ErrCodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+
+	// ErrCodeNotAcceptable indicates no response format matches the Accept header
+	// HTTP Status: 406
+	// Common causes: Client only accepts formats the endpoint cannot produce
+	ErrCodeNotAcceptable ErrorCode = "NOT_ACCEPTABLE"
+
 	// Server Errors (5xx)
 
 	// ErrCodeInternalServer indicates an unexpected server error
@@ -181,6 +190,11 @@ type HTTPError struct {
 	// Internal is the underlying error (not exposed in JSON)
 	// Preserved for server-side logging and debugging
 	Internal error `json:"-"`
+
+	// Problem sends the error as RFC 9457 problem details
+	// (application/problem+json) instead of an ErrorResponse
+	// Set with AsProblem.
+	Problem bool `json:"-"`
 }
 
 // StackFrame represents a single frame in the stack trace
@@ -333,6 +347,22 @@ func (e *HTTPError) WithStack(skip int) *HTTPError {
 	return e
 }
 
+// AsProblem marks the error to be sent as RFC 9457 problem details
+// The response has Content-Type application/problem+json and the body
+// returned by ToProblemDetails. Content negotiation errors (406 and 415)
+// are sent this way.
+//
+// Returns:
+//   - *HTTPError: Error marked as problem details for method chaining
+//
+// Example:
+//
+//	return blaze.ErrConflict("User already exists").AsProblem()
+func (e *HTTPError) AsProblem() *HTTPError {
+	e.Problem = true
+	return e
+}
+
 // NewHTTPError creates a new HTTP error with the specified parameters
 // Base constructor for creating custom HTTP errors
 //
@@ -485,6 +515,22 @@ func ErrConflict(message string) *HTTPError {
 	return NewHTTPError(http.StatusConflict, ErrCodeConflict, message)
 }
 
+// ErrPayloadTooLarge creates a 413 Payload Too Large error
+// Use for request bodies or uploads exceeding a size limit
+//
+// Parameters:
+//   - message: Description of the limit
+//
+// Returns:
+//   - *HTTPError: 413 error instance
+//
+// Example:
+//
+//	return blaze.ErrPayloadTooLarge("Uploads are limited to 10MB")
+func ErrPayloadTooLarge(message string) *HTTPError {
+	return NewHTTPError(http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge, message)
+}
+
 // ErrValidation creates a 422 Validation Error
 // Use for field validation failures, business rule violations
 //
@@ -795,6 +841,68 @@ func (e *HTTPError) ToErrorResponse(c *Context, includeStack bool) *ErrorRespons
 	return resp
 }
 
+// ==================== Problem Details ====================
+
+// ProblemContentType is the media type of RFC 9457 problem details
+const ProblemContentType = "application/problem+json"
+
+// ToProblemDetails converts HTTPError to RFC 9457 problem details
+// The members are:
+//   - type: "about:blank", the status code is the problem type
+//   - title: Status text of the status code
+//   - status: HTTP status code
+//   - detail: Error message
+//   - instance: Request path
+//   - code: Error code
+//
+// Details of map type become extension members, except for keys that
+// would replace the members above; other details are sent as "details".
+//
+// Parameters:
+//   - c: Request context (can be nil for standalone conversion)
+//
+// Returns:
+//   - Map: Problem details ready for JSON serialization
+//
+// Example:
+//
+//	c.SetContentType(blaze.ProblemContentType)
+//	return c.Status(err.StatusCode).JSON(err.ToProblemDetails(c))
+func (e *HTTPError) ToProblemDetails(c *Context) Map {
+	problem := Map{}
+	switch details := e.Details.(type) {
+	case nil:
+	case Map:
+		for key, value := range details {
+			problem[key] = value
+		}
+	case map[string]interface{}:
+		for key, value := range details {
+			problem[key] = value
+		}
+	default:
+		problem["details"] = details
+	}
+
+	problem["type"] = "about:blank"
+	problem["title"] = http.StatusText(e.StatusCode)
+	problem["status"] = e.StatusCode
+	problem["detail"] = e.Message
+	problem["code"] = e.Code
+
+	instance := e.Path
+	if instance == "" && c != nil {
+		instance = c.Path()
+	}
+	if instance != "" {
+		problem["instance"] = instance
+	} else {
+		delete(problem, "instance")
+	}
+
+	return problem
+}
+
 // captureStack captures the current stack trace
 // Filters out runtime frames and formats for readability
 //
@@ -954,7 +1062,8 @@ func DevelopmentErrorHandlerConfig() *ErrorHandlerConfig {
 //  4. Set request context (path, method, request ID)
 //  5. Create error response
 //  6. Apply security filters (hide internal errors)
-//  7. Send JSON response with appropriate status code
+//  7. Send JSON response with appropriate status code, or problem
+//     details for errors marked with AsProblem
 //
 // Error Type Handling:
 //   - HTTPError: Uses status code, code, and message from error
@@ -999,6 +1108,16 @@ func HandleError(c *Context, err error, config *ErrorHandlerConfig) error {
 		httpErr.Method = c.Method()
 		httpErr.RequestID = c.GetUserValueString("request_id")
 
+		if httpErr.Problem {
+			// Hide internal error in production
+			if config.HideInternalErrors && httpErr.Internal != nil {
+				hidden := *httpErr
+				hidden.Details = nil
+				httpErr = &hidden
+			}
+			return c.Status(httpErr.StatusCode).Problem(httpErr)
+		}
+
 		// Convert to response
 		response := httpErr.ToErrorResponse(c, config.IncludeStackTrace)
 
@@ -1040,25 +1159,33 @@ func HandleError(c *Context, err error, config *ErrorHandlerConfig) error {
 }
 
 // MarshalJSON provides custom JSON marshaling for HTTPError
-// Includes internal error message in development mode
+// Includes internal error message in development mode. Encodes with the
+// default JSON engine; the app's engine writes HTTPError values in
+// responses itself.
 //
 // Returns:
 //   - []byte: JSON representation
 //   - error: Marshaling error or nil
 func (e *HTTPError) MarshalJSON() ([]byte, error) {
-	type Alias HTTPError
-	return json.Marshal(&struct {
-		*Alias
+	return defaultJSONEngine.Marshal(e.jsonValue())
+}
+
+// httpErrorJSON is an HTTPError without its MarshalJSON method
+type httpErrorJSON HTTPError
+
+// jsonValue returns the value MarshalJSON encodes
+func (e *HTTPError) jsonValue() interface{} {
+	internal := ""
+	if e.Internal != nil {
+		internal = e.Internal.Error()
+	}
+	return &struct {
+		*httpErrorJSON
 		Internal string `json:"internal,omitempty"`
 	}{
-		Alias: (*Alias)(e),
-		Internal: func() string {
-			if e.Internal != nil {
-				return e.Internal.Error()
-			}
-			return ""
-		}(),
-	})
+		httpErrorJSON: (*httpErrorJSON)(e),
+		Internal:      internal,
+	}
 }
 
 // IsHTTPError checks if an error is an HTTPError
@@ -0,0 +1,354 @@
+package blaze
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ==================== Nested Form Binding ====================
+
+// FormBindingConfig limits nested form keys accepted by form binding
+// Bracket and dot notation let clients choose the shape of the bound
+// data; the limits keep deeply nested or huge indexed keys from being
+// used to exhaust memory or CPU.
+type FormBindingConfig struct {
+	// MaxDepth is the maximum nesting depth of a key: user[address][city]
+	// has depth 2. Zero disables the limit.
+	// Default: 10
+	MaxDepth int
+
+	// MaxIndex is the largest accepted slice index: items[1000][sku].
+	// Zero disables the limit.
+	// Default: 1000
+	MaxIndex int
+}
+
+// DefaultFormBindingConfig returns the default nested form limits
+//
+// Default Values:
+//   - MaxDepth: 10
+//   - MaxIndex: 1000
+//
+// Returns:
+//   - FormBindingConfig: Default configuration
+func DefaultFormBindingConfig() FormBindingConfig {
+	return FormBindingConfig{
+		MaxDepth: 10,
+		MaxIndex: 1000,
+	}
+}
+
+// formBindingConfig holds the global form binding limits
+var formBindingConfig atomic.Pointer[FormBindingConfig]
+
+// GetFormBindingConfig returns the global form binding limits
+//
+// Returns:
+//   - FormBindingConfig: Current configuration
+func GetFormBindingConfig() FormBindingConfig {
+	if config := formBindingConfig.Load(); config != nil {
+		return *config
+	}
+	return DefaultFormBindingConfig()
+}
+
+// SetFormBindingConfig sets the global form binding limits
+// Applies to BindForm, BindMultipartForm and typed handlers.
+//
+// Parameters:
+//   - config: Limits for nested form keys
+//
+// Example:
+//
+//	config := blaze.DefaultFormBindingConfig()
+//	config.MaxIndex = 100
+//	blaze.SetFormBindingConfig(config)
+func SetFormBindingConfig(config FormBindingConfig) {
+	formBindingConfig.Store(&config)
+}
+
+// nestForm returns a copy of form whose keys use dot notation
+// user[address][city], user.address.city and user[address].city all
+// become "user.address.city", and tags[] becomes "tags". Keys that are not
+// valid bracket notation are kept as they are.
+//
+// Parameters:
+//   - form: Parsed form
+//   - config: Nesting limits
+//
+// Returns:
+//   - *MultipartForm: Form with normalized keys and the nesting index
+//   - error: A key exceeds the maximum depth
+func nestForm(form *MultipartForm, config FormBindingConfig) (*MultipartForm, error) {
+	nested := &MultipartForm{
+		Value:    make(map[string][]string, len(form.Value)),
+		File:     make(map[string][]*MultipartFile, len(form.File)),
+		nested:   make(map[string][]string),
+		maxIndex: config.MaxIndex,
+	}
+	seen := make(map[string]bool)
+
+	normalize := func(key string) (string, error) {
+		segments, ok := parseFormKey(key)
+		if !ok {
+			return key, nil
+		}
+		if config.MaxDepth > 0 && len(segments)-1 > config.MaxDepth {
+			return "", fmt.Errorf("form field %s exceeds maximum nesting depth %d", key, config.MaxDepth)
+		}
+
+		for depth := 1; depth < len(segments); depth++ {
+			parent := strings.Join(segments[:depth], ".")
+			if child := parent + "\x00" + segments[depth]; !seen[child] {
+				seen[child] = true
+				nested.nested[parent] = append(nested.nested[parent], segments[depth])
+			}
+		}
+		return strings.Join(segments, "."), nil
+	}
+
+	// Sorted for a stable order of merged keys (tags and tags[])
+	for _, key := range sortedKeys(form.Value) {
+		name, err := normalize(key)
+		if err != nil {
+			return nil, err
+		}
+		nested.Value[name] = append(nested.Value[name], form.Value[key]...)
+	}
+	for _, key := range sortedKeys(form.File) {
+		name, err := normalize(key)
+		if err != nil {
+			return nil, err
+		}
+		nested.File[name] = append(nested.File[name], form.File[key]...)
+	}
+
+	return nested, nil
+}
+
+// parseFormKey splits a bracket or dot notation key into its segments
+// "items[0][sku]" -> items, 0, sku. A trailing "[]" is dropped. ok is false
+// for keys that are not valid notation, such as "a[b" or "a..b".
+func parseFormKey(key string) (segments []string, ok bool) {
+	end := strings.IndexAny(key, "[.")
+	if end < 0 {
+		return []string{key}, true
+	}
+	if end == 0 {
+		return nil, false
+	}
+
+	segments = append(segments, key[:end])
+	for i := end; i < len(key); {
+		switch key[i] {
+		case '.':
+			j := i + 1
+			for j < len(key) && key[j] != '[' && key[j] != '.' {
+				j++
+			}
+			if j == i+1 {
+				return nil, false
+			}
+			segments = append(segments, key[i+1:j])
+			i = j
+
+		case '[':
+			closing := strings.IndexByte(key[i:], ']')
+			if closing < 0 {
+				return nil, false
+			}
+			name := key[i+1 : i+closing]
+			if name == "" {
+				// "[]" appends and is only valid at the end
+				if i+closing+1 != len(key) {
+					return nil, false
+				}
+				return segments, true
+			}
+			segments = append(segments, name)
+			i += closing + 1
+
+		default:
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// joinFormKey appends a field name to a key prefix
+func joinFormKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// sortedKeys returns the keys of a form map in order
+func sortedKeys[T any](m map[string][]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hasNested reports whether the form has keys below key
+func (f *MultipartForm) hasNested(key string) bool {
+	return len(f.nested[key]) > 0
+}
+
+// indexes returns the numeric child keys of key in ascending order
+// Gaps are closed: items[0] and items[5] bind to a slice of two elements.
+func (f *MultipartForm) indexes(key string) ([]int, error) {
+	var indexes []int
+	for _, child := range f.nested[key] {
+		index, err := strconv.Atoi(child)
+		if err != nil || index < 0 {
+			continue
+		}
+		if f.maxIndex > 0 && index > f.maxIndex {
+			return nil, fmt.Errorf("index %d of field %s exceeds maximum index %d", index, key, f.maxIndex)
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+// indexedValues returns the values of key[0], key[1], ... in index order
+func (f *MultipartForm) indexedValues(key string) ([]string, error) {
+	indexes, err := f.indexes(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, index := range indexes {
+		values = append(values, f.Value[key+"."+strconv.Itoa(index)]...)
+	}
+	return values, nil
+}
+
+// indexedFiles returns the files of key[0], key[1], ... in index order
+func (f *MultipartForm) indexedFiles(key string) ([]*MultipartFile, error) {
+	indexes, err := f.indexes(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*MultipartFile
+	for _, index := range indexes {
+		files = append(files, f.File[key+"."+strconv.Itoa(index)]...)
+	}
+	return files, nil
+}
+
+// setStructSliceField binds a slice of structs from indexed keys
+// items[0][sku]=A&items[1][sku]=B fills two elements; pointer elements
+// are allocated.
+func (c *Context) setStructSliceField(form *MultipartForm, field reflect.Value, fieldName string, tag StructTag) error {
+	indexes, err := form.indexes(fieldName)
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		if tag.Required {
+			return fmt.Errorf("required field %s is missing", fieldName)
+		}
+		return nil
+	}
+
+	elemType := field.Type().Elem()
+	slice := reflect.MakeSlice(field.Type(), len(indexes), len(indexes))
+	for i, index := range indexes {
+		elem := slice.Index(i)
+		if elemType.Kind() == reflect.Ptr {
+			elem.Set(reflect.New(elemType.Elem()))
+			elem = elem.Elem()
+		}
+		if err := c.bindFormStruct(form, elem, fieldName+"."+strconv.Itoa(index)); err != nil {
+			return fmt.Errorf("failed to set slice element %d: %w", index, err)
+		}
+	}
+
+	field.Set(slice)
+	return nil
+}
+
+// setMapField binds a map from the child keys of fieldName
+// meta[color]=red&meta[size]=L fills map[string]string{"color": "red",
+// "size": "L"}. Values may be scalars, time.Time, slices, maps, structs or
+// pointers to them; keys may be strings or numbers.
+func (c *Context) setMapField(form *MultipartForm, field reflect.Value, fieldName string, tag StructTag) error {
+	children := form.nested[fieldName]
+	if len(children) == 0 {
+		if tag.Required {
+			return fmt.Errorf("required field %s is missing", fieldName)
+		}
+		return nil
+	}
+
+	mapType := field.Type()
+	m := reflect.MakeMapWithSize(mapType, len(children))
+	for _, name := range children {
+		key := reflect.New(mapType.Key()).Elem()
+		if err := c.setScalarValue(key, name, mapType.Key()); err != nil {
+			return fmt.Errorf("invalid key %s of field %s: %w", name, fieldName, err)
+		}
+
+		value := reflect.New(mapType.Elem()).Elem()
+		if err := c.setFormElement(form, value, fieldName+"."+name); err != nil {
+			return fmt.Errorf("failed to set map entry %s: %w", name, err)
+		}
+		m.SetMapIndex(key, value)
+	}
+
+	field.Set(m)
+	return nil
+}
+
+// setFormElement binds a map value from key
+func (c *Context) setFormElement(form *MultipartForm, value reflect.Value, key string) error {
+	switch {
+	case value.Type() == timeType:
+		if raw := form.GetValue(key); raw != "" {
+			return c.parseTimeField(value, raw, key)
+		}
+		return nil
+
+	case isNestedBindStruct(value.Type()):
+		return c.bindFormStruct(form, value, key)
+
+	case value.Kind() == reflect.Ptr:
+		if value.Type().Elem() == multipartFileType {
+			if file := form.GetFile(key); file != nil {
+				value.Set(reflect.ValueOf(file))
+			}
+			return nil
+		}
+		elem := reflect.New(value.Type().Elem())
+		if err := c.setFormElement(form, elem.Elem(), key); err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+
+	case value.Kind() == reflect.Slice:
+		return c.setSliceField(form, value, key, StructTag{})
+
+	case value.Kind() == reflect.Map:
+		return c.setMapField(form, value, key, StructTag{})
+
+	default:
+		raw := form.GetValue(key)
+		if raw == "" && value.Kind() != reflect.String {
+			return nil
+		}
+		return c.setScalarValue(value, raw, value.Type())
+	}
+}
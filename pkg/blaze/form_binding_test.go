@@ -0,0 +1,272 @@
+package blaze_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+type formAddress struct {
+	Street string `form:"street"`
+	City   string `form:"city"`
+}
+
+type formUser struct {
+	Name    string       `form:"name"`
+	Address formAddress  `form:"address"`
+	Work    *formAddress `form:"work"`
+}
+
+type formItem struct {
+	SKU string `form:"sku"`
+	Qty int    `form:"qty"`
+}
+
+type formOrder struct {
+	User   formUser               `form:"user"`
+	Items  []formItem             `form:"items"`
+	Extras []*formItem            `form:"extras"`
+	Tags   []string               `form:"tags"`
+	Meta   map[string]string      `form:"meta"`
+	Counts map[string]int         `form:"counts"`
+	Sizes  map[int][]string       `form:"sizes"`
+	Owners map[string]formAddress `form:"owners"`
+}
+
+type formDocument struct {
+	Title string               `form:"title"`
+	File  *blaze.MultipartFile `form:"file"`
+}
+
+type formProfile struct {
+	Avatar *blaze.MultipartFile `form:"avatar"`
+}
+
+type formUpload struct {
+	Profile     formProfile            `form:"profile"`
+	Documents   []formDocument         `form:"docs"`
+	Attachments []*blaze.MultipartFile `form:"attachments"`
+}
+
+// bindFormRequest sends body to a route binding into dest with BindForm
+// and returns the binding error
+func bindFormRequest(t *testing.T, dest interface{}, contentType string, body []byte) error {
+	t.Helper()
+
+	var bindErr error
+	app := blaze.New()
+	app.POST("/forms", func(c *blaze.Context) error {
+		bindErr = c.BindForm(dest)
+		return c.Text("ok")
+	})
+
+	blazetest.New(t, app).Post("/forms").WithBody(contentType, body).Do().AssertStatus(http.StatusOK)
+	return bindErr
+}
+
+// bindValues binds URL-encoded values into dest
+func bindValues(t *testing.T, dest interface{}, values url.Values) error {
+	t.Helper()
+	return bindFormRequest(t, dest, "application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// setFormBindingConfig sets the global form limits for the rest of the test
+func setFormBindingConfig(t *testing.T, config blaze.FormBindingConfig) {
+	previous := blaze.GetFormBindingConfig()
+	blaze.SetFormBindingConfig(config)
+	t.Cleanup(func() { blaze.SetFormBindingConfig(previous) })
+}
+
+func TestBindFormNested(t *testing.T) {
+	var got formOrder
+	err := bindValues(t, &got, url.Values{
+		"user[name]":              {"ada"},
+		"user[address][street]":   {"Main St"},
+		"user.address.city":       {"Paris"},
+		"user[work].city":         {"Lyon"},
+		"items[0][sku]":           {"A"},
+		"items[0][qty]":           {"2"},
+		"items[5][sku]":           {"B"},
+		"items.1.sku":             {"C"},
+		"extras[0][sku]":          {"X"},
+		"tags":                    {"c"},
+		"tags[]":                  {"a", "b"},
+		"meta[color]":             {"red"},
+		"meta[size]":              {"L"},
+		"counts[a]":               {"1"},
+		"sizes[1][]":              {"S", "M"},
+		"owners[home][city]":      {"Nice"},
+		"owners[office][street]":  {"Rue 1"},
+		"unrelated[key][nesting]": {"x"},
+	})
+	if err != nil {
+		t.Fatalf("BindForm: %v", err)
+	}
+
+	want := formOrder{
+		User: formUser{
+			Name:    "ada",
+			Address: formAddress{Street: "Main St", City: "Paris"},
+			Work:    &formAddress{City: "Lyon"},
+		},
+
+		// Gaps between indexes are closed
+		Items:  []formItem{{SKU: "A", Qty: 2}, {SKU: "C"}, {SKU: "B"}},
+		Extras: []*formItem{{SKU: "X"}},
+
+		// tags and tags[] are merged in key order
+		Tags:   []string{"c", "a", "b"},
+		Meta:   map[string]string{"color": "red", "size": "L"},
+		Counts: map[string]int{"a": 1},
+		Sizes:  map[int][]string{1: {"S", "M"}},
+		Owners: map[string]formAddress{"home": {City: "Nice"}, "office": {Street: "Rue 1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BindForm = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindFormFlat(t *testing.T) {
+	// Without nested keys, struct fields are read from the same level
+	var got formOrder
+	if err := bindValues(t, &got, url.Values{"name": {"ada"}, "city": {"Paris"}, "user[name": {"bob"}}); err != nil {
+		t.Fatalf("BindForm: %v", err)
+	}
+	if got.User.Name != "ada" || got.User.Address.City != "Paris" {
+		t.Errorf("user = %+v", got.User)
+	}
+
+	// Absent maps and nested pointers stay nil
+	if got.User.Work != nil || got.Meta != nil || got.Items != nil {
+		t.Errorf("absent fields set: work=%v meta=%v items=%v", got.User.Work, got.Meta, got.Items)
+	}
+}
+
+func TestBindFormNestedFiles(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("docs[0][title]", "report")
+	file, _ := form.CreateFormFile("docs[0][file]", "report.pdf")
+	file.Write([]byte("%PDF"))
+	form.WriteField("docs[1][title]", "notes")
+	file, _ = form.CreateFormFile("profile[avatar]", "me.png")
+	file.Write([]byte("png"))
+	for _, name := range []string{"a.txt", "b.txt"} {
+		file, _ = form.CreateFormFile("attachments[]", name)
+		file.Write([]byte(name))
+	}
+	form.Close()
+
+	var got formUpload
+	if err := bindFormRequest(t, &got, form.FormDataContentType(), body.Bytes()); err != nil {
+		t.Fatalf("BindForm: %v", err)
+	}
+
+	if len(got.Documents) != 2 {
+		t.Fatalf("documents = %+v, want 2", got.Documents)
+	}
+	if doc := got.Documents[0]; doc.Title != "report" || doc.File == nil || doc.File.Filename != "report.pdf" {
+		t.Errorf("documents[0] = %+v", doc)
+	}
+	if doc := got.Documents[1]; doc.Title != "notes" || doc.File != nil {
+		t.Errorf("documents[1] = %+v", doc)
+	}
+	if got.Profile.Avatar == nil || got.Profile.Avatar.Filename != "me.png" {
+		t.Errorf("profile avatar = %+v", got.Profile.Avatar)
+	}
+
+	var names []string
+	for _, file := range got.Attachments {
+		names = append(names, file.Filename)
+	}
+	if strings.Join(names, ",") != "a.txt,b.txt" {
+		t.Errorf("attachments = %v", names)
+	}
+}
+
+func TestBindFormErrors(t *testing.T) {
+	deep := "user" + strings.Repeat("[a]", 11)
+
+	tests := []struct {
+		name   string
+		values url.Values
+		want   string
+	}{
+		{
+			name:   "maximum depth",
+			values: url.Values{deep: {"x"}},
+			want:   "form field " + deep + " exceeds maximum nesting depth 10",
+		},
+		{
+			name:   "maximum index",
+			values: url.Values{"items[1001][sku]": {"A"}},
+			want:   "failed to set slice field Items: index 1001 of field items exceeds maximum index 1000",
+		},
+		{
+			name:   "maximum index of values",
+			values: url.Values{"tags[1001]": {"a"}},
+			want:   "failed to set slice field Tags: index 1001 of field tags exceeds maximum index 1000",
+		},
+		{
+			name:   "invalid element",
+			values: url.Values{"items[3][qty]": {"x"}},
+			want:   "failed to set slice field Items: failed to set slice element 3: failed to set int field Qty: invalid integer value for field items.3.qty: x",
+		},
+		{
+			name:   "invalid map value",
+			values: url.Values{"counts[a]": {"x"}},
+			want:   "failed to set map field Counts: failed to set map entry a: invalid integer value: x",
+		},
+		{
+			name:   "invalid map key",
+			values: url.Values{"sizes[s]": {"x"}},
+			want:   "failed to set map field Sizes: invalid key s of field sizes: invalid integer value: s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bindValues(t, &formOrder{}, tt.values)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormBindingConfig(t *testing.T) {
+	if got := blaze.GetFormBindingConfig(); got != blaze.DefaultFormBindingConfig() {
+		t.Errorf("GetFormBindingConfig = %+v, want the defaults", got)
+	}
+
+	setFormBindingConfig(t, blaze.FormBindingConfig{MaxDepth: 1, MaxIndex: 2})
+
+	var order formOrder
+	err := bindValues(t, &order, url.Values{"tags[3]": {"a"}})
+	if want := "failed to set slice field Tags: index 3 of field tags exceeds maximum index 2"; err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+	err = bindValues(t, &order, url.Values{"user[address][city]": {"Paris"}})
+	if want := "form field user[address][city] exceeds maximum nesting depth 1"; err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+
+	// Zero disables the limits
+	setFormBindingConfig(t, blaze.FormBindingConfig{})
+
+	order = formOrder{}
+	deep := "user" + strings.Repeat("[a]", 20)
+	if err := bindValues(t, &order, url.Values{"items[5000][sku]": {"A"}, deep: {"x"}}); err != nil {
+		t.Fatalf("BindForm without limits: %v", err)
+	}
+	if len(order.Items) != 1 || order.Items[0].SKU != "A" {
+		t.Errorf("items = %+v", order.Items)
+	}
+}
@@ -0,0 +1,353 @@
+package blaze
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ==================== Typed Handlers ====================
+
+// HandlerSignature describes the request and response types of a typed
+// handler. Routes registered with the typed helpers (GET, POST, ...) keep
+// it in Route.Signature for documentation and introspection.
+//
+// Example:
+//
+//	blaze.POST(app, "/users", createUser)
+//	info := app.GetRouteInfo()["POST:/users"]
+//	log.Printf("%s -> %s", info.Request, info.Response)
+type HandlerSignature struct {
+	// Request is the Req type parameter
+	Request reflect.Type
+
+	// Response is the Resp type parameter
+	Response reflect.Type
+}
+
+// RouteRegistrar is implemented by *App, *Group and *Router, the targets
+// of the typed registration helpers
+type RouteRegistrar interface {
+	addRoute(method, path string, handler HandlerFunc, options []RouteOption)
+}
+
+func (a *App) addRoute(method, path string, handler HandlerFunc, options []RouteOption) {
+	a.router.AddRoute(method, path, handler, options...)
+}
+
+func (g *Group) addRoute(method, path string, handler HandlerFunc, options []RouteOption) {
+	g.add(method, path, handler, options)
+}
+
+func (r *Router) addRoute(method, path string, handler HandlerFunc, options []RouteOption) {
+	r.AddRoute(method, path, handler, options...)
+}
+
+// GET registers a typed GET handler
+// The handler is adapted with Handle and the route records its Req and
+// Resp types in Route.Signature, so GetRouteInfo and the OpenAPI
+// generator always describe the function that is registered.
+//
+// Parameters:
+//   - r: App, Group or Router to register on
+//   - path: URL pattern with optional parameters
+//   - fn: Typed handler function
+//   - options: Optional route configuration
+//
+// Example:
+//
+//	blaze.GET(app, "/users/:id", getUser, blaze.WithName("user"))
+//	blaze.GET(api, "/users", listUsers)
+func GET[Req, Resp any](r RouteRegistrar, path string, fn func(*Context, Req) (Resp, error), options ...RouteOption) {
+	HandleMethod(r, "GET", path, fn, options...)
+}
+
+// POST registers a typed POST handler
+// See GET for how the signature is recorded.
+//
+// Example:
+//
+//	blaze.POST(app, "/orgs/:org/users", createUser)
+func POST[Req, Resp any](r RouteRegistrar, path string, fn func(*Context, Req) (Resp, error), options ...RouteOption) {
+	HandleMethod(r, "POST", path, fn, options...)
+}
+
+// PUT registers a typed PUT handler
+// See GET for how the signature is recorded.
+func PUT[Req, Resp any](r RouteRegistrar, path string, fn func(*Context, Req) (Resp, error), options ...RouteOption) {
+	HandleMethod(r, "PUT", path, fn, options...)
+}
+
+// PATCH registers a typed PATCH handler
+// See GET for how the signature is recorded.
+func PATCH[Req, Resp any](r RouteRegistrar, path string, fn func(*Context, Req) (Resp, error), options ...RouteOption) {
+	HandleMethod(r, "PATCH", path, fn, options...)
+}
+
+// DELETE registers a typed DELETE handler
+// See GET for how the signature is recorded.
+func DELETE[Req, Resp any](r RouteRegistrar, path string, fn func(*Context, Req) (Resp, error), options ...RouteOption) {
+	HandleMethod(r, "DELETE", path, fn, options...)
+}
+
+// HandleMethod registers a typed handler for any HTTP method
+// The route keeps the Req and Resp types of fn in Route.Signature. A
+// WithSignature option naming other types rejects the route.
+//
+// Parameters:
+//   - r: App, Group or Router to register on
+//   - method: HTTP method
+//   - path: URL pattern with optional parameters
+//   - fn: Typed handler function
+//   - options: Optional route configuration
+//
+// Example:
+//
+//	blaze.HandleMethod(app, "QUERY", "/search", search)
+func HandleMethod[Req, Resp any](r RouteRegistrar, method, path string, fn func(*Context, Req) (Resp, error), options ...RouteOption) {
+	all := make([]RouteOption, 0, len(options)+1)
+	all = append(all, options...)
+	all = append(all, withHandlerSignature(signatureOf[Req, Resp]()))
+
+	r.addRoute(method, path, Handle(fn), all)
+}
+
+// WithSignature records the request and response types of a route
+// Only needed when a Handle handler is registered with the untyped
+// methods (app.POST, router.AddRoute); the typed helpers record the
+// signature themselves and reject a WithSignature that disagrees.
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.POST("/orgs/:org/users", blaze.Handle(createUser),
+//	    blaze.WithSignature[CreateUserRequest, UserResponse](),
+//	)
+func WithSignature[Req, Resp any]() RouteOption {
+	signature := signatureOf[Req, Resp]()
+	return func(r *Route) {
+		r.Signature = signature
+	}
+}
+
+// signatureOf returns the signature of a func(*Context, Req) (Resp, error)
+func signatureOf[Req, Resp any]() *HandlerSignature {
+	return &HandlerSignature{
+		Request:  reflect.TypeFor[Req](),
+		Response: reflect.TypeFor[Resp](),
+	}
+}
+
+// withHandlerSignature sets the signature of the registered handler,
+// rejecting the route when an earlier option stated different types
+func withHandlerSignature(signature *HandlerSignature) RouteOption {
+	return func(r *Route) {
+		if r.Signature != nil && *r.Signature != *signature {
+			r.setOptionErr(fmt.Errorf("signature %s -> %s does not match the handler's %s -> %s",
+				r.Signature.Request, r.Signature.Response, signature.Request, signature.Response))
+			return
+		}
+		r.Signature = signature
+	}
+}
+
+// Handle adapts a typed function to a HandlerFunc
+// The request is bound into a new Req, validated, and passed to fn. The
+// returned Resp is written in the format the client accepts.
+//
+// Request Binding (in order):
+//  1. Body: by Content-Type, with the registered codecs or as a form
+//  2. Path parameters: fields tagged param:"id"
+//  3. Query parameters: fields tagged query:"page"
+//  4. Headers: fields tagged header:"X-Tenant-ID"
+//  5. Cookies: fields tagged cookie:"session"
+//
+// Tags use the form tag syntax, e.g. query:"limit,default=20" or
+// header:"X-API-Key,required". Req may be a struct or a pointer to one;
+// other types are bound from the body only.
+//
+// Errors:
+//   - Unsupported body Content-Type: 415 Unsupported Media Type listing
+//     the supported media types
+//   - Malformed body or parameter: 400 Bad Request
+//   - Validation failure: 400 with the ValidationErrors as details
+//   - Errors returned by fn are passed to the error handler unchanged,
+//     so *HTTPError keeps its status
+//
+// Response:
+//   - Encoded with Negotiate: the registered codec the Accept header
+//     prefers (JSON by default)
+//   - 406 Not Acceptable when no acceptable codec can encode Resp
+//   - The status set by fn (c.Status(201)) is kept
+//   - A nil pointer or interface Resp writes no body; the status becomes
+//     204 unless fn already wrote a status or body
+//
+// The handler's types are not visible to the router. Register it with
+// the typed helpers (GET, POST, ...) to keep them in Route.Signature.
+//
+// Parameters:
+//   - fn: Typed handler function
+//
+// Returns:
+//   - HandlerFunc: Handler to register on a route
+//
+// Example:
+//
+//	type CreateUserRequest struct {
+//	    OrgID string `param:"org"`
+//	    Name  string `json:"name" validate:"required"`
+//	    Email string `json:"email" validate:"required,email"`
+//	}
+//
+//	type UserResponse struct {
+//	    ID   int    `json:"id"`
+//	    Name string `json:"name"`
+//	}
+//
+//	blaze.POST(app, "/orgs/:org/users",
+//	    func(c *blaze.Context, req CreateUserRequest) (UserResponse, error) {
+//	        user, err := users.Create(req.OrgID, req.Name, req.Email)
+//	        if err != nil {
+//	            return UserResponse{}, blaze.ErrConflict("User already exists")
+//	        }
+//	        c.Status(201)
+//	        return UserResponse{ID: user.ID, Name: user.Name}, nil
+//	    },
+//	)
+func Handle[Req, Resp any](fn func(*Context, Req) (Resp, error)) HandlerFunc {
+	return func(c *Context) error {
+		var req Req
+		if err := c.bindRequest(&req); err != nil {
+			return err
+		}
+
+		resp, err := fn(c, req)
+		if err != nil {
+			return err
+		}
+
+		return c.respond(resp)
+	}
+}
+
+// bindRequest binds and validates the request into v, a pointer to Req
+func (c *Context) bindRequest(v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+
+	// Allocate pointer requests (Req = *CreateUserRequest)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+	target := rv.Addr().Interface()
+
+	if err := c.bufferBody(); err != nil {
+		return err
+	}
+	if len(c.RequestCtx.Request.Body()) > 0 {
+		if err := c.bindBody(target); err != nil {
+			return err
+		}
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := c.bindTagged(rv, bindSources); err != nil {
+		return ErrBadRequest(err.Error())
+	}
+
+	if err := GetValidator().ValidateStruct(target); err != nil {
+		if validationErr, ok := err.(ValidationErrors); ok {
+			return NewHTTPError(http.StatusBadRequest, ErrCodeValidation, "Validation failed").
+				WithDetails(validationErr.Errors)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// bindBody binds the request body into v by Content-Type
+func (c *Context) bindBody(v interface{}) error {
+	if err := c.Bind(v); err != nil {
+		if httpErr, ok := err.(*HTTPError); ok {
+			return httpErr
+		}
+		return ErrBadRequest(fmt.Sprintf("Invalid request body: %v", err))
+	}
+	return nil
+}
+
+// respond writes the result of a typed handler in the format the Accept
+// header prefers
+func (c *Context) respond(data interface{}) error {
+	resp := &c.RequestCtx.Response
+	if isNilValue(data) {
+		if resp.StatusCode() == fasthttp.StatusOK && !resp.IsBodyStream() && len(resp.Body()) == 0 {
+			return c.NoContent()
+		}
+		return nil
+	}
+
+	return c.Negotiate(resp.StatusCode(), data)
+}
+
+// isNilValue reports whether v is nil or a nil pointer or interface
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// acceptQuality returns the q-value accept gives mediaType, 0 when the
+// media type is not acceptable
+func acceptQuality(accept, mediaType string) float64 {
+	quality, specificity := 0.0, -1
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+		// exact type > type/* > */*
+		rangeSpecificity := -1
+		switch {
+		case mediaRange == mediaType:
+			rangeSpecificity = 2
+		case strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, mediaRange[:len(mediaRange)-1]):
+			rangeSpecificity = 1
+		case mediaRange == "*/*" || mediaRange == "*":
+			rangeSpecificity = 0
+		}
+		if rangeSpecificity < 0 || rangeSpecificity < specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(strings.TrimSpace(key), "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+
+		if rangeSpecificity > specificity || q > quality {
+			quality, specificity = q, rangeSpecificity
+		}
+	}
+
+	return quality
+}
@@ -0,0 +1,195 @@
+package blaze_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+type createUserRequest struct {
+	OrgID   string `param:"org"`
+	DryRun  bool   `query:"dry_run,default=false"`
+	Tenant  string `header:"X-Tenant-ID,required"`
+	Session string `cookie:"session"`
+	Name    string `json:"name" validate:"required,min=2"`
+}
+
+type userResponse struct {
+	Org     string `json:"org"`
+	Tenant  string `json:"tenant"`
+	Session string `json:"session"`
+	Name    string `json:"name"`
+	DryRun  bool   `json:"dry_run"`
+}
+
+func createUser(c *blaze.Context, req createUserRequest) (userResponse, error) {
+	if req.Name == "taken" {
+		return userResponse{}, blaze.ErrConflict("User already exists")
+	}
+	c.Status(http.StatusCreated)
+	return userResponse{
+		Org:     req.OrgID,
+		Tenant:  req.Tenant,
+		Session: req.Session,
+		Name:    req.Name,
+		DryRun:  req.DryRun,
+	}, nil
+}
+
+func TestHandleBind(t *testing.T) {
+	app := blaze.New()
+	blaze.POST(app, "/orgs/:org/users", createUser)
+
+	srv := blazetest.New(t, app)
+
+	srv.Post("/orgs/acme/users").
+		WithQuery("dry_run", "true").
+		WithHeader("X-Tenant-ID", "t1").
+		WithCookie("session", "s1").
+		WithJSON(blaze.Map{"name": "ada"}).
+		Do().
+		AssertStatus(http.StatusCreated).
+		AssertJSONPath("org", "acme").
+		AssertJSONPath("tenant", "t1").
+		AssertJSONPath("session", "s1").
+		AssertJSONPath("name", "ada").
+		AssertJSONPath("dry_run", true)
+
+	// Form bodies bind like JSON ones
+	srv.Post("/orgs/acme/users").
+		WithHeader("X-Tenant-ID", "t1").
+		WithBody("application/x-www-form-urlencoded", []byte("name=grace")).
+		Do().
+		AssertStatus(http.StatusCreated).
+		AssertJSONPath("name", "grace").
+		AssertJSONPath("dry_run", false)
+
+	tests := []struct {
+		name   string
+		req    *blazetest.Request
+		status int
+		code   string
+	}{
+		{"missing header", srv.Post("/orgs/acme/users").WithJSON(blaze.Map{"name": "ada"}),
+			http.StatusBadRequest, "BAD_REQUEST"},
+		{"bad query", srv.Post("/orgs/acme/users").WithQuery("dry_run", "maybe").
+			WithHeader("X-Tenant-ID", "t1").WithJSON(blaze.Map{"name": "ada"}),
+			http.StatusBadRequest, "BAD_REQUEST"},
+		{"malformed body", srv.Post("/orgs/acme/users").WithHeader("X-Tenant-ID", "t1").
+			WithBody("application/json", []byte(`{"name":`)),
+			http.StatusBadRequest, "BAD_REQUEST"},
+		{"handler error", srv.Post("/orgs/acme/users").WithHeader("X-Tenant-ID", "t1").
+			WithJSON(blaze.Map{"name": "taken"}),
+			http.StatusConflict, "CONFLICT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Do().AssertStatus(tt.status).AssertJSONPath("error.code", tt.code)
+		})
+	}
+}
+
+func TestHandleValidate(t *testing.T) {
+	app := blaze.New()
+	blaze.POST(app, "/orgs/:org/users", createUser)
+
+	blazetest.New(t, app).Post("/orgs/acme/users").
+		WithHeader("X-Tenant-ID", "t1").
+		WithJSON(blaze.Map{"name": "a"}).
+		Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertJSONPath("error.code", "VALIDATION_ERROR").
+		AssertJSONPath("error.details.0.field", "name").
+		AssertJSONPath("error.details.0.tag", "min")
+}
+
+func TestHandleRespond(t *testing.T) {
+	type item struct {
+		Name string `json:"name" xml:"name"`
+	}
+
+	app := blaze.New()
+	blaze.GET(app, "/item", func(c *blaze.Context, _ struct{}) (item, error) {
+		return item{Name: "ada"}, nil
+	})
+	blaze.DELETE(app, "/item", func(c *blaze.Context, _ struct{}) (*item, error) {
+		return nil, nil
+	})
+	blaze.PUT(app, "/item", func(c *blaze.Context, _ struct{}) (*item, error) {
+		c.Status(http.StatusAccepted)
+		return nil, nil
+	})
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/item").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/json").
+		AssertJSONPath("name", "ada")
+	srv.Get("/item").WithHeader("Accept", "application/xml").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/xml").
+		AssertBodyContains("<name>ada</name>")
+
+	// A nil response writes no body
+	srv.Delete("/item").Do().AssertStatus(http.StatusNoContent).AssertBody("")
+	srv.Put("/item").Do().AssertStatus(http.StatusAccepted).AssertBody("")
+}
+
+func TestHandleMediaTypes(t *testing.T) {
+	app := blaze.New()
+	blaze.POST(app, "/orgs/:org/users", createUser)
+
+	srv := blazetest.New(t, app)
+
+	srv.Post("/orgs/acme/users").
+		WithHeader("X-Tenant-ID", "t1").
+		WithHeader("Accept", "image/png").
+		WithJSON(blaze.Map{"name": "ada"}).
+		Do().
+		AssertStatus(http.StatusNotAcceptable).
+		AssertHeader("Content-Type", blaze.ProblemContentType).
+		AssertJSONPath("code", "NOT_ACCEPTABLE")
+
+	srv.Post("/orgs/acme/users").
+		WithHeader("X-Tenant-ID", "t1").
+		WithBody("text/plain", []byte("ada")).
+		Do().
+		AssertStatus(http.StatusUnsupportedMediaType).
+		AssertHeader("Content-Type", blaze.ProblemContentType).
+		AssertJSONPath("code", "UNSUPPORTED_MEDIA_TYPE")
+}
+
+func TestHandleSignature(t *testing.T) {
+	app := blaze.New()
+	blaze.POST(app, "/users", createUser)
+	blaze.GET(app.Group("/api"), "/users/:org", createUser)
+	app.PUT("/users", blaze.Handle(createUser),
+		blaze.WithSignature[createUserRequest, userResponse]())
+
+	router := blaze.NewRouter()
+	blaze.HandleMethod(router, "PATCH", "/users", createUser)
+
+	info := app.GetRouteInfo()
+	for _, key := range []string{"POST:/users", "GET:/api/users/:org", "PUT:/users"} {
+		route := info[key]
+		if route == nil || route.Request != "blaze_test.createUserRequest" || route.Response != "blaze_test.userResponse" {
+			t.Errorf("%s: route info %+v", key, route)
+		}
+	}
+	if route, _, ok := router.FindRoute("PATCH", "/users"); !ok || route.Signature == nil {
+		t.Errorf("router route has no signature")
+	}
+
+	// The typed helpers reject a WithSignature that disagrees
+	captureLog(t)
+	blaze.POST(app, "/other", createUser, blaze.WithSignature[struct{}, userResponse]())
+
+	err := app.ValidateRoutes()
+	if err == nil || !strings.Contains(err.Error(), "does not match the handler's") {
+		t.Errorf("ValidateRoutes = %v, want a signature mismatch", err)
+	}
+}
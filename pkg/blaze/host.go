@@ -0,0 +1,302 @@
+package blaze
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// virtualHost holds the routing state for a host pattern registered with App.Host
+// Each virtual host has its own router, middleware stack and fallback handlers
+//
+// Pattern Syntax:
+//   - Exact host: api.example.com
+//   - Named label: :tenant.example.com (captured as c.Param("tenant"))
+//   - Wildcard: *.example.com (one or more leading labels, not captured)
+//   - Catch-all: * (any host)
+//   - Port: api.example.com:8443 (port must match; otherwise ports are ignored)
+type virtualHost struct {
+	// pattern is the host pattern as registered
+	pattern string
+
+	// labels are the dot-separated pattern labels
+	// Literal labels are lowercased, parameter names keep their casing
+	labels []string
+
+	// port is the required port, empty to accept any port
+	port string
+
+	// exact is true when the pattern contains no parameters or wildcards
+	exact bool
+
+	// router serves routes registered on this host
+	router *Router
+
+	// middleware runs for every request on this host, including 404/405
+	middleware []MiddlewareFunc
+
+	// notFoundHandler and methodNotAllowedHandler replace the app
+	// handlers for requests on this host
+	notFoundHandler         HandlerFunc
+	methodNotAllowedHandler HandlerFunc
+}
+
+// newVirtualHost compiles a host pattern
+func newVirtualHost(pattern string, config RouterConfig) *virtualHost {
+	host, port := splitHostPort(pattern)
+
+	vh := &virtualHost{
+		pattern:                 pattern,
+		port:                    port,
+		exact:                   true,
+		router:                  NewRouter(config),
+		notFoundHandler:         NotFoundHandler(),
+		methodNotAllowedHandler: MethodNotAllowedHandler(),
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		switch {
+		case strings.HasPrefix(label, ":"):
+			vh.exact = false
+		case label == "*":
+			vh.exact = false
+		default:
+			label = toLowerASCII(label)
+		}
+		vh.labels = append(vh.labels, label)
+	}
+
+	return vh
+}
+
+// match reports whether host matches the pattern, storing named labels in params
+func (vh *virtualHost) match(host string, params *paramList) bool {
+	name, port := splitHostPort(host)
+	if vh.port != "" && port != vh.port {
+		return false
+	}
+
+	name = toLowerASCII(strings.TrimSuffix(name, "."))
+	labels := strings.Split(name, ".")
+
+	// A leading "*" absorbs any extra labels
+	patternLabels := vh.labels
+	if len(patternLabels) > 0 && patternLabels[0] == "*" {
+		if len(labels) < len(patternLabels) {
+			return false
+		}
+		labels = labels[len(labels)-len(patternLabels)+1:]
+		patternLabels = patternLabels[1:]
+	}
+
+	if len(labels) != len(patternLabels) {
+		return false
+	}
+
+	for i, label := range patternLabels {
+		switch {
+		case strings.HasPrefix(label, ":"):
+			if labels[i] == "" {
+				return false
+			}
+		case label == "*":
+			continue
+		case label != labels[i]:
+			return false
+		}
+	}
+
+	// Capture only after the whole host matched
+	for i, label := range patternLabels {
+		if strings.HasPrefix(label, ":") {
+			params.add(label[1:], labels[i])
+		}
+	}
+
+	return true
+}
+
+// Host returns a route group bound to a virtual host
+// Routes registered on the group only match requests whose Host header
+// matches the pattern
+//
+// Pattern Syntax:
+//   - "api.example.com": exact host (case-insensitive)
+//   - ":tenant.example.com": named label, available as c.Param("tenant")
+//   - "*.example.com": any subdomain depth, not captured
+//   - "*": any host (register last to act as a catch-all host)
+//   - "admin.example.com:8443": also requires the port to match
+//
+// Host Selection:
+//   - Exact hosts are tried before patterns
+//   - Patterns are tried in registration order
+//   - Requests matching no host are served by routes registered
+//     directly on the App (the fallback host)
+//
+// Each host has an independent middleware stack and 404/405 handlers.
+// Middleware added with Use on the returned group runs for every request
+// on the host, including not found responses, after the App's global
+// middleware. Calling Host again with the same pattern returns a group
+// for the same virtual host.
+//
+// Parameters:
+//   - pattern: Host pattern
+//
+// Returns:
+//   - *Group: Route group for the host
+//
+// Example:
+//
+//	api := app.Host("api.example.com")
+//	api.Use(blaze.CORS(blaze.DefaultCORSOptions()))
+//	api.GET("/users", listUsers)
+//
+//	tenant := app.Host(":tenant.example.com")
+//	tenant.GET("/", func(c *blaze.Context) error {
+//	    return c.Text("Welcome " + c.Param("tenant"))
+//	})
+//	tenant.SetNotFoundHandler(func(c *blaze.Context) error {
+//	    return c.Status(404).HTML("<h1>Not found</h1>")
+//	})
+//
+//	// Everything else
+//	app.GET("/", landingPage)
+func (a *App) Host(pattern string) *Group {
+	var vh *virtualHost
+	for _, existing := range a.hosts {
+		if existing.pattern == pattern {
+			vh = existing
+			break
+		}
+	}
+
+	if vh == nil {
+		vh = newVirtualHost(pattern, a.router.config)
+		vh.router.setOuter(func() MiddlewareFunc {
+			return chainMiddleware(slices.Concat(a.middleware, vh.middleware))
+		})
+		vh.router.nameOwner = a.nameOwner(vh.router)
+		a.hosts = append(a.hosts, vh)
+	}
+
+	return &Group{
+		app:        a,
+		prefix:     "",
+		middleware: make([]MiddlewareFunc, 0),
+		host:       vh,
+	}
+}
+
+// nameOwner returns the lookup a router of the app uses to find its route
+// names on the app's other routers
+// Names must be unique across hosts for reverse routing to know which
+// host a name refers to.
+func (a *App) nameOwner(self *Router) func(name string) *Route {
+	return func(name string) *Route {
+		if a.router != self {
+			if route, ok := a.router.GetRoute(name); ok {
+				return route
+			}
+		}
+		for _, vh := range a.hosts {
+			if vh.router == self {
+				continue
+			}
+			if route, ok := vh.router.GetRoute(name); ok {
+				return route
+			}
+		}
+		return nil
+	}
+}
+
+// buildHost returns the host for an absolute URL to a route on this host
+// Parameter labels are filled from params, falling back to the labels of
+// current when it is on this host. The port is the pattern's port, or the
+// current one. Patterns with wildcard labels can only be built from a
+// current host they match. Host parameters are removed from the returned
+// params so they are not repeated in the query string.
+func (vh *virtualHost) buildHost(current string, params Map) (string, Map, error) {
+	var captured paramList
+	onHost := vh.match(current, &captured)
+
+	if slices.Contains(vh.labels, "*") {
+		if !onHost {
+			return "", nil, fmt.Errorf("host %q has wildcard labels and cannot be built from parameters", vh.pattern)
+		}
+		return current, params, nil
+	}
+
+	labels := make([]string, len(vh.labels))
+	rest, copied := params, false
+	for i, label := range vh.labels {
+		if !strings.HasPrefix(label, ":") {
+			labels[i] = label
+			continue
+		}
+
+		name := label[1:]
+		value := formatURLValue(params[name])
+		switch {
+		case value != "":
+			if !copied {
+				rest, copied = maps.Clone(params), true
+			}
+			delete(rest, name)
+		case onHost:
+			value, _ = captured.get(name)
+		default:
+			return "", nil, fmt.Errorf("missing host parameter %q", name)
+		}
+		labels[i] = value
+	}
+
+	host := strings.Join(labels, ".")
+	port := vh.port
+	if port == "" {
+		_, port = splitHostPort(current)
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	return host, rest, nil
+}
+
+// matchHost selects the virtual host for a request
+// Returns nil when no host pattern matches
+func (a *App) matchHost(host string, params *paramList) *virtualHost {
+	if len(a.hosts) == 0 {
+		return nil
+	}
+
+	for _, vh := range a.hosts {
+		if vh.exact && vh.match(host, params) {
+			return vh
+		}
+	}
+
+	for _, vh := range a.hosts {
+		if !vh.exact && vh.match(host, params) {
+			return vh
+		}
+	}
+
+	return nil
+}
+
+// splitHostPort separates an optional port from a host
+// Handles bracketed IPv6 literals ("[::1]:8080")
+func splitHostPort(host string) (string, string) {
+	i := strings.LastIndexByte(host, ':')
+	if i < 0 || i < strings.LastIndexByte(host, ']') {
+		return host, ""
+	}
+
+	// A ':' at the start of a label is a parameter, not a port
+	if i == 0 || host[i-1] == '.' {
+		return host, ""
+	}
+
+	return host[:i], host[i+1:]
+}
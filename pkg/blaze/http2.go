@@ -208,6 +208,8 @@ type HTTP2Server struct {
 	server      *http.Server            // Underlying net/http server
 	h2Server    *http2.Server           // HTTP/2 protocol handler
 	fastHandler fasthttp.RequestHandler // FastHTTP handler to wrap
+	maxBodySize int                     // Request body limit in bytes (0 = unlimited)
+	streamBody  bool                    // Pass request bodies to the handler as streams
 	mu          sync.RWMutex            // Protects concurrent access
 }
 
@@ -285,89 +287,33 @@ func (h2s *HTTP2Server) SetFastHTTPHandler(handler fasthttp.RequestHandler) {
 }
 
 // convertFastHTTPHandler converts FastHTTP handler to net/http handler
-// Performs bidirectional conversion between fasthttp and net/http formats
+// Uses the same conversion as App.HTTPHandler
 //
 // Conversion Process:
-//  1. Extract method, URI, headers from net/http request
-//  2. Create fasthttp.Request and populate fields
-//  3. Copy request body if present
-//  4. Create fasthttp.RequestCtx
-//  5. Call FastHTTP handler
-//  6. Extract status, headers, body from fasthttp.Response
-//  7. Write to net/http.ResponseWriter
-//
-// Performance Notes:
-//   - Conversion has minimal overhead
-//   - Zero-copy optimizations where possible
-//   - Body streaming supported
+//  1. Copy method, URI, Host and headers to a fasthttp.Request
+//  2. Read the body, enforcing the request body limit
+//  3. Carry remote address, local address and TLS state into the RequestCtx
+//  4. Call FastHTTP handler
+//  5. Copy headers, then write the status
+//  6. Write the body, flushing body streams as they are produced
+//  7. Send response trailers
 //
 // Returns:
 //   - http.HandlerFunc: net/http compatible handler
 func (h2s *HTTP2Server) convertFastHTTPHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// Convert net/http request to fasthttp request
-		var req fasthttp.Request
-		var resp fasthttp.Response
-
-		// Set method
-		req.Header.SetMethod(r.Method)
-
-		// Set URI
-		if r.URL.RawQuery != "" {
-			req.SetRequestURI(r.URL.Path + "?" + r.URL.RawQuery)
-		} else {
-			req.SetRequestURI(r.URL.Path)
-		}
-
-		// Copy headers
-		for name, values := range r.Header {
-			for _, value := range values {
-				req.Header.Add(name, value)
-			}
-		}
-
-		// Copy body
-		if r.Body != nil {
-			req.SetBodyStream(r.Body, int(r.ContentLength))
-		}
-
-		// Set remote address using the correct method
-		remoteAddr := r.RemoteAddr
-		if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
-			remoteAddr = host
-		}
-
-		// Create RequestCtx
-		ctx := &fasthttp.RequestCtx{}
-		ctx.Init(&req, nil, nil)
-
-		// Set the remote address correctly
-		if tcpAddr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
-			ctx.SetRemoteAddr(tcpAddr)
-		}
-
-		// Call FastHTTP handler
 		h2s.mu.RLock()
 		handler := h2s.fastHandler
+		maxBodySize := h2s.maxBodySize
+		streamBody := h2s.streamBody
 		h2s.mu.RUnlock()
 
-		if handler != nil {
-			handler(ctx)
+		if handler == nil {
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
 		}
 
-		// Get response
-		ctx.Response.CopyTo(&resp)
-
-		// Copy response to net/http response
-		w.WriteHeader(resp.StatusCode())
-
-		// Copy headers
-		resp.Header.VisitAll(func(key, value []byte) {
-			w.Header().Add(string(key), string(value))
-		})
-
-		// Write body
-		w.Write(resp.Body())
+		serveFastHTTP(w, r, handler, maxBodySize, streamBody)
 	}
 }
 
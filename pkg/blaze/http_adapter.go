@@ -0,0 +1,907 @@
+package blaze
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ==================== net/http Interoperability ====================
+
+// httpRequestLocal is the locals key holding the request seen by the
+// innermost wrapped net/http middleware
+const httpRequestLocal = "__http_request__"
+
+// WrapHTTPHandler adapts a net/http handler to a blaze handler
+// Lets existing http.Handler implementations (pprof, promhttp, OAuth
+// callbacks, file servers) be registered as blaze routes.
+//
+// Request Conversion:
+//   - Method, request URI, protocol, Host and all headers are copied
+//   - The body is buffered, so the request stays valid for the whole handler
+//   - RemoteAddr, TLS state and the local address are preserved
+//   - The request context is canceled when the handler returns or the
+//     client disconnects during a streamed response
+//
+// Response Conversion:
+//   - Status and headers are captured when WriteHeader (or the first Write) runs
+//   - Output is buffered until the handler returns
+//   - The first Flush switches to a streamed response: buffered output is
+//     sent and every later Flush reaches the client immediately (SSE, long polling)
+//   - Trailers declared through the "Trailer" header or set with
+//     http.TrailerPrefix are sent after the body
+//
+// The handler runs on its own goroutine so it can keep writing after
+// the response has switched to streaming. Panics are re-raised on the
+// request goroutine, where Recovery middleware handles them. Hijacking
+// and 1xx informational responses are not supported.
+//
+// Parameters:
+//   - h: net/http handler to serve
+//
+// Returns:
+//   - HandlerFunc: Blaze handler serving h
+//
+// Example:
+//
+//	app.GET("/metrics", blaze.WrapHTTPHandler(promhttp.Handler()))
+//	app.GET("/debug/pprof/*path", blaze.WrapHTTPHandler(http.DefaultServeMux))
+func WrapHTTPHandler(h http.Handler) HandlerFunc {
+	return func(c *Context) error {
+		req, cancel, err := newHTTPRequest(c)
+		if err != nil {
+			return err
+		}
+
+		rw := newHTTPResponseWriter()
+		done := make(chan struct{})
+		var panicked interface{}
+
+		go func() {
+			defer close(done)
+			defer func() {
+				panicked = recover()
+			}()
+			h.ServeHTTP(rw, req)
+		}()
+
+		select {
+		case <-done:
+		case <-rw.flushed:
+			select {
+			case <-done:
+			default:
+				rw.writeHeaderTo(&c.RequestCtx.Response)
+				resp := &c.RequestCtx.Response
+				c.SetBodyStreamWriter(func(w *bufio.Writer) {
+					defer cancel()
+					if !rw.stream(w, done) {
+						// Client gone; the canceled context stops the handler
+						return
+					}
+
+					// The server writes trailers once this writer returns
+					rw.writeTrailersTo(resp)
+					if panicked != nil && panicked != http.ErrAbortHandler {
+						log.Printf("blaze: panic serving %s %s: %v", req.Method, req.RequestURI, panicked)
+					}
+				})
+				return nil
+			}
+		}
+
+		cancel()
+		if panicked != nil {
+			panic(panicked)
+		}
+
+		rw.writeHeaderTo(&c.RequestCtx.Response)
+		rw.writeBodyTo(&c.RequestCtx.Response)
+		return nil
+	}
+}
+
+// WrapHTTPMiddleware adapts net/http middleware to blaze middleware
+// The middleware runs with a net/http view of the request. When it calls
+// the handler it wraps, the rest of the blaze chain runs and its response
+// is written through the middleware's ResponseWriter, so middleware that
+// inspects or rewrites responses (metrics, compression, security headers)
+// sees the status, headers and body.
+//
+// Request Changes:
+//   - Method, URL, Host, headers and body changes made by the middleware
+//     are applied to the blaze request before the next handler runs
+//   - The request passed on by the middleware, including values it stored
+//     in the request context, is available through c.HTTPRequest() and
+//     to net/http middleware and handlers wrapped further down the chain
+//
+// Behavior:
+//   - If the middleware does not call the wrapped handler, its own
+//     response is sent (e.g. 401 from an auth middleware)
+//   - Errors returned by the blaze chain are passed through unchanged
+//   - Streamed blaze responses keep their body stream; only the status and
+//     headers pass through the middleware
+//
+// Middleware that runs the wrapped handler on another goroutine and
+// returns before it finishes (http.TimeoutHandler) cannot cut the blaze
+// chain short: the Context is only valid until the middleware returns, so
+// the chain is waited for and then discarded, and the middleware's own
+// response is sent. A wrapped handler called after the middleware
+// returned does nothing. Use WithTimeout for route timeouts instead.
+//
+// Parameters:
+//   - mw: net/http middleware
+//
+// Returns:
+//   - MiddlewareFunc: Blaze middleware running mw
+//
+// Example:
+//
+//	app.Use(blaze.WrapHTTPMiddleware(sessionManager.LoadAndSave))
+//
+//	app.GET("/me", func(c *blaze.Context) error {
+//	    user := sessionManager.GetString(c.HTTPRequest().Context(), "user")
+//	    return c.JSON(blaze.Map{"user": user})
+//	})
+func WrapHTTPMiddleware(mw func(http.Handler) http.Handler) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			req, cancel, err := newHTTPRequest(c)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			orig := snapshotHTTPRequest(req)
+			rw := newHTTPResponseWriter()
+
+			var (
+				called   bool
+				nextErr  error
+				streamed bool
+
+				// running counts wrapped handlers still using c; once
+				// returned is set, new calls must not touch c
+				mu       sync.Mutex
+				running  sync.WaitGroup
+				active   int
+				returned bool
+			)
+
+			wrapped := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				if returned {
+					mu.Unlock()
+					return
+				}
+				active++
+				running.Add(1)
+				mu.Unlock()
+				defer func() {
+					mu.Lock()
+					active--
+					mu.Unlock()
+					running.Done()
+				}()
+
+				called = true
+				if err := orig.apply(c, r); err != nil {
+					nextErr = err
+					return
+				}
+				c.SetLocals(httpRequestLocal, r)
+
+				if nextErr = next(c); nextErr != nil {
+					return
+				}
+
+				// Replay the blaze response through the middleware's writer
+				streamed = c.RequestCtx.Response.IsBodyStream()
+				writeHTTPResponse(w, &c.RequestCtx.Response, !streamed)
+			})
+
+			mw(wrapped).ServeHTTP(rw, req)
+
+			mu.Lock()
+			returned = true
+			abandoned := active > 0
+			mu.Unlock()
+
+			// c is released once this returns, so wait for a chain the
+			// middleware left running on another goroutine
+			running.Wait()
+
+			switch {
+			case abandoned:
+				// The middleware answered without waiting for the chain
+				c.RequestCtx.Response.Reset()
+				rw.writeHeaderTo(&c.RequestCtx.Response)
+				rw.writeBodyTo(&c.RequestCtx.Response)
+				return nil
+
+			case !called:
+				// The middleware answered the request itself
+				rw.writeHeaderTo(&c.RequestCtx.Response)
+				rw.writeBodyTo(&c.RequestCtx.Response)
+				return nil
+
+			case nextErr != nil:
+				// The error handler writes the response; keep headers the
+				// middleware added before calling the handler
+				setFastHTTPHeaders(&c.RequestCtx.Response, rw.header)
+				return nextErr
+			}
+
+			// The replayed response comes back as the final response.
+			// Headers the middleware removed are removed here too.
+			encoding := string(c.RequestCtx.Response.Header.ContentEncoding())
+			_, header := rw.result()
+			removeFastHTTPHeaders(&c.RequestCtx.Response, header)
+			rw.writeHeaderTo(&c.RequestCtx.Response)
+
+			if streamed {
+				// The middleware never saw the streamed body, so it cannot
+				// have encoded it
+				c.RequestCtx.Response.Header.SetContentEncoding(encoding)
+				return nil
+			}
+
+			rw.writeBodyTo(&c.RequestCtx.Response)
+			return nil
+		}
+	}
+}
+
+// HTTPRequest returns the request passed on by the innermost middleware
+// added with WrapHTTPMiddleware
+// Use it to read values net/http middleware stored in the request context.
+//
+// Returns:
+//   - *http.Request: Request seen by the middleware, or nil outside wrapped middleware
+func (c *Context) HTTPRequest() *http.Request {
+	if r, ok := c.Locals(httpRequestLocal).(*http.Request); ok {
+		return r
+	}
+	return nil
+}
+
+// HTTPHandler returns a net/http handler serving the app
+// Lets a blaze app be embedded in a net/http server, mux or test harness
+// (httptest.NewServer). Routes, middleware, hosts and mounts behave as
+// they do under ListenAndServe.
+//
+// Request Conversion:
+//   - RemoteAddr becomes the remote TCP address (c.IP(), rate limiting, logging)
+//   - TLS requests report IsTLS and an https scheme
+//   - Bodies larger than Config.MaxRequestBodySize are rejected with 413
+//   - With Config.StreamRequestBody, WithStreamingBody routes read the body as it arrives
+//
+// Response Conversion:
+//   - Headers are copied before the status is written
+//   - Body streams (SetBodyStreamWriter, SSE) are flushed to the client as produced
+//   - Response trailers are sent after the body
+//
+// Like Handler, the first call runs ValidateRoutes and panics with the
+// rejected routes. WebSocket upgrades are not supported through the adapter.
+//
+// Returns:
+//   - http.Handler: net/http handler serving the app
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/legacy/", legacyHandler)
+//	mux.Handle("/", app.HTTPHandler())
+//	log.Fatal(http.ListenAndServe(":8080", mux))
+func (a *App) HTTPHandler() http.Handler {
+	a.mustValidateRoutes()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveFastHTTP(w, r, a.handler, a.config.MaxRequestBodySize, a.config.StreamRequestBody)
+	})
+}
+
+// ==================== net/http to fasthttp ====================
+
+// serveFastHTTP serves a net/http request with a fasthttp handler
+// maxBodySize limits the request body; zero means no limit. With
+// streamBody the body is passed to the handler unread, which enforces the
+// limit itself.
+func serveFastHTTP(w http.ResponseWriter, r *http.Request, handler fasthttp.RequestHandler, maxBodySize int, streamBody bool) {
+	var ctx fasthttp.RequestCtx
+	ctx.Init2(newHTTPConn(w, r), log.Default(), true)
+
+	req := &ctx.Request
+	req.Header.SetMethod(r.Method)
+	req.Header.SetProtocol(r.Proto)
+	req.SetRequestURI(httpRequestURI(r))
+	req.Header.SetHost(r.Host)
+
+	for key, values := range r.Header {
+		switch key {
+		case "Host", "Content-Length", "Transfer-Encoding":
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if streamBody && r.Body != nil && r.Body != http.NoBody {
+		// -1 when the length is unknown (chunked)
+		req.SetBodyStream(r.Body, int(r.ContentLength))
+	} else if r.Body != nil && r.Body != http.NoBody {
+		body := r.Body
+		if maxBodySize > 0 {
+			body = http.MaxBytesReader(w, body, int64(maxBodySize))
+		}
+
+		data, err := io.ReadAll(body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		req.SetBodyRaw(data)
+	}
+
+	handler(&ctx)
+
+	// A timed out handler may still be writing to ctx.Response
+	resp := &ctx.Response
+	if timeout := ctx.LastTimeoutErrorResponse(); timeout != nil {
+		resp = timeout
+	}
+	writeHTTPResponse(w, resp, true)
+}
+
+// writeHTTPResponse writes a fasthttp response to a net/http ResponseWriter
+// Headers are copied before WriteHeader; body streams are flushed as they
+// are read. With writeBody false only the status and headers are written.
+func writeHTTPResponse(w http.ResponseWriter, resp *fasthttp.Response, writeBody bool) {
+	stream := resp.IsBodyStream()
+
+	var trailers []string
+	for name := range resp.Header.Trailers() {
+		trailers = append(trailers, string(name))
+	}
+
+	header := w.Header()
+	for k, v := range resp.Header.All() {
+		key := string(k)
+		switch {
+		case key == "Connection", key == "Transfer-Encoding", key == "Keep-Alive", key == "Trailer":
+			continue
+		case key == "Content-Length" && stream:
+			continue
+		case slices.ContainsFunc(trailers, func(name string) bool { return strings.EqualFold(name, key) }):
+			continue
+		}
+		header.Add(key, string(v))
+	}
+
+	if len(trailers) > 0 {
+		header.Set("Trailer", strings.Join(trailers, ", "))
+	}
+
+	w.WriteHeader(resp.StatusCode())
+
+	if !writeBody {
+		return
+	}
+
+	if stream {
+		if err := resp.BodyWriteTo(&flushWriter{w: w, rc: http.NewResponseController(w)}); err != nil {
+			return
+		}
+	} else {
+		w.Write(resp.Body())
+	}
+
+	// Values set after the body are sent as trailers
+	for _, name := range trailers {
+		if value := resp.Header.Peek(name); len(value) > 0 {
+			header.Set(name, string(value))
+		}
+	}
+}
+
+// httpRequestURI returns the request URI as received, keeping its escaping
+func httpRequestURI(r *http.Request) string {
+	if r.RequestURI != "" {
+		return r.RequestURI
+	}
+	return r.URL.RequestURI()
+}
+
+// flushWriter flushes the ResponseWriter after every write so streamed
+// bodies reach the client as they are produced
+type flushWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+// Write writes p and flushes it to the client
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if err := f.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return n, err
+	}
+	return n, nil
+}
+
+// httpConn carries the addresses of a net/http request into a
+// fasthttp.RequestCtx. It is never read from or written to.
+type httpConn struct {
+	net.Conn
+	local  net.Addr
+	remote net.Addr
+	rc     *http.ResponseController
+}
+
+// LocalAddr returns the address the request was received on
+func (c *httpConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+// RemoteAddr returns the client address
+func (c *httpConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// SetWriteDeadline sets the write deadline of the net/http response, so
+// streamed responses can extend it
+func (c *httpConn) SetWriteDeadline(t time.Time) error {
+	if err := c.rc.SetWriteDeadline(t); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// SetReadDeadline sets the read deadline of the net/http request, so
+// streamed request bodies can extend it
+func (c *httpConn) SetReadDeadline(t time.Time) error {
+	if err := c.rc.SetReadDeadline(t); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// httpTLSConn also reports the TLS state of the request, so
+// RequestCtx.IsTLS and Context.Scheme recognize HTTPS requests
+type httpTLSConn struct {
+	*httpConn
+	state *tls.ConnectionState
+}
+
+// Handshake is a no-op; the net/http server completed the handshake
+func (c *httpTLSConn) Handshake() error {
+	return nil
+}
+
+// ConnectionState returns the TLS state of the request
+func (c *httpTLSConn) ConnectionState() tls.ConnectionState {
+	return *c.state
+}
+
+// newHTTPConn returns the connection view of a net/http request
+func newHTTPConn(w http.ResponseWriter, r *http.Request) net.Conn {
+	local, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	if !ok {
+		local = &net.TCPAddr{IP: net.IPv4zero}
+	}
+
+	conn := &httpConn{
+		local:  local,
+		remote: parseRemoteAddr(r.RemoteAddr),
+		rc:     http.NewResponseController(w),
+	}
+	if r.TLS != nil {
+		return &httpTLSConn{httpConn: conn, state: r.TLS}
+	}
+	return conn
+}
+
+// parseRemoteAddr converts a net/http RemoteAddr ("ip:port") to a TCP address
+// Never resolves host names; unparsable addresses become 0.0.0.0
+func parseRemoteAddr(addr string) net.Addr {
+	if ap, err := netip.ParseAddrPort(addr); err == nil {
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ap.Addr().Unmap(), ap.Port()))
+	}
+	if ip, err := netip.ParseAddr(addr); err == nil {
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip.Unmap(), 0))
+	}
+	return &net.TCPAddr{IP: net.IPv4zero}
+}
+
+// ==================== fasthttp to net/http ====================
+
+// newHTTPRequest builds a net/http request from the blaze request
+// Everything is copied, so the request stays valid after the blaze
+// handler returns. The context inherits the values of the request passed
+// on by wrapped middleware; the returned cancel function cancels it.
+func newHTTPRequest(c *Context) (*http.Request, context.CancelFunc, error) {
+	requestURI := string(c.RequestURI())
+	u, err := url.ParseRequestURI(requestURI)
+	if err != nil {
+		return nil, nil, ErrBadRequest("Invalid request URI").WithInternal(err)
+	}
+
+	proto := string(c.RequestCtx.Request.Header.Protocol())
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok {
+		proto, major, minor = "HTTP/1.1", 1, 1
+	}
+
+	header := make(http.Header)
+	for k, v := range c.RequestCtx.Request.Header.All() {
+		if key := string(k); key != "Host" {
+			header.Add(key, string(v))
+		}
+	}
+
+	var body io.ReadCloser = http.NoBody
+	data := bytes.Clone(c.PostBody())
+	if len(data) > 0 {
+		body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	// Values stored by outer wrapped middleware stay visible. Their
+	// cancellation does not carry over: a streamed response outlives them.
+	parent := context.Background()
+	if outer := c.HTTPRequest(); outer != nil {
+		parent = context.WithoutCancel(outer.Context())
+	}
+
+	ctx := context.WithValue(parent, http.LocalAddrContextKey, c.LocalAddr())
+	ctx, cancel := context.WithCancel(ctx)
+
+	req := (&http.Request{
+		Method:        string(c.Method()),
+		URL:           u,
+		Proto:         proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          body,
+		ContentLength: int64(len(data)),
+		Host:          string(c.Host()),
+		RemoteAddr:    c.RemoteAddr().String(),
+		RequestURI:    requestURI,
+		TLS:           c.TLSConnectionState(),
+	}).WithContext(ctx)
+
+	return req, cancel, nil
+}
+
+// httpRequestSnapshot records the parts of a net/http request a middleware
+// may change. Middleware often mutates the request in place, so the
+// values are copied.
+type httpRequestSnapshot struct {
+	method string
+	uri    string
+	host   string
+	header http.Header
+	body   io.ReadCloser
+}
+
+// snapshotHTTPRequest records the current state of r
+func snapshotHTTPRequest(r *http.Request) *httpRequestSnapshot {
+	return &httpRequestSnapshot{
+		method: r.Method,
+		uri:    r.URL.RequestURI(),
+		host:   r.Host,
+		header: r.Header.Clone(),
+		body:   r.Body,
+	}
+}
+
+// apply copies changes made to r since the snapshot to the blaze request
+func (s *httpRequestSnapshot) apply(c *Context, r *http.Request) error {
+	req := &c.RequestCtx.Request
+
+	if r.Method != s.method {
+		req.Header.SetMethod(r.Method)
+	}
+	if uri := r.URL.RequestURI(); uri != s.uri {
+		req.SetRequestURI(uri)
+	}
+	if r.Host != s.host {
+		req.Header.SetHost(r.Host)
+	}
+
+	for key := range s.header {
+		if _, ok := r.Header[key]; !ok && key != "Content-Length" {
+			req.Header.Del(key)
+		}
+	}
+	for key, values := range r.Header {
+		if key == "Content-Length" || slices.Equal(values, s.header[key]) {
+			continue
+		}
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if r.Body != s.body && r.Body != nil {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return ErrBadRequest("Failed to read request body").WithInternal(err)
+		}
+		req.SetBodyRaw(data)
+	}
+
+	return nil
+}
+
+// httpResponseWriter is the http.ResponseWriter given to wrapped net/http
+// handlers. Output is buffered until the handler returns or, for
+// streaming handlers, drained by stream after the first Flush.
+type httpResponseWriter struct {
+	mu sync.Mutex
+
+	// header is the live header map returned by Header
+	header http.Header
+
+	// written is the header snapshot taken by WriteHeader
+	written http.Header
+
+	status int
+	body   bytes.Buffer
+
+	// err is set once the client has gone away
+	err error
+
+	// flushed is closed by the first Flush
+	flushed   chan struct{}
+	flushOnce sync.Once
+
+	// pending signals new output while streaming
+	pending chan struct{}
+}
+
+// newHTTPResponseWriter creates an empty response writer
+func newHTTPResponseWriter() *httpResponseWriter {
+	return &httpResponseWriter{
+		header:  make(http.Header),
+		flushed: make(chan struct{}),
+		pending: make(chan struct{}, 1),
+	}
+}
+
+// Header returns the response header map
+func (w *httpResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader records the status code and snapshots the headers
+// 1xx informational responses are ignored.
+func (w *httpResponseWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(code)
+}
+
+// writeHeader implements WriteHeader; the caller holds mu
+func (w *httpResponseWriter) writeHeader(code int) {
+	if w.written != nil || code < 200 {
+		return
+	}
+	w.status = code
+	w.written = w.header.Clone()
+}
+
+// Write buffers p, writing a 200 status first if none was written
+// Detects the Content-Type like net/http when none is set.
+func (w *httpResponseWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.written == nil {
+		if _, ok := w.header["Content-Type"]; !ok && len(p) > 0 {
+			w.header.Set("Content-Type", http.DetectContentType(p))
+		}
+		w.writeHeader(http.StatusOK)
+	}
+	if !bodyAllowed(w.status) {
+		return 0, http.ErrBodyNotAllowed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	n, _ := w.body.Write(p)
+	w.signal()
+	return n, nil
+}
+
+// Flush sends buffered output to the client, switching to a streamed response
+func (w *httpResponseWriter) Flush() {
+	w.mu.Lock()
+	w.writeHeader(http.StatusOK)
+	w.mu.Unlock()
+
+	w.flushOnce.Do(func() {
+		close(w.flushed)
+	})
+	w.signal()
+}
+
+// signal wakes the streaming loop without blocking
+func (w *httpResponseWriter) signal() {
+	select {
+	case w.pending <- struct{}{}:
+	default:
+	}
+}
+
+// result returns the status and headers, defaulting to 200
+func (w *httpResponseWriter) result() (int, http.Header) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writeHeader(http.StatusOK)
+	return w.status, w.written
+}
+
+// writeHeaderTo copies the status and headers to a fasthttp response
+func (w *httpResponseWriter) writeHeaderTo(resp *fasthttp.Response) {
+	status, header := w.result()
+	resp.SetStatusCode(status)
+	setFastHTTPHeaders(resp, header)
+}
+
+// writeBodyTo copies the buffered body and trailers to a fasthttp response
+func (w *httpResponseWriter) writeBodyTo(resp *fasthttp.Response) {
+	if !w.writeTrailersTo(resp) {
+		resp.SetBody(w.body.Bytes())
+		return
+	}
+
+	// Trailers are only sent with chunked transfer encoding
+	resp.SetBodyStream(bytes.NewReader(bytes.Clone(w.body.Bytes())), -1)
+}
+
+// writeTrailersTo sets the trailers on a fasthttp response
+// Trailers are the declared "Trailer" keys and keys with http.TrailerPrefix.
+// Reports whether any trailer was set.
+func (w *httpResponseWriter) writeTrailersTo(resp *fasthttp.Response) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	set := false
+	add := func(name string, values []string) {
+		if len(values) == 0 || resp.Header.AddTrailer(name) != nil {
+			return
+		}
+		resp.Header.Set(name, strings.Join(values, ", "))
+		set = true
+	}
+
+	for _, declared := range w.written["Trailer"] {
+		for _, name := range strings.Split(declared, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			add(name, w.header[name])
+		}
+	}
+	for key, values := range w.header {
+		if name, ok := strings.CutPrefix(key, http.TrailerPrefix); ok {
+			add(http.CanonicalHeaderKey(name), values)
+		}
+	}
+
+	return set
+}
+
+// stream sends output to the client until the handler finishes
+// Reports false if the client went away.
+func (w *httpResponseWriter) stream(bw *bufio.Writer, done <-chan struct{}) bool {
+	var chunk []byte
+
+	for finished := false; !finished; {
+		select {
+		case <-w.pending:
+		case <-done:
+			finished = true
+		}
+
+		w.mu.Lock()
+		chunk = append(chunk[:0], w.body.Bytes()...)
+		w.body.Reset()
+		w.mu.Unlock()
+
+		if _, err := bw.Write(chunk); err != nil {
+			w.abort(err)
+			return false
+		}
+		if err := bw.Flush(); err != nil {
+			w.abort(err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// abort makes later writes fail with err
+func (w *httpResponseWriter) abort(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+	w.body.Reset()
+}
+
+// setFastHTTPHeaders copies net/http response headers to a fasthttp response
+// Headers already on the response are replaced. Hop-by-hop headers,
+// Content-Length and trailers are left to the server.
+func setFastHTTPHeaders(resp *fasthttp.Response, header http.Header) {
+	for key, values := range header {
+		switch {
+		case key == "Content-Length", key == "Connection", key == "Transfer-Encoding",
+			key == "Keep-Alive", key == "Trailer", strings.HasPrefix(key, http.TrailerPrefix):
+			continue
+		case key != "Set-Cookie":
+			// Cookies are keyed by name and replace each other
+			resp.Header.Del(key)
+		}
+
+		for _, value := range values {
+			resp.Header.Add(key, value)
+		}
+	}
+}
+
+// removeFastHTTPHeaders removes response headers missing from header
+// Trailers are kept; they are never part of the header map.
+func removeFastHTTPHeaders(resp *fasthttp.Response, header http.Header) {
+	var trailers []string
+	for name := range resp.Header.Trailers() {
+		trailers = append(trailers, string(name))
+	}
+
+	var removed []string
+	for k := range resp.Header.All() {
+		key := string(k)
+		switch key {
+		case "Content-Length", "Content-Type", "Connection", "Transfer-Encoding", "Trailer":
+			continue
+		}
+		if _, ok := header[key]; !ok && !slices.Contains(trailers, key) {
+			removed = append(removed, key)
+		}
+	}
+
+	for _, key := range removed {
+		if key == "Set-Cookie" {
+			resp.Header.DelAllCookies()
+			continue
+		}
+		resp.Header.Del(key)
+	}
+}
+
+// bodyAllowed reports whether a status permits a response body
+func bodyAllowed(status int) bool {
+	return status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,359 @@
+package blaze_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+func TestWrapHTTPHandler(t *testing.T) {
+	app := blaze.New()
+	app.GET("/buffered", blaze.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+		w.Header().Add("X-Multi", "a")
+		w.Header().Add("X-Multi", "b")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.Query().Get("q"), r.Header.Get("X-Token"))
+	})))
+	app.POST("/echo", blaze.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	})))
+	app.GET("/sniff", blaze.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body>hi</body></html>"))
+	})))
+
+	srv := blazetest.New(t, app)
+
+	res := srv.Get("/buffered").
+		WithQuery("q", "go").
+		WithHeader("X-Token", "secret").
+		Do().
+		AssertStatus(http.StatusCreated).
+		AssertHeader("X-Path", "/buffered").
+		AssertBody("GET go secret")
+	if got := res.Header("X-Multi"); got != "a" {
+		t.Errorf("X-Multi = %q, want the first of both values", got)
+	}
+
+	srv.Post("/echo").WithBody("text/plain", []byte("payload")).Do().AssertBody("payload")
+	srv.Get("/sniff").Do().AssertHeaderContains("Content-Type", "text/html")
+}
+
+func TestWrapHTTPHandlerStreams(t *testing.T) {
+	next := make(chan struct{})
+
+	app := blaze.New()
+	app.GET("/stream", blaze.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "part %d\n", i)
+			w.(http.Flusher).Flush()
+			<-next
+		}
+	})))
+
+	srv := blazetest.New(t, app)
+	stream := openStream(t, srv, "/stream")
+
+	// Each flush reaches the client while the handler is still running
+	for i := 1; i <= 3; i++ {
+		want := fmt.Sprintf("part %d", i)
+		for {
+			line, err := stream.ReadString('\n')
+			if err != nil {
+				t.Fatalf("waiting for %q: %v", want, err)
+			}
+			if strings.TrimRight(line, "\r\n") == want {
+				break
+			}
+		}
+		if i < 3 {
+			next <- struct{}{}
+		}
+	}
+	next <- struct{}{}
+}
+
+func TestWrapHTTPHandlerTrailers(t *testing.T) {
+	app := blaze.New()
+	app.GET("/sum", blaze.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "X-Sum")
+		w.Write([]byte("data"))
+		w.Header().Set("X-Sum", "42")
+		w.Header().Set(http.TrailerPrefix+"X-Late", "yes")
+	})))
+
+	srv := httptest.NewServer(app.HTTPHandler())
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if string(body) != "data" {
+		t.Errorf("body = %q", body)
+	}
+	if got := res.Trailer.Get("X-Sum"); got != "42" {
+		t.Errorf("X-Sum trailer = %q, want 42", got)
+	}
+	if got := res.Trailer.Get("X-Late"); got != "yes" {
+		t.Errorf("X-Late trailer = %q, want yes", got)
+	}
+}
+
+func TestWrapHTTPHandlerPanic(t *testing.T) {
+	captureLog(t)
+
+	app := blaze.New()
+	app.Use(blaze.Recovery())
+	app.GET("/panic", blaze.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	blazetest.New(t, app).Get("/panic").Do().AssertStatus(http.StatusInternalServerError)
+}
+
+// contextKey keys values stored by test middleware
+type contextKey string
+
+func TestWrapHTTPMiddleware(t *testing.T) {
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			r.Header.Del("Authorization")
+			r.Header.Set("X-User", "ada")
+			w.Header().Set("X-Auth", "checked")
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey("role"), "admin")))
+		})
+	}
+
+	// Strips the header the handler sets
+	hide := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(&hidingWriter{ResponseWriter: w}, r)
+		})
+	}
+
+	var handled int
+	app := blaze.New()
+	app.Use(blaze.WrapHTTPMiddleware(auth))
+	app.Use(blaze.WrapHTTPMiddleware(hide))
+	app.GET("/me", func(c *blaze.Context) error {
+		handled++
+		c.SetHeader("X-Powered-By", "blaze")
+		role, _ := c.HTTPRequest().Context().Value(contextKey("role")).(string)
+		return c.Text(c.Header("X-User") + " " + role + " " + c.Header("Authorization"))
+	})
+	app.GET("/missing", func(c *blaze.Context) error {
+		return blaze.NewHTTPError(http.StatusTeapot, "TEAPOT", "short and stout")
+	})
+
+	srv := blazetest.New(t, app)
+
+	// Short-circuit: the chain never runs
+	srv.Get("/me").Do().
+		AssertStatus(http.StatusUnauthorized).
+		AssertHeader("WWW-Authenticate", "Bearer").
+		AssertBodyContains("unauthorized")
+	if handled != 0 {
+		t.Errorf("handler ran %d times for a rejected request", handled)
+	}
+
+	// Request changes reach the handler, header deletions reach the client
+	srv.Get("/me").WithHeader("Authorization", "Bearer x").Do().
+		AssertStatus(http.StatusOK).
+		AssertBody("ada admin ").
+		AssertHeader("X-Auth", "checked").
+		AssertHeaderMissing("X-Powered-By")
+
+	// Errors from the chain go to the error handler unchanged
+	srv.Get("/missing").WithHeader("Authorization", "Bearer x").Do().
+		AssertStatus(http.StatusTeapot).
+		AssertJSONPath("error.code", "TEAPOT").
+		AssertHeader("X-Auth", "checked")
+}
+
+// hidingWriter deletes X-Powered-By before the status is written
+type hidingWriter struct {
+	http.ResponseWriter
+}
+
+func (w *hidingWriter) WriteHeader(code int) {
+	w.Header().Del("X-Powered-By")
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func TestWrapHTTPMiddlewareOtherGoroutine(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+
+	app := blaze.New()
+	app.Use(blaze.WrapHTTPMiddleware(func(next http.Handler) http.Handler {
+		return http.TimeoutHandler(next, 10*time.Millisecond, "too slow")
+	}))
+	app.GET("/slow", func(c *blaze.Context) error {
+		<-release
+		defer close(finished)
+		c.SetHeader("X-Slow", "1")
+		return c.Text("done")
+	})
+
+	srv := blazetest.New(t, app)
+
+	// TimeoutHandler answers while the chain is blocked; the chain
+	// finishes before the Context is released
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+	srv.Get("/slow").Do().
+		AssertStatus(http.StatusServiceUnavailable).
+		AssertBody("too slow").
+		AssertHeaderMissing("X-Slow")
+
+	select {
+	case <-finished:
+	default:
+		t.Error("the request completed before the chain returned")
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	config := blaze.DefaultConfig()
+	config.MaxRequestBodySize = 16
+
+	app := blaze.NewWithConfig(config)
+	app.GET("/info", func(c *blaze.Context) error {
+		return c.JSON(blaze.Map{
+			"scheme": c.Scheme(),
+			"tls":    c.IsTLS(),
+			"ip":     c.IP(),
+			"accept": c.Header("Accept"),
+		})
+	})
+	app.POST("/upload", func(c *blaze.Context) error {
+		return c.Text(string(c.PostBody()))
+	})
+	app.GET("/cookie", func(c *blaze.Context) error {
+		c.SetCookie("a", "1")
+		c.SetCookie("b", "2")
+		return c.NoContent()
+	})
+
+	plain := httptest.NewServer(app.HTTPHandler())
+	defer plain.Close()
+
+	res := getJSON(t, plain.Client(), plain.URL+"/info", "application/json")
+	if res["scheme"] != "http" || res["tls"] != false || res["ip"] != "127.0.0.1" || res["accept"] != "application/json" {
+		t.Errorf("plain request seen as %v", res)
+	}
+
+	post := func(body string) *http.Response {
+		resp, err := plain.Client().Post(plain.URL+"/upload", "text/plain", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	if resp := post("small"); resp.StatusCode != http.StatusOK {
+		t.Errorf("small body: status %d", resp.StatusCode)
+	}
+	if resp := post(strings.Repeat("x", 64)); resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: status %d, want 413", resp.StatusCode)
+	}
+
+	resp, err := plain.Client().Get(plain.URL + "/cookie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if n := len(resp.Cookies()); n != 2 {
+		t.Errorf("got %d cookies, want 2", n)
+	}
+
+	secure := httptest.NewTLSServer(app.HTTPHandler())
+	defer secure.Close()
+
+	res = getJSON(t, secure.Client(), secure.URL+"/info", "")
+	if res["scheme"] != "https" || res["tls"] != true {
+		t.Errorf("TLS request seen as %v", res)
+	}
+}
+
+// getJSON fetches url and decodes the JSON object it returns
+func getJSON(t *testing.T, client *http.Client, url, accept string) map[string]interface{} {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var v map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestHTTPHandlerSSE(t *testing.T) {
+	next := make(chan struct{})
+
+	app := blaze.New()
+	app.GET("/events", func(c *blaze.Context) error {
+		return c.SSE(func(w *blaze.SSEWriter) error {
+			for i := 1; i <= 2; i++ {
+				if err := w.Data(fmt.Sprintf("tick %d", i)); err != nil {
+					return err
+				}
+				<-next
+			}
+			return nil
+		})
+	})
+
+	srv := httptest.NewServer(app.HTTPHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	// Events arrive before the stream ends
+	reader := bufio.NewReader(resp.Body)
+	for i := 1; i <= 2; i++ {
+		if event := readSSEEvent(t, reader); event["data"] != fmt.Sprintf("tick %d", i) {
+			t.Fatalf("got event %v", event)
+		}
+		next <- struct{}{}
+	}
+}
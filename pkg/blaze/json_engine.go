@@ -0,0 +1,413 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// ==================== JSON Engine ====================
+
+// JSONEncoder encodes values as JSON
+// The App's encoder writes c.JSON responses, JSON negotiated by
+// c.Negotiate and typed handlers, error responses and WebSocket messages.
+type JSONEncoder interface {
+	Marshal(v interface{}) ([]byte, error)
+}
+
+// JSONDecoder decodes JSON into values
+// The App's decoder reads bodies bound with Bind, BindJSON and typed
+// handlers, and WebSocket messages read with ReadJSON.
+type JSONDecoder interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONEngine is a JSONEncoder and JSONDecoder in one
+type JSONEngine interface {
+	JSONEncoder
+	JSONDecoder
+}
+
+// JSONOptions configures the built-in JSON engines
+type JSONOptions struct {
+	// DisallowUnknownFields rejects object keys that match no struct field
+	DisallowUnknownFields bool
+
+	// UseNumber decodes numbers into interface{} values as json.Number
+	// instead of float64, keeping large integers exact
+	UseNumber bool
+
+	// EscapeHTML escapes <, > and & in strings as \u003c, \u003e and \u0026
+	EscapeHTML bool
+
+	// MaxDepth limits the nesting of decoded arrays and objects
+	// 0 leaves the limit to the engine (10000 for json-iterator).
+	MaxDepth int
+}
+
+// DefaultJSONOptions returns the options of the default engine
+// The defaults match encoding/json: HTML is escaped, unknown fields are
+// ignored and numbers decode to float64.
+//
+// Returns:
+//   - JSONOptions: Default options
+func DefaultJSONOptions() JSONOptions {
+	return JSONOptions{
+		EscapeHTML: true,
+	}
+}
+
+// defaultJSONEngine is used by apps without an engine of their own
+var defaultJSONEngine JSONEngine = NewJSONIterEngine(DefaultJSONOptions())
+
+// DefaultJSONEngine returns the engine used by apps without an engine of
+// their own and by Map and HTTPError when they are encoded outside an app
+//
+// Returns:
+//   - JSONEngine: Process-wide default engine
+func DefaultJSONEngine() JSONEngine {
+	return defaultJSONEngine
+}
+
+// SetDefaultJSONEngine replaces the process-wide default engine
+// Apps that set their own encoder or decoder keep it. The engine also
+// writes Map.ToJSON, Map.ToJSONBytes and the MarshalJSON methods of Map
+// and HTTPError, which have no app to take one from.
+//
+// Call before creating apps and serving requests.
+//
+// Parameters:
+//   - engine: JSON engine (nil restores json-iterator with the default
+//     options)
+//
+// Example:
+//
+//	options := blaze.DefaultJSONOptions()
+//	options.EscapeHTML = false
+//	blaze.SetDefaultJSONEngine(blaze.NewJSONIterEngine(options))
+func SetDefaultJSONEngine(engine JSONEngine) {
+	if engine == nil {
+		engine = NewJSONIterEngine(DefaultJSONOptions())
+	}
+	defaultJSONEngine = engine
+}
+
+// NewJSONIterEngine returns a JSON engine backed by json-iterator
+// This is the default engine. Its output matches encoding/json, with map
+// keys sorted. Map and HTTPError values are written with the engine's
+// own options wherever they are nested.
+//
+// Parameters:
+//   - options: Engine options
+//
+// Returns:
+//   - JSONEngine: json-iterator engine
+//
+// Example:
+//
+//	options := blaze.DefaultJSONOptions()
+//	options.DisallowUnknownFields = true
+//	engine := blaze.NewJSONIterEngine(options)
+//	app.SetJSONEncoder(engine).SetJSONDecoder(engine)
+func NewJSONIterEngine(options JSONOptions) JSONEngine {
+	api := jsoniter.Config{
+		EscapeHTML:             options.EscapeHTML,
+		SortMapKeys:            true,
+		ValidateJsonRawMessage: true,
+		UseNumber:              options.UseNumber,
+		DisallowUnknownFields:  options.DisallowUnknownFields,
+	}.Froze()
+	api.RegisterExtension(&plainJSONExtension{})
+
+	return &jsonIterEngine{
+		api:      api,
+		maxDepth: options.MaxDepth,
+	}
+}
+
+// NewStdJSONEngine returns a JSON engine backed by encoding/json
+// Use it for exact encoding/json behavior, such as its error messages.
+// encoding/json always calls MarshalJSON, so Map and HTTPError values
+// nested in other values are written by the default engine; top-level
+// ones use this engine's options.
+//
+// Parameters:
+//   - options: Engine options
+//
+// Returns:
+//   - JSONEngine: encoding/json engine
+//
+// Example:
+//
+//	engine := blaze.NewStdJSONEngine(blaze.DefaultJSONOptions())
+//	app.SetJSONEncoder(engine).SetJSONDecoder(engine)
+func NewStdJSONEngine(options JSONOptions) JSONEngine {
+	return &stdJSONEngine{options: options}
+}
+
+// jsonIterEngine encodes with a frozen json-iterator configuration
+type jsonIterEngine struct {
+	api      jsoniter.API
+	maxDepth int
+}
+
+func (e *jsonIterEngine) Marshal(v interface{}) ([]byte, error) {
+	return e.api.Marshal(v)
+}
+
+func (e *jsonIterEngine) Unmarshal(data []byte, v interface{}) error {
+	if err := checkJSONDepth(data, e.maxDepth); err != nil {
+		return err
+	}
+	return e.api.Unmarshal(data, v)
+}
+
+// stdJSONEngine encodes with encoding/json
+type stdJSONEngine struct {
+	options JSONOptions
+}
+
+func (e *stdJSONEngine) Marshal(v interface{}) ([]byte, error) {
+	v = plainJSONValue(v)
+	if e.options.EscapeHTML {
+		return json.Marshal(v)
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+func (e *stdJSONEngine) Unmarshal(data []byte, v interface{}) error {
+	if err := checkJSONDepth(data, e.options.MaxDepth); err != nil {
+		return err
+	}
+	if !e.options.DisallowUnknownFields && !e.options.UseNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if e.options.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if e.options.UseNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	// Unmarshal rejects data after the value, the Decoder does not
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after top-level value at offset %d", dec.InputOffset())
+	}
+	return nil
+}
+
+// plainJSONValue returns v without its MarshalJSON method when v is a Map
+// or *HTTPError, so the engine encoding it applies its own options
+func plainJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case Map:
+		return map[string]interface{}(v)
+	case *HTTPError:
+		if v != nil {
+			return v.jsonValue()
+		}
+	}
+	return v
+}
+
+// plainJSONExtension makes json-iterator encode Map and *HTTPError values
+// with its own configuration instead of their MarshalJSON methods
+type plainJSONExtension struct {
+	jsoniter.DummyExtension
+}
+
+var (
+	mapType       = reflect.TypeOf(Map(nil))
+	httpErrorType = reflect.TypeOf((*HTTPError)(nil))
+)
+
+func (*plainJSONExtension) CreateEncoder(typ reflect2.Type) jsoniter.ValEncoder {
+	switch typ.Type1() {
+	case mapType:
+		return plainJSONEncoder(func(ptr unsafe.Pointer) interface{} {
+			if m := *(*Map)(ptr); m != nil {
+				return map[string]interface{}(m)
+			}
+			return nil
+		})
+	case httpErrorType:
+		return plainJSONEncoder(func(ptr unsafe.Pointer) interface{} {
+			if e := *(**HTTPError)(ptr); e != nil {
+				return e.jsonValue()
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// plainJSONEncoder writes the plain value of the Map or *HTTPError at ptr
+type plainJSONEncoder func(ptr unsafe.Pointer) interface{}
+
+func (value plainJSONEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	v := value(ptr)
+	if m, ok := v.(map[string]interface{}); ok {
+		return len(m) == 0
+	}
+	return v == nil
+}
+
+func (value plainJSONEncoder) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	stream.WriteVal(value(ptr))
+}
+
+// checkJSONDepth reports an error when arrays and objects in data nest
+// deeper than maxDepth (0 = no limit)
+// Brackets inside strings are skipped; syntax errors are left to the
+// decoder.
+func checkJSONDepth(data []byte, maxDepth int) error {
+	if maxDepth <= 0 {
+		return nil
+	}
+
+	depth, inString := 0, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '[', '{':
+			depth++
+			if depth > maxDepth {
+				return fmt.Errorf("json: nesting depth exceeds maximum of %d at offset %d", maxDepth, i)
+			}
+		case ']', '}':
+			depth--
+		}
+	}
+	return nil
+}
+
+// ==================== App JSON Settings ====================
+
+// SetJSONEncoder sets the JSON encoder of the app
+// The encoder writes c.JSON, c.JSONStatus and c.JSONPretty responses, JSON
+// chosen by c.Negotiate and typed handlers, error responses written by the
+// app and the error handler, and WebSocket WriteJSON messages. Apps
+// mounted on this one use it unless they set their own.
+//
+// Call before serving requests.
+//
+// Parameters:
+//   - encoder: JSON encoder (nil restores the default)
+//
+// Returns:
+//   - *App: App instance for method chaining
+//
+// Example:
+//
+//	app.SetJSONEncoder(blaze.NewStdJSONEngine(blaze.DefaultJSONOptions()))
+func (a *App) SetJSONEncoder(encoder JSONEncoder) *App {
+	a.jsonEncoder = encoder
+	return a
+}
+
+// SetJSONDecoder sets the JSON decoder of the app
+// The decoder reads JSON bodies bound with Bind, BindJSON and typed
+// handlers, and WebSocket ReadJSON messages. Apps mounted on this one use
+// it unless they set their own.
+//
+// Call before serving requests.
+//
+// Parameters:
+//   - decoder: JSON decoder (nil restores the default)
+//
+// Returns:
+//   - *App: App instance for method chaining
+//
+// Example:
+//
+//	options := blaze.DefaultJSONOptions()
+//	options.DisallowUnknownFields = true
+//	options.MaxDepth = 32
+//	app.SetJSONDecoder(blaze.NewJSONIterEngine(options))
+func (a *App) SetJSONDecoder(decoder JSONDecoder) *App {
+	a.jsonDecoder = decoder
+	return a
+}
+
+// JSONEncoder returns the JSON encoder used by the app
+// Falls back to the encoder of the app it is mounted on, then to the
+// default engine (see SetDefaultJSONEngine).
+//
+// Returns:
+//   - JSONEncoder: Effective encoder
+func (a *App) JSONEncoder() JSONEncoder {
+	for app := a; app != nil; app = app.parent {
+		if app.jsonEncoder != nil {
+			return app.jsonEncoder
+		}
+	}
+	return defaultJSONEngine
+}
+
+// JSONDecoder returns the JSON decoder used by the app
+// Falls back to the decoder of the app it is mounted on, then to the
+// default engine (see SetDefaultJSONEngine).
+//
+// Returns:
+//   - JSONDecoder: Effective decoder
+func (a *App) JSONDecoder() JSONDecoder {
+	for app := a; app != nil; app = app.parent {
+		if app.jsonDecoder != nil {
+			return app.jsonDecoder
+		}
+	}
+	return defaultJSONEngine
+}
+
+// hasJSONEngine reports whether a or an app it is mounted on replaced the
+// default encoder or decoder
+func (a *App) hasJSONEngine() bool {
+	for app := a; app != nil; app = app.parent {
+		if app.jsonEncoder != nil || app.jsonDecoder != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEncoder returns the JSON encoder of the app serving the request
+func (c *Context) jsonEncoder() JSONEncoder {
+	app, _ := c.Locals("__app__").(*App)
+	return app.JSONEncoder()
+}
+
+// jsonDecoder returns the JSON decoder of the app serving the request
+func (c *Context) jsonDecoder() JSONDecoder {
+	app, _ := c.Locals("__app__").(*App)
+	return app.JSONDecoder()
+}
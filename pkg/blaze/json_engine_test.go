@@ -0,0 +1,178 @@
+package blaze_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+// countingEngine wraps an engine and counts its calls
+type countingEngine struct {
+	blaze.JSONEngine
+	marshals, unmarshals atomic.Int32
+}
+
+func (e *countingEngine) Marshal(v interface{}) ([]byte, error) {
+	e.marshals.Add(1)
+	return e.JSONEngine.Marshal(v)
+}
+
+func (e *countingEngine) Unmarshal(data []byte, v interface{}) error {
+	e.unmarshals.Add(1)
+	return e.JSONEngine.Unmarshal(data, v)
+}
+
+func jsonEngineApp() *blaze.App {
+	app := blaze.New()
+	app.GET("/map", func(c *blaze.Context) error {
+		return c.JSON(blaze.Map{"html": "<b>", "nested": []interface{}{blaze.Map{"html": "<i>"}}})
+	})
+	app.POST("/bind", func(c *blaze.Context) error {
+		var body map[string]interface{}
+		if err := c.Bind(&body); err != nil {
+			return blaze.ErrBadRequest(err.Error())
+		}
+		return c.JSON(body)
+	})
+	app.GET("/error", func(c *blaze.Context) error {
+		return blaze.ErrBadRequest("<bad>").WithDetails(blaze.Map{"field": "<name>"})
+	})
+	blaze.POST(app, "/typed", func(c *blaze.Context, req map[string]interface{}) (map[string]interface{}, error) {
+		return req, nil
+	})
+	return app
+}
+
+func TestJSONEngineCustom(t *testing.T) {
+	engine := &countingEngine{JSONEngine: blaze.NewStdJSONEngine(blaze.DefaultJSONOptions())}
+
+	app := jsonEngineApp()
+	app.SetJSONEncoder(engine).SetJSONDecoder(engine)
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/map").Do().AssertJSONPath("html", "<b>")
+	if n := engine.marshals.Load(); n != 1 {
+		t.Errorf("c.JSON: %d marshals, want 1", n)
+	}
+
+	srv.Post("/bind").WithJSON(blaze.Map{"a": 1}).Do().AssertJSONPath("a", float64(1))
+	if n := engine.unmarshals.Load(); n != 1 {
+		t.Errorf("Bind: %d unmarshals, want 1", n)
+	}
+
+	srv.Get("/error").Do().AssertStatus(http.StatusBadRequest).AssertJSONPath("error.message", "<bad>")
+	if n := engine.marshals.Load(); n != 3 {
+		t.Errorf("error response: %d marshals in total, want 3", n)
+	}
+
+	srv.Post("/typed").WithJSON(blaze.Map{"a": 1}).Do().AssertJSONPath("a", float64(1))
+	if n := engine.unmarshals.Load(); n != 2 {
+		t.Errorf("typed handler: %d unmarshals in total, want 2", n)
+	}
+}
+
+func TestJSONEngineOptions(t *testing.T) {
+	options := blaze.DefaultJSONOptions()
+	options.EscapeHTML = false
+
+	for name, engine := range map[string]blaze.JSONEngine{
+		"jsoniter": blaze.NewJSONIterEngine(options),
+		"std":      blaze.NewStdJSONEngine(options),
+	} {
+		t.Run(name, func(t *testing.T) {
+			app := jsonEngineApp()
+			app.SetJSONEncoder(engine)
+
+			srv := blazetest.New(t, app)
+			srv.Get("/map").Do().AssertBodyContains(`"html":"<b>"`)
+			srv.Get("/error").Do().AssertBodyContains(`"message":"<bad>"`)
+		})
+	}
+
+	// json-iterator applies its options to nested Map values too
+	app := jsonEngineApp()
+	app.SetJSONEncoder(blaze.NewJSONIterEngine(options))
+
+	srv := blazetest.New(t, app)
+	srv.Get("/map").Do().AssertBodyContains(`"nested":[{"html":"<i>"}]`)
+	srv.Get("/error").Do().AssertBodyContains(`"details":{"field":"<name>"}`)
+}
+
+func TestJSONEngineMaxDepth(t *testing.T) {
+	deep := strings.Repeat("[", 5) + strings.Repeat("]", 5)
+
+	for name, newEngine := range map[string]func(blaze.JSONOptions) blaze.JSONEngine{
+		"jsoniter": blaze.NewJSONIterEngine,
+		"std":      blaze.NewStdJSONEngine,
+	} {
+		t.Run(name, func(t *testing.T) {
+			options := blaze.DefaultJSONOptions()
+			options.MaxDepth = 3
+
+			app := jsonEngineApp()
+			app.SetJSONDecoder(newEngine(options))
+
+			srv := blazetest.New(t, app)
+
+			srv.Post("/bind").WithBody("application/json", []byte(`{"a":[[1]]}`)).Do().
+				AssertStatus(http.StatusOK)
+			srv.Post("/bind").WithBody("application/json", []byte(`{"a":`+deep+`}`)).Do().
+				AssertStatus(http.StatusBadRequest).
+				AssertBodyContains("nesting depth exceeds maximum of 3")
+			srv.Post("/typed").WithBody("application/json", []byte(`{"a":`+deep+`}`)).Do().
+				AssertStatus(http.StatusBadRequest).
+				AssertJSONPath("error.code", "BAD_REQUEST").
+				AssertBodyContains("nesting depth exceeds maximum of 3")
+		})
+	}
+}
+
+func TestJSONEngineMounted(t *testing.T) {
+	engine := &countingEngine{JSONEngine: blaze.NewJSONIterEngine(blaze.DefaultJSONOptions())}
+
+	app := blaze.New()
+	app.SetJSONEncoder(engine)
+	app.Mount("/sub", jsonEngineApp())
+
+	blazetest.New(t, app).Get("/sub/map").Do().AssertStatus(http.StatusOK)
+	if n := engine.marshals.Load(); n != 1 {
+		t.Errorf("mounted app: %d marshals, want 1", n)
+	}
+}
+
+func TestDefaultJSONEngine(t *testing.T) {
+	m := blaze.Map{"html": "<b>"}
+	httpErr := blaze.ErrBadRequest("<bad>").WithInternal(errors.New("db down"))
+
+	if got, _ := m.ToJSON(); got != `{"html":"\u003cb\u003e"}` {
+		t.Errorf("ToJSON = %s", got)
+	}
+	if data, _ := json.Marshal(httpErr); !strings.Contains(string(data), `"internal":"db down"`) {
+		t.Errorf("HTTPError JSON = %s", data)
+	}
+
+	options := blaze.DefaultJSONOptions()
+	options.EscapeHTML = false
+	blaze.SetDefaultJSONEngine(blaze.NewJSONIterEngine(options))
+	t.Cleanup(func() { blaze.SetDefaultJSONEngine(nil) })
+
+	if got, _ := m.ToJSON(); got != `{"html":"<b>"}` {
+		t.Errorf("ToJSON with the default engine replaced = %s", got)
+	}
+	if data, _ := m.MarshalJSON(); string(data) != `{"html":"<b>"}` {
+		t.Errorf("Map.MarshalJSON = %s", data)
+	}
+	if data, _ := httpErr.MarshalJSON(); !strings.Contains(string(data), `"message":"<bad>"`) {
+		t.Errorf("HTTPError.MarshalJSON = %s", data)
+	}
+
+	// Apps without an engine of their own follow the default
+	blazetest.New(t, jsonEngineApp()).Get("/map").Do().AssertBodyContains(`"html":"<b>"`)
+}
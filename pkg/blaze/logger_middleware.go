@@ -283,7 +283,7 @@ func LoggerMiddlewareWithConfig(config LoggerMiddlewareConfig) MiddlewareFunc {
 			}
 
 			// Add request body if enabled
-			if config.LogRequestBody && len(c.Body()) > 0 {
+			if config.LogRequestBody && !c.IsBodyStreamed() && len(c.Body()) > 0 {
 				reqLogger = reqLogger.With("request_body", string(c.Body()))
 			}
 
@@ -313,7 +313,7 @@ func LoggerMiddlewareWithConfig(config LoggerMiddlewareConfig) MiddlewareFunc {
 			)
 
 			// Add response body if enabled and present
-			if config.LogResponseBody {
+			if config.LogResponseBody && !c.Response().IsBodyStream() {
 				body := c.Response().Body()
 				if len(body) > 0 && len(body) < 1024*10 { // Limit to 10KB
 					resLogger = resLogger.With("response_body", string(body))
@@ -397,6 +397,12 @@ func AccessLogMiddleware(logger *Loggerlog) MiddlewareFunc {
 			// Execute handler
 			err := next(c)
 
+			// The size of streamed bodies (SSE) is unknown here
+			size := 0
+			if !c.Response().IsBodyStream() {
+				size = len(c.Response().Body())
+			}
+
 			// Apache Combined Log Format:
 			// %h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-agent}i"
 			logger.Info("access",
@@ -406,7 +412,7 @@ func AccessLogMiddleware(logger *Loggerlog) MiddlewareFunc {
 				"path", c.Path(),
 				"protocol", c.Protocol(),
 				"status", c.Response().StatusCode(),
-				"bytes", len(c.Response().Body()),
+				"bytes", size,
 				"referer", c.Header("Referer"),
 				"user_agent", c.UserAgent(),
 				"duration_ms", time.Since(start).Milliseconds(),
@@ -5,6 +5,8 @@ import (
 	"log"
 	"strings"
 	"time"
+
+	"github.com/valyala/fasthttp"
 )
 
 // Logger middleware logs HTTP requests with essential information
@@ -220,7 +222,7 @@ func ShutdownAware() MiddlewareFunc {
 //   - Sets maximum duration for request processing
 //   - Returns 408 Request Timeout if exceeded
 //   - Returns 503 if shutdown occurs during request
-//   - Cancels handler context on timeout
+//   - The handler keeps running; responses it writes after the timeout are discarded
 //
 // Shutdown Integration:
 //   - Monitors shutdown context
@@ -239,14 +241,19 @@ func ShutdownAware() MiddlewareFunc {
 //
 // Example - Per-Route Timeout:
 //
-//	app.GET("/fast", handler, blaze.GracefulTimeout(1 * time.Second))
-//	app.GET("/slow", handler, blaze.GracefulTimeout(30 * time.Second))
+//	app.GET("/fast", handler, blaze.WithTimeout(1*time.Second))
+//	app.GET("/slow", handler, blaze.WithTimeout(30*time.Second))
 func GracefulTimeout(timeout time.Duration) MiddlewareFunc {
 	return func(next HandlerFunc) HandlerFunc {
 		return func(c *Context) error {
 			ctx, cancel := c.WithTimeout(timeout)
 			defer cancel()
 
+			// Headers set by outer middleware (CORS, request ID) are kept
+			// on the timeout response
+			var timeoutResp fasthttp.Response
+			c.RequestCtx.Response.Header.CopyTo(&timeoutResp.Header)
+
 			done := make(chan error, 1)
 			go func() {
 				done <- next(c)
@@ -256,16 +263,33 @@ func GracefulTimeout(timeout time.Duration) MiddlewareFunc {
 			case err := <-done:
 				return err
 			case <-ctx.Done():
+				// next keeps running with c after this returns, so the
+				// timeout response is sent from a separate Response and
+				// the server does not reuse the request context
+				c.retain()
+
+				body := Map{
+					"error":   "Request Timeout",
+					"message": "Request exceeded timeout limit",
+				}
+				timeoutResp.SetStatusCode(fasthttp.StatusRequestTimeout)
 				if c.IsShuttingDown() {
-					return c.Status(503).JSON(Map{
+					body = Map{
 						"error":   "Service Unavailable",
 						"message": "Server is shutting down",
-					})
+					}
+					timeoutResp.SetStatusCode(fasthttp.StatusServiceUnavailable)
 				}
-				return c.Status(408).JSON(Map{
-					"error":   "Request Timeout",
-					"message": "Request exceeded timeout limit",
-				})
+
+				data, err := c.jsonEncoder().Marshal(body)
+				if err != nil {
+					return err
+				}
+				timeoutResp.Header.SetContentType("application/json")
+				timeoutResp.SetBody(data)
+
+				c.RequestCtx.TimeoutErrorWithResponse(&timeoutResp)
+				return nil
 			}
 		}
 	}
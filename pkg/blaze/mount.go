@@ -0,0 +1,232 @@
+package blaze
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/valyala/fasthttp"
+)
+
+// mountedApp is a sub-application served under a path prefix
+type mountedApp struct {
+	// prefix is the normalized mount path ("/billing"), empty for the root
+	prefix string
+
+	// app handles every request under prefix
+	app *App
+
+	// caseSensitive mirrors the parent's router setting for prefix matching
+	caseSensitive bool
+
+	// chain is serve wrapped in the parent's global middleware
+	// Replaced atomically when the parent's middleware changes
+	chain atomic.Pointer[HandlerFunc]
+}
+
+// Mount serves an independent application under a path prefix
+// Every request whose path is the prefix or starts with prefix + "/" is
+// handed to sub with the prefix stripped, so sub registers its routes
+// as if it were served at the root.
+//
+// Request Flow:
+//  1. Parent global middleware
+//  2. Sub-app host selection, global middleware and routes
+//  3. Sub-app not found / 405 handlers for unmatched paths
+//
+// The mounted app keeps its own router, middleware, error handling,
+// state and 404/405 handlers. Server settings (ports, TLS, timeouts)
+// come from the parent. The mount owns its whole prefix: parent routes
+// under the prefix are never reached. The longest matching prefix wins
+// when mounts are nested.
+//
+// Lifecycle:
+//   - Shutting down the parent shuts down mounted apps
+//   - Tasks registered with RegisterGracefulTask on a mounted app run
+//     during the parent's graceful shutdown
+//   - ValidateRoutes and GetRouteInfo include mounted apps
+//   - URL and URLFor on the sub-app include the mount prefix
+//
+// Panics if sub is already mounted or is the app itself.
+//
+// Parameters:
+//   - prefix: Path prefix (e.g. "/billing")
+//   - sub: Application to mount
+//
+// Returns:
+//   - *App: App instance for method chaining
+//
+// Example:
+//
+//	billing := blaze.New()
+//	billing.UseErrorHandler(nil)
+//	billing.SetState("provider", stripeClient)
+//	billing.GET("/invoices/:id", getInvoice) // served at /billing/invoices/:id
+//
+//	app := blaze.New()
+//	app.Use(blaze.Logger())
+//	app.Mount("/billing", billing)
+func (a *App) Mount(prefix string, sub *App) *App {
+	if sub == a {
+		panic("blaze: an app cannot be mounted on itself")
+	}
+	if sub.parent != nil {
+		panic("blaze: app is already mounted under " + sub.mountPath())
+	}
+
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		prefix = ""
+	}
+
+	sub.parent = a
+	sub.mountPrefix = prefix
+
+	m := &mountedApp{
+		prefix:        prefix,
+		app:           sub,
+		caseSensitive: a.router.config.CaseSensitive,
+	}
+	m.compile(a.outerMiddleware())
+	a.mounts = append(a.mounts, m)
+
+	// Longest prefix first
+	sort.SliceStable(a.mounts, func(i, j int) bool {
+		return len(a.mounts[i].prefix) > len(a.mounts[j].prefix)
+	})
+
+	return a
+}
+
+// matchMount returns the mounted app serving path
+func (a *App) matchMount(path string) *mountedApp {
+	for _, m := range a.mounts {
+		if _, ok := m.strip(path); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// strip removes the mount prefix from path
+// Reports false when path is outside the mount
+func (m *mountedApp) strip(path string) (string, bool) {
+	if len(path) < len(m.prefix) {
+		return "", false
+	}
+
+	head := path[:len(m.prefix)]
+	if m.caseSensitive && head != m.prefix || !m.caseSensitive && !strings.EqualFold(head, m.prefix) {
+		return "", false
+	}
+
+	rest := path[len(m.prefix):]
+	switch {
+	case rest == "":
+		return "/", true
+	case rest[0] == '/':
+		return rest, true
+	default:
+		// "/billingx" is not under "/billing"
+		return "", false
+	}
+}
+
+// serve handles the request with the mounted app
+// The request path, app and shutdown context are restored afterwards so
+// parent middleware sees the original request.
+func (m *mountedApp) serve(c *Context) error {
+	sub := m.app
+	if sub.IsShuttingDown() {
+		c.SetStatusCode(fasthttp.StatusServiceUnavailable)
+		c.SetBodyString("Server is shutting down")
+		return nil
+	}
+
+	// Parent middleware may have rewritten the path
+	rest, ok := m.strip(c.Path())
+	if !ok {
+		return ErrNotFound("Not Found")
+	}
+
+	uri := c.URI()
+	original := append([]byte(nil), uri.Path()...)
+	app, shutdownCtx := c.Locals("__app__"), c.Locals("shutdown_ctx")
+
+	uri.SetPath(rest)
+	defer func() {
+		uri.SetPathBytes(original)
+		c.SetLocals("__app__", app)
+		c.SetLocals("shutdown_ctx", shutdownCtx)
+	}()
+
+	// The sub-app writes its own errors
+	sub.serve(c)
+	return nil
+}
+
+// mountPath returns the full path prefix the app is served under
+// Empty for apps that are not mounted
+func (a *App) mountPath() string {
+	if a.parent == nil {
+		return ""
+	}
+	return a.parent.mountPath() + a.mountPrefix
+}
+
+// joinMountPath prefixes a path (optionally with a query) with a mount path
+func joinMountPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "/" || strings.HasPrefix(path, "/?") {
+		return prefix + path[1:]
+	}
+	return prefix + path
+}
+
+// GetRouteInfo returns information about every route served by the app
+// Includes mounted apps, with patterns prefixed by their mount path.
+// Keys have the form "METHOD:pattern".
+//
+// Returns:
+//   - map[string]*RouteInfo: Route information keyed by method and pattern
+//
+// Example:
+//
+//	for key, info := range app.GetRouteInfo() {
+//	    log.Printf("%s -> %s", key, info.Source)
+//	}
+func (a *App) GetRouteInfo() map[string]*RouteInfo {
+	info := a.router.GetRouteInfo()
+
+	for _, m := range a.mounts {
+		for _, route := range m.app.GetRouteInfo() {
+			mounted := *route
+			mounted.Pattern = joinMountPath(m.prefix, route.Pattern)
+			info[mounted.Method+":"+mounted.Pattern] = &mounted
+		}
+	}
+
+	return info
+}
+
+// beginShutdown marks the app and its mounted apps as shutting down and
+// cancels their shutdown contexts, starting registered graceful tasks
+func (a *App) beginShutdown() {
+	a.setShuttingDown(true)
+	a.shutdownCancel()
+
+	for _, m := range a.mounts {
+		m.app.beginShutdown()
+	}
+}
+
+// waitGracefulTasks waits for graceful tasks of the app and its mounted apps
+func (a *App) waitGracefulTasks() {
+	a.shutdownWg.Wait()
+
+	for _, m := range a.mounts {
+		m.app.waitGracefulTasks()
+	}
+}
@@ -97,6 +97,13 @@ type MultipartForm struct {
 	// Each field name maps to a slice of MultipartFile (supports multiple files)
 	// Example: {"avatar": []MultipartFile{...}, "documents": []MultipartFile{...}}
 	File map[string][]*MultipartFile
+
+	// nested lists the child keys of each key prefix for bracket and dot
+	// notation ("items" -> "0", "1"), set when the form is bound
+	nested map[string][]string
+
+	// maxIndex is the largest slice index accepted when binding
+	maxIndex int
 }
 
 // MultipartConfig holds multipart form parsing and validation configuration
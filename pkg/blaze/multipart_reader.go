@@ -0,0 +1,343 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ==================== Streaming Multipart ====================
+
+// MultipartPart is a single field or file of a multipart form, read as it
+// arrives
+// A part is an io.Reader over its content. Reads are counted in Size and
+// fail with a 413 HTTPError once the part exceeds its limit (MaxFileSize
+// for files, the remaining MaxMemory for field values). A part is only
+// valid inside the MultipartReader callback it was passed to.
+type MultipartPart struct {
+	// FieldName is the form field name of the part
+	FieldName string
+
+	// Filename is the original filename, empty for form fields
+	// May contain unsafe characters; SaveToDir sanitizes it
+	Filename string
+
+	// Header contains the MIME headers of the part
+	Header map[string][]string
+
+	// ContentType is the MIME type from the part's Content-Type header
+	// Can be spoofed by clients - validate file content for security
+	ContentType string
+
+	// Size is the number of bytes read from the part so far
+	Size int64
+
+	part  *multipart.Part
+	limit int64 // -1 = no limit
+	over  string
+	err   error
+}
+
+// MultipartReader reads a multipart form part by part with the default
+// configuration
+// See MultipartReaderWithConfig.
+//
+// Parameters:
+//   - fn: Function called with each part, in order
+//
+// Returns:
+//   - error: The first error of fn, or a 400, 413 or 415 HTTPError
+//
+// Example:
+//
+//	return c.MultipartReader(func(part *blaze.MultipartPart) error {
+//	    if !part.IsFile() {
+//	        value, err := part.Value()
+//	        log.Printf("%s = %s", part.FieldName, value)
+//	        return err
+//	    }
+//	    _, err := part.SaveToDir("./uploads")
+//	    return err
+//	})
+func (c *Context) MultipartReader(fn func(part *MultipartPart) error) error {
+	return c.MultipartReaderWithConfig(DefaultMultipartConfig(), fn)
+}
+
+// MultipartReaderWithConfig reads a multipart form part by part
+// Unlike MultipartFormWithConfig, nothing is buffered or written to
+// TempDir: fn is called with each field and file as it arrives and reads
+// the content itself, so files can be streamed straight to their
+// destination. Combined with WithStreamingBody, uploads of any size are
+// processed with constant memory.
+//
+// Limits from config:
+//   - AllowedExtensions, AllowedMimeTypes: Checked from the part headers,
+//     before fn is called, so rejected files are never read (415)
+//   - MaxFiles: Checked when a file part starts (413)
+//   - MaxFileSize: Enforced while a file part is read (413)
+//   - MaxMemory: Total size of the field values (413)
+//
+// Content fn leaves unread is skipped. Errors returned by fn stop the
+// parsing and are returned as is.
+//
+// Parameters:
+//   - config: Multipart limits
+//   - fn: Function called with each part, in order
+//
+// Returns:
+//   - error: The first error of fn, or a 400, 413 or 415 HTTPError
+//
+// Example:
+//
+//	config := blaze.DefaultMultipartConfig()
+//	config.MaxFileSize = 5 << 30 // 5GB
+//	config.AllowedExtensions = []string{".mp4", ".mov"}
+//
+//	app.POST("/videos", func(c *blaze.Context) error {
+//	    var saved []blaze.Map
+//	    err := c.MultipartReaderWithConfig(config, func(part *blaze.MultipartPart) error {
+//	        if !part.IsFile() {
+//	            return nil
+//	        }
+//	        sum := sha256.New()
+//	        path, err := part.SaveToDir("./videos", sum)
+//	        if err != nil {
+//	            return err
+//	        }
+//	        saved = append(saved, blaze.Map{
+//	            "path":   path,
+//	            "size":   part.Size,
+//	            "sha256": hex.EncodeToString(sum.Sum(nil)),
+//	        })
+//	        return nil
+//	    })
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return c.JSON(saved)
+//	}, blaze.WithStreamingBody(), blaze.WithBodyLimit(6<<30))
+func (c *Context) MultipartReaderWithConfig(config *MultipartConfig, fn func(part *MultipartPart) error) error {
+	boundary := string(c.RequestCtx.Request.Header.MultipartFormBoundary())
+	if boundary == "" {
+		return NewHTTPError(http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType,
+			"Expected a multipart/form-data body").AsProblem()
+	}
+
+	reader := multipart.NewReader(c.BodyStream(), boundary)
+	files := 0
+	var fieldBytes int64
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return multipartError(err)
+		}
+
+		p := &MultipartPart{
+			FieldName:   part.FormName(),
+			Filename:    part.FileName(),
+			Header:      part.Header,
+			ContentType: part.Header.Get("Content-Type"),
+			part:        part,
+			limit:       -1,
+		}
+
+		if p.IsFile() {
+			if config.MaxFiles > 0 && files >= config.MaxFiles {
+				return ErrPayloadTooLarge(fmt.Sprintf("Maximum number of files (%d) exceeded", config.MaxFiles))
+			}
+			if err := config.validateFile(&MultipartFile{Filename: p.Filename, ContentType: p.ContentType}); err != nil {
+				return NewHTTPError(http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType, err.Error()).AsProblem()
+			}
+			files++
+
+			if config.MaxFileSize > 0 {
+				p.limit = config.MaxFileSize
+				p.over = fmt.Sprintf("File %q exceeds the maximum size of %d bytes", p.Filename, config.MaxFileSize)
+			}
+		} else if config.MaxMemory > 0 {
+			p.limit = max(config.MaxMemory-fieldBytes, 0)
+			p.over = fmt.Sprintf("Form fields exceed the maximum size of %d bytes", config.MaxMemory)
+		}
+
+		err = fn(p)
+		if !p.IsFile() {
+			fieldBytes += p.Size
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// IsFile reports whether the part is a file upload rather than a field
+//
+// Returns:
+//   - bool: true when the part has a filename
+func (p *MultipartPart) IsFile() bool {
+	return p.Filename != ""
+}
+
+// GetExtension returns the file extension in lowercase
+// Includes the leading dot
+//
+// Returns:
+//   - string: File extension (e.g., ".jpg", ".pdf"), empty for fields
+func (p *MultipartPart) GetExtension() string {
+	return strings.ToLower(filepath.Ext(p.Filename))
+}
+
+// Read reads the content of the part
+// Returns a 413 HTTPError once the part exceeds its limit.
+func (p *MultipartPart) Read(b []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+
+	// Read one byte past the limit to detect oversized parts
+	if p.limit >= 0 && int64(len(b)) > p.limit-p.Size+1 {
+		b = b[:p.limit-p.Size+1]
+	}
+
+	n, err := p.part.Read(b)
+	if p.limit >= 0 && p.Size+int64(n) > p.limit {
+		n = int(p.limit - p.Size)
+		p.Size = p.limit
+		p.err = ErrPayloadTooLarge(p.over)
+		return n, p.err
+	}
+	p.Size += int64(n)
+
+	if err != nil && err != io.EOF {
+		p.err = multipartError(err)
+		return n, p.err
+	}
+	return n, err
+}
+
+// Value reads the whole part into a string
+// Meant for form fields, whose total size is limited by MaxMemory.
+//
+// Returns:
+//   - string: Part content
+//   - error: 413 HTTPError or read error
+func (p *MultipartPart) Value() (string, error) {
+	data, err := io.ReadAll(p)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// CopyTo streams the content of the part into dst
+// Every hash is updated with the content on the way, so checksums are
+// available without reading the data twice.
+//
+// Parameters:
+//   - dst: Destination writer
+//   - hashes: Optional hashes to update (sha256.New(), md5.New(), ...)
+//
+// Returns:
+//   - int64: Bytes copied
+//   - error: 413 HTTPError, read or write error
+//
+// Example:
+//
+//	sum := sha256.New()
+//	n, err := part.CopyTo(bucketWriter, sum)
+//	etag := hex.EncodeToString(sum.Sum(nil))
+func (p *MultipartPart) CopyTo(dst io.Writer, hashes ...hash.Hash) (int64, error) {
+	if len(hashes) > 0 {
+		writers := make([]io.Writer, 0, len(hashes)+1)
+		writers = append(writers, dst)
+		for _, h := range hashes {
+			writers = append(writers, h)
+		}
+		dst = io.MultiWriter(writers...)
+	}
+	return io.Copy(dst, p)
+}
+
+// Save streams the part into a file at path
+// Creates parent directories if they don't exist. The partial file is
+// removed when the part cannot be saved completely.
+//
+// Parameters:
+//   - path: Destination file path
+//   - hashes: Optional hashes to update with the content
+//
+// Returns:
+//   - error: 413 HTTPError, read or write error
+//
+// Example:
+//
+//	err := part.Save("/uploads/backup.tar")
+func (p *MultipartPart) Save(path string, hashes ...hash.Hash) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := p.CopyTo(file, hashes...); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// SaveToDir streams the part into a directory under its original filename
+// The filename is sanitized as in MultipartFile.SaveToDir.
+//
+// Parameters:
+//   - dir: Destination directory
+//   - hashes: Optional hashes to update with the content
+//
+// Returns:
+//   - string: Full path where the file was saved
+//   - error: 413 HTTPError, read or write error
+//
+// Example:
+//
+//	path, err := part.SaveToDir("/uploads/documents")
+//	// Saves to: /uploads/documents/original-filename.pdf
+func (p *MultipartPart) SaveToDir(dir string, hashes ...hash.Hash) (string, error) {
+	filename := sanitizeFilename(p.Filename)
+	if filename == "" {
+		filename = fmt.Sprintf("upload_%d", time.Now().UnixNano())
+	}
+
+	path := filepath.Join(dir, filename)
+	return path, p.Save(path, hashes...)
+}
+
+// multipartError returns HTTP errors of the body (413 past the body limit)
+// as is and reports other parse errors as a malformed request
+func multipartError(err error) error {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+	if errors.Is(err, ErrStreamClosed) {
+		return err
+	}
+	return ErrBadRequest("Malformed multipart body").WithInternal(err)
+}
@@ -0,0 +1,197 @@
+package blaze_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+type person struct {
+	Name string `json:"name"`
+}
+
+// Encodings of person{Name: "ada"}
+var personBodies = map[string]string{
+	"application/json":    `{"name":"ada"}`,
+	"application/msgpack": "\x81\xa4name\xa3ada",
+	"application/cbor":    "\xa1\x64name\x63ada",
+	"application/yaml":    "name: ada\n",
+}
+
+// csvCodec encodes persons as a single CSV line
+type csvCodec struct{}
+
+func (csvCodec) MediaTypes() []string { return []string{"text/csv"} }
+
+func (csvCodec) Marshal(v interface{}) ([]byte, error) {
+	p, ok := v.(person)
+	if !ok {
+		return nil, blaze.ErrCodecUnsupported
+	}
+	return []byte(p.Name + "\n"), nil
+}
+
+func (csvCodec) Unmarshal(data []byte, v interface{}) error {
+	p, ok := v.(*person)
+	if !ok {
+		return errors.New("unsupported target")
+	}
+	p.Name = strings.TrimSpace(string(data))
+	return nil
+}
+
+func negotiationApp() *blaze.App {
+	app := blaze.New()
+	app.RegisterCodec(csvCodec{})
+
+	app.GET("/person", func(c *blaze.Context) error {
+		return c.Negotiate(http.StatusOK, person{Name: "ada"})
+	})
+	app.GET("/map", func(c *blaze.Context) error {
+		return c.Negotiate(http.StatusOK, blaze.Map{"name": "ada"})
+	})
+	app.POST("/person", func(c *blaze.Context) error {
+		var p person
+		if err := c.Bind(&p); err != nil {
+			return err
+		}
+		return c.Text(p.Name)
+	})
+	return app
+}
+
+func TestNegotiate(t *testing.T) {
+	srv := blazetest.New(t, negotiationApp())
+
+	for mediaType, body := range personBodies {
+		srv.Get("/person").
+			WithHeader("Accept", mediaType).
+			Do().
+			AssertStatus(http.StatusOK).
+			AssertHeaderContains("Content-Type", mediaType).
+			AssertHeaderContains("Vary", "Accept").
+			AssertBody(body)
+	}
+
+	tests := []struct {
+		path, accept, contentType string
+	}{
+		{"/person", "", "application/json"},
+		{"/person", "*/*", "application/json"},
+		{"/person", "application/msgpack, application/json;q=0.5", "application/msgpack"},
+		{"/person", "application/json;q=0.5, application/*", "application/xml"},
+		{"/person", "text/csv, application/json;q=0.9", "text/csv"},
+		// The CSV codec cannot encode maps, so the next choice is used
+		{"/map", "text/csv, application/json;q=0.9", "application/json"},
+	}
+	for _, tt := range tests {
+		res := srv.Get(tt.path).WithHeader("Accept", tt.accept).Do().AssertStatus(http.StatusOK)
+		if got := res.Header("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+			t.Errorf("%s with Accept %q: Content-Type %q, want %s", tt.path, tt.accept, got, tt.contentType)
+		}
+	}
+
+	srv.Get("/person").WithHeader("Accept", "image/png").Do().
+		AssertStatus(http.StatusNotAcceptable).
+		AssertHeader("Content-Type", blaze.ProblemContentType).
+		AssertJSONPath("status", float64(http.StatusNotAcceptable)).
+		AssertJSONPath("title", "Not Acceptable").
+		AssertJSONPath("code", "NOT_ACCEPTABLE").
+		AssertJSONPath("accept", "image/png").
+		AssertJSONPath("available.0", "application/json")
+}
+
+func TestBindCodecs(t *testing.T) {
+	srv := blazetest.New(t, negotiationApp())
+
+	for mediaType, body := range personBodies {
+		srv.Post("/person").
+			WithBody(mediaType, []byte(body)).
+			Do().
+			AssertStatus(http.StatusOK).
+			AssertBody("ada")
+	}
+
+	srv.Post("/person").WithBody("text/csv", []byte("ada\n")).Do().AssertBody("ada")
+	srv.Post("/person").WithBody("application/xml", []byte("<person><Name>ada</Name></person>")).Do().AssertBody("ada")
+
+	srv.Post("/person").WithBody("text/plain", []byte("ada")).Do().
+		AssertStatus(http.StatusUnsupportedMediaType).
+		AssertHeader("Content-Type", blaze.ProblemContentType).
+		AssertJSONPath("status", float64(http.StatusUnsupportedMediaType)).
+		AssertJSONPath("detail", "Unsupported content type: text/plain").
+		AssertJSONPath("instance", "/person").
+		AssertJSONPath("content_type", "text/plain")
+
+	// Malformed bodies are rejected, not decoded partially
+	for mediaType, body := range map[string]string{
+		"application/msgpack": "81a46e616d65",
+		"application/cbor":    "a1646e616d65",
+		"application/yaml":    hex.EncodeToString([]byte("name: [ada\n")),
+	} {
+		data, _ := hex.DecodeString(body)
+		res := srv.Post("/person").WithBody(mediaType, data).Do()
+		if res.StatusCode() == http.StatusOK {
+			t.Errorf("%s: truncated body accepted as %q", mediaType, res.BodyString())
+		}
+	}
+}
+
+func TestProblemDetails(t *testing.T) {
+	handlers := func(app *blaze.App) *blaze.App {
+		app.GET("/conflict", func(c *blaze.Context) error {
+			return blaze.ErrConflict("User already exists").
+				WithDetails(blaze.Map{"user": "ada", "status": 200}).
+				AsProblem()
+		})
+		app.GET("/internal", func(c *blaze.Context) error {
+			return blaze.ErrBadRequest("Bad input").
+				WithDetails([]string{"a"}).
+				WithInternal(errors.New("db down")).
+				AsProblem()
+		})
+		return app
+	}
+
+	for name, app := range map[string]*blaze.App{
+		"unhandled":     handlers(blaze.New()),
+		"error handler": handlers(blaze.New().UseErrorHandler(&blaze.ErrorHandlerConfig{})),
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := blazetest.New(t, app)
+
+			// Map details become members, without replacing the standard ones
+			srv.Get("/conflict").Do().
+				AssertStatus(http.StatusConflict).
+				AssertHeader("Content-Type", blaze.ProblemContentType).
+				AssertJSONPath("type", "about:blank").
+				AssertJSONPath("title", "Conflict").
+				AssertJSONPath("status", float64(http.StatusConflict)).
+				AssertJSONPath("detail", "User already exists").
+				AssertJSONPath("instance", "/conflict").
+				AssertJSONPath("code", "CONFLICT").
+				AssertJSONPath("user", "ada")
+
+			srv.Get("/internal").Do().
+				AssertStatus(http.StatusBadRequest).
+				AssertHeader("Content-Type", blaze.ProblemContentType).
+				AssertJSONPath("details.0", "a")
+		})
+	}
+
+	// Details of errors with an internal cause stay hidden in production
+	config := blaze.DefaultErrorHandlerConfig()
+	config.Logger = func(error) {}
+	app := handlers(blaze.New().UseErrorHandler(config))
+	res := blazetest.New(t, app).Get("/internal").Do().
+		AssertStatus(http.StatusBadRequest).
+		AssertJSONPath("detail", "Bad input")
+	if strings.Contains(res.BodyString(), "details") {
+		t.Errorf("internal error details sent: %s", res.BodyString())
+	}
+}
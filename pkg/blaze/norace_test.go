@@ -0,0 +1,7 @@
+//go:build !race
+
+package blaze
+
+// raceEnabled reports whether tests run under the race detector, which
+// changes allocation counts
+const raceEnabled = false
@@ -0,0 +1,554 @@
+package blaze
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ==================== OpenAPI Document ====================
+
+// OpenAPIVersion is the OpenAPI specification version of generated documents
+const OpenAPIVersion = "3.1.0"
+
+// OpenAPIDocument is an OpenAPI 3.1 document
+// Generated from the registered routes by App.OpenAPI. The document is a
+// plain value: fields can be changed before it is serialized, for example
+// to add servers.
+//
+// Example:
+//
+//	doc := app.OpenAPI(blaze.OpenAPIInfo{Title: "Users API", Version: "1.2.0"})
+//	doc.Servers = []blaze.OpenAPIServer{{URL: "https://api.example.com"}}
+//	data, err := doc.YAML()
+type OpenAPIDocument struct {
+	OpenAPI    string                      `json:"openapi"`
+	Info       OpenAPIInfo                 `json:"info"`
+	Servers    []OpenAPIServer             `json:"servers,omitempty"`
+	Paths      map[string]*OpenAPIPathItem `json:"paths"`
+	Components *OpenAPIComponents          `json:"components,omitempty"`
+	Tags       []OpenAPITag                `json:"tags,omitempty"`
+}
+
+// OpenAPIInfo describes the API
+// Title and Version default to "Blaze API" and "1.0.0" when empty.
+type OpenAPIInfo struct {
+	Title          string          `json:"title"`
+	Version        string          `json:"version"`
+	Summary        string          `json:"summary,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	TermsOfService string          `json:"termsOfService,omitempty"`
+	Contact        *OpenAPIContact `json:"contact,omitempty"`
+	License        *OpenAPILicense `json:"license,omitempty"`
+}
+
+// OpenAPIContact is the contact information of the API
+type OpenAPIContact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// OpenAPILicense is the license of the API
+// Identifier is an SPDX expression ("MIT"); set either it or URL.
+type OpenAPILicense struct {
+	Name       string `json:"name"`
+	Identifier string `json:"identifier,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// OpenAPIServer is a server hosting the API
+type OpenAPIServer struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPITag describes a tag used by operations
+type OpenAPITag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIPathItem holds the operations of one path
+type OpenAPIPathItem struct {
+	Get     *OpenAPIOperation `json:"get,omitempty"`
+	Put     *OpenAPIOperation `json:"put,omitempty"`
+	Post    *OpenAPIOperation `json:"post,omitempty"`
+	Delete  *OpenAPIOperation `json:"delete,omitempty"`
+	Options *OpenAPIOperation `json:"options,omitempty"`
+	Head    *OpenAPIOperation `json:"head,omitempty"`
+	Patch   *OpenAPIOperation `json:"patch,omitempty"`
+	Trace   *OpenAPIOperation `json:"trace,omitempty"`
+}
+
+// OpenAPIOperation describes a single route
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter is a path, query, header or cookie parameter
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+}
+
+// OpenAPIRequestBody describes the request body of an operation
+type OpenAPIRequestBody struct {
+	Description string                       `json:"description,omitempty"`
+	Required    bool                         `json:"required,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse describes one response of an operation
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType holds the schema of a body in one media type
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema,omitempty"`
+}
+
+// OpenAPIComponents holds the reusable schemas of the document
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas,omitempty"`
+}
+
+// JSON returns the document as indented JSON
+//
+// Returns:
+//   - []byte: JSON document
+//   - error: Encoding error or nil
+func (d *OpenAPIDocument) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the document as YAML
+//
+// Returns:
+//   - []byte: YAML document
+//   - error: Encoding error or nil
+func (d *OpenAPIDocument) YAML() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(data)
+}
+
+// operation returns the field holding the operation for method
+// Returns nil for methods OpenAPI cannot describe (CONNECT).
+func (p *OpenAPIPathItem) operation(method string) **OpenAPIOperation {
+	switch method {
+	case http.MethodGet:
+		return &p.Get
+	case http.MethodPut:
+		return &p.Put
+	case http.MethodPost:
+		return &p.Post
+	case http.MethodDelete:
+		return &p.Delete
+	case http.MethodOptions:
+		return &p.Options
+	case http.MethodHead:
+		return &p.Head
+	case http.MethodPatch:
+		return &p.Patch
+	case http.MethodTrace:
+		return &p.Trace
+	default:
+		return nil
+	}
+}
+
+// ==================== Route Documentation ====================
+
+// RouteDocs holds the OpenAPI documentation of a route
+// Set with WithSummary, WithDescription, WithRequestBody, WithResponse and
+// WithoutOpenAPI. Routes using Handle are documented from their typed
+// signature when no request body or responses are declared.
+type RouteDocs struct {
+	// Summary is a short description of the operation
+	Summary string
+
+	// Description is a longer, CommonMark description
+	Description string
+
+	// RequestBody is the type of the request body (nil = none declared)
+	RequestBody reflect.Type
+
+	// Responses lists the declared responses in declaration order
+	Responses []RouteResponse
+
+	// Hidden excludes the route from generated documents
+	Hidden bool
+}
+
+// RouteResponse is a documented response of a route
+type RouteResponse struct {
+	// Status is the HTTP status code
+	Status int
+
+	// Body is the response body type (nil = no body)
+	Body reflect.Type
+}
+
+// WithSummary sets the OpenAPI summary of the route
+//
+// Parameters:
+//   - summary: Short description of the operation
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/users/:id", getUser, blaze.WithSummary("Get a user"))
+func WithSummary(summary string) RouteOption {
+	return func(r *Route) {
+		r.Docs.Summary = summary
+	}
+}
+
+// WithDescription sets the OpenAPI description of the route
+// The description may use CommonMark.
+//
+// Parameters:
+//   - description: Long description of the operation
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.DELETE("/users/:id", deleteUser,
+//	    blaze.WithDescription("Deletes the user and **all** their sessions."),
+//	)
+func WithDescription(description string) RouteOption {
+	return func(r *Route) {
+		r.Docs.Description = description
+	}
+}
+
+// WithRequestBody documents the request body type of the route
+// Pass a value of the type (or a reflect.Type). Fields tagged param,
+// query, header or cookie are documented as parameters instead.
+//
+// Parameters:
+//   - body: Value of the request body type
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.POST("/users", createUser, blaze.WithRequestBody(CreateUserRequest{}))
+func WithRequestBody(body interface{}) RouteOption {
+	return func(r *Route) {
+		r.Docs.RequestBody = docsType(body)
+	}
+}
+
+// WithResponse documents a response of the route
+// Can be used once per status code; pass nil for responses without body.
+//
+// Parameters:
+//   - status: HTTP status code
+//   - body: Value of the response body type, or nil
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/users/:id", getUser,
+//	    blaze.WithResponse(200, User{}),
+//	    blaze.WithResponse(404, blaze.ErrorResponse{}),
+//	)
+func WithResponse(status int, body interface{}) RouteOption {
+	return func(r *Route) {
+		r.Docs.Responses = append(r.Docs.Responses, RouteResponse{Status: status, Body: docsType(body)})
+	}
+}
+
+// WithoutOpenAPI excludes the route from generated OpenAPI documents
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/internal/debug", debugHandler, blaze.WithoutOpenAPI())
+func WithoutOpenAPI() RouteOption {
+	return func(r *Route) {
+		r.Docs.Hidden = true
+	}
+}
+
+// docsType returns the type documented by a With* option argument
+func docsType(v interface{}) reflect.Type {
+	if v == nil {
+		return nil
+	}
+	if t, ok := v.(reflect.Type); ok {
+		return t
+	}
+	return reflect.TypeOf(v)
+}
+
+// ==================== Document Generation ====================
+
+// OpenAPI generates an OpenAPI 3.1 document from the registered routes
+// Routes of mounted apps are included under their mount path; routes of
+// virtual hosts and routes registered with WithoutOpenAPI are not.
+//
+// Generated From Routes:
+//   - Paths: ":id" and "*path" become "{id}" and "{path}"; optional
+//     parameters produce one path per variant
+//   - Path parameters: typed from constraints (<int>, <uuid>, <date>, ...)
+//   - Operation: name (operationId), tags, summary and description
+//   - Parameters and body: from the Handle request type or WithRequestBody
+//   - Responses: from WithResponse or the Handle response type
+//   - Schemas: from Go types, json tags and validate tags, shared under
+//     components/schemas
+//
+// Parameters:
+//   - info: API title, version and description
+//
+// Returns:
+//   - *OpenAPIDocument: Generated document
+//
+// Example:
+//
+//	doc := app.OpenAPI(blaze.OpenAPIInfo{
+//	    Title:   "Users API",
+//	    Version: "1.2.0",
+//	})
+//	data, _ := doc.JSON()
+//	os.WriteFile("openapi.json", data, 0644)
+func (a *App) OpenAPI(info OpenAPIInfo) *OpenAPIDocument {
+	if info.Title == "" {
+		info.Title = "Blaze API"
+	}
+	if info.Version == "" {
+		info.Version = "1.0.0"
+	}
+
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    info,
+		Paths:   make(map[string]*OpenAPIPathItem),
+	}
+
+	schemas := newSchemaGenerator()
+	tags := make(map[string]bool)
+
+	a.eachRoute("", func(prefix string, route *Route) {
+		if route.Docs.Hidden || len(route.segments) == 0 {
+			return
+		}
+
+		op := buildOperation(route, schemas)
+		for _, path := range openAPIPaths(prefix, route.segments) {
+			item := doc.Paths[path]
+			if item == nil {
+				item = &OpenAPIPathItem{}
+				doc.Paths[path] = item
+			}
+			if slot := item.operation(route.Method); slot != nil {
+				// Each optional-parameter variant only lists its own path parameters
+				variant := *op
+				variant.Parameters = pathParameters(path, op.Parameters)
+				*slot = &variant
+			}
+		}
+		for _, tag := range route.Tags {
+			tags[tag] = true
+		}
+	})
+
+	if len(schemas.components) > 0 {
+		doc.Components = &OpenAPIComponents{Schemas: schemas.components}
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, OpenAPITag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+
+	return doc
+}
+
+// eachRoute calls fn for every route of the app and its mounted apps
+// prefix is the mount path of the app owning the route.
+func (a *App) eachRoute(prefix string, fn func(prefix string, route *Route)) {
+	for _, route := range a.router.table.Load().routes {
+		fn(prefix, route)
+	}
+	for _, m := range a.mounts {
+		m.app.eachRoute(joinMountPath(prefix, m.prefix), fn)
+	}
+}
+
+// openAPIPaths renders the OpenAPI paths of a parsed pattern, one per
+// optional-parameter variant
+func openAPIPaths(prefix string, segments []routeSegment) []string {
+	required := len(segments)
+	for required > 0 && segments[required-1].optional {
+		required--
+	}
+
+	paths := make([]string, 0, len(segments)-required+1)
+	for n := required; n <= len(segments); n++ {
+		var b strings.Builder
+		for _, seg := range segments[:n] {
+			b.WriteByte('/')
+			for _, part := range seg.parts {
+				if part.param || part.catchAll {
+					b.WriteString("{" + part.text + "}")
+				} else {
+					b.WriteString(part.text)
+				}
+			}
+		}
+		path := b.String()
+		if path == "" {
+			path = "/"
+		}
+		paths = append(paths, joinMountPath(prefix, path))
+	}
+	return paths
+}
+
+// pathParameters drops path parameters not present in path
+func pathParameters(path string, params []*OpenAPIParameter) []*OpenAPIParameter {
+	filtered := make([]*OpenAPIParameter, 0, len(params))
+	for _, param := range params {
+		if param.In == "path" && !strings.Contains(path, "{"+param.Name+"}") {
+			continue
+		}
+		filtered = append(filtered, param)
+	}
+	return filtered
+}
+
+// buildOperation documents a route
+func buildOperation(route *Route, schemas *schemaGenerator) *OpenAPIOperation {
+	op := &OpenAPIOperation{
+		OperationID: route.Name,
+		Summary:     route.Docs.Summary,
+		Description: route.Docs.Description,
+		Tags:        route.Tags,
+		Responses:   make(map[string]*OpenAPIResponse),
+	}
+
+	// The request type comes from WithRequestBody or the typed handler
+	requestType := route.Docs.RequestBody
+	if requestType == nil && route.Signature != nil {
+		requestType = route.Signature.Request
+	}
+
+	declared := make(map[string]bool)
+	if requestType != nil {
+		for _, param := range schemas.parameters(requestType) {
+			declared[param.In+":"+param.Name] = true
+			op.Parameters = append(op.Parameters, param)
+		}
+	}
+
+	// Path parameters not declared by the request type
+	for _, name := range route.Params {
+		if declared["path:"+name] {
+			continue
+		}
+		op.Parameters = append(op.Parameters, &OpenAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   constraintSchema(route.Constraints[name]),
+		})
+	}
+	sortParameters(op.Parameters, route.Params)
+
+	if requestType != nil && (route.Docs.RequestBody != nil || methodHasBody(route.Method)) {
+		op.RequestBody = schemas.requestBody(requestType)
+	}
+
+	switch {
+	case len(route.Docs.Responses) > 0:
+		for _, resp := range route.Docs.Responses {
+			op.Responses[strconv.Itoa(resp.Status)] = schemas.response(resp.Status, resp.Body)
+		}
+	case route.Signature != nil:
+		op.Responses["200"] = schemas.response(http.StatusOK, route.Signature.Response)
+		op.Responses["400"] = schemas.response(http.StatusBadRequest, reflect.TypeOf(ErrorResponse{}))
+	default:
+		op.Responses["200"] = &OpenAPIResponse{Description: http.StatusText(http.StatusOK)}
+	}
+
+	return op
+}
+
+// sortParameters orders path parameters as in the pattern, followed by
+// the other parameters in declaration order
+func sortParameters(params []*OpenAPIParameter, pathOrder []string) {
+	position := func(p *OpenAPIParameter) int {
+		if p.In != "path" {
+			return len(pathOrder)
+		}
+		for i, name := range pathOrder {
+			if name == p.Name {
+				return i
+			}
+		}
+		return len(pathOrder)
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return position(params[i]) < position(params[j])
+	})
+}
+
+// methodHasBody reports whether requests of method usually carry a body
+func methodHasBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// constraintSchema returns the schema of a path parameter
+func constraintSchema(constraint *RouteConstraint) *OpenAPISchema {
+	if constraint == nil {
+		return &OpenAPISchema{Type: "string"}
+	}
+
+	switch constraint.Type {
+	case IntConstraint:
+		return &OpenAPISchema{Type: "integer", Minimum: float64Ptr(0)}
+	case UUIDConstraint:
+		return &OpenAPISchema{Type: "string", Format: "uuid"}
+	case DateConstraint:
+		return &OpenAPISchema{Type: "string", Format: "date"}
+	default:
+		schema := &OpenAPISchema{Type: "string"}
+		if constraint.Pattern != nil {
+			schema.Pattern = constraint.Pattern.String()
+		}
+		return schema
+	}
+}
@@ -0,0 +1,524 @@
+package blaze
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ==================== OpenAPI Schemas ====================
+
+// OpenAPISchema is a JSON Schema (2020-12) as used by OpenAPI 3.1
+// Generated from Go types: named struct types are stored once under
+// components/schemas and referenced with $ref.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []interface{}             `json:"enum,omitempty"`
+	Default              interface{}               `json:"default,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64                  `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64                  `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	MinItems             *int                      `json:"minItems,omitempty"`
+	MaxItems             *int                      `json:"maxItems,omitempty"`
+	UniqueItems          bool                      `json:"uniqueItems,omitempty"`
+}
+
+var (
+	multipartFileType = reflect.TypeOf(MultipartFile{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// parameterLocations maps binding tags to OpenAPI parameter locations
+var parameterLocations = map[string]string{
+	"param":  "path",
+	"query":  "query",
+	"header": "header",
+	"cookie": "cookie",
+}
+
+// schemaGenerator converts Go types to schemas for one document
+type schemaGenerator struct {
+	// components holds the schemas of named struct types by name
+	components map[string]*OpenAPISchema
+
+	// names maps named struct types to their component name
+	names map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: make(map[string]*OpenAPISchema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// schema returns the schema of t
+// Every call returns a new value, so callers may add constraints to it.
+func (g *schemaGenerator) schema(t reflect.Type) *OpenAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case t == durationType:
+		return &OpenAPISchema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}
+	case t == multipartFileType:
+		return &OpenAPISchema{Type: "string", Format: "binary"}
+	case t == rawMessageType:
+		return &OpenAPISchema{}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &OpenAPISchema{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &OpenAPISchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32", Minimum: float64Ptr(0)}
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &OpenAPISchema{Type: "integer", Format: "int64", Minimum: float64Ptr(0)}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// []byte is encoded as base64 by encoding/json
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + g.component(t)}
+	default:
+		// Interfaces and other dynamic values accept anything
+		return &OpenAPISchema{}
+	}
+}
+
+// component registers the named struct type t and returns its name
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	base := componentName(shortTypeName(t.Name()))
+	name := base
+	for i := 2; g.components[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	// Register before building, so recursive types reference themselves
+	g.names[t] = name
+	g.components[name] = &OpenAPISchema{}
+	*g.components[name] = *g.structSchema(t)
+	return name
+}
+
+// shortTypeName drops package paths from the type arguments of generic
+// type names: "Page[example.com/app/models.User]" -> "Page[User]"
+func shortTypeName(name string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i <= len(name); i++ {
+		if i < len(name) && !strings.ContainsRune("[],* ", rune(name[i])) {
+			continue
+		}
+		ident := name[start:i]
+		if dot := strings.LastIndexByte(ident, '.'); dot >= 0 {
+			ident = ident[dot+1:]
+		}
+		b.WriteString(ident)
+		if i < len(name) {
+			b.WriteByte(name[i])
+		}
+		start = i + 1
+	}
+	return b.String()
+}
+
+// componentName replaces characters not allowed in component names, such
+// as the brackets of generic type names
+func componentName(name string) string {
+	name = strings.NewReplacer("[", "_", ",", "_", "]", "", " ", "", "*", "").Replace(name)
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// structSchema builds the object schema of struct type t
+// Fields are named by their json tags. Fields bound from the path, query,
+// headers or cookies without a json tag are not part of the body and are
+// left out.
+func (g *schemaGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	g.addProperties(schema, t)
+	return schema
+}
+
+// addProperties adds the JSON fields of struct type t to schema
+func (g *schemaGenerator) addProperties(schema *OpenAPISchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened
+		if name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			g.addProperties(schema, embedded)
+			continue
+		}
+
+		property := g.schema(field.Type)
+		property.Description = field.Tag.Get("description")
+		if applyValidateTag(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// jsonFieldName returns the JSON property name of a struct field
+// Returns "" for embedded structs that encoding/json flattens and false
+// for fields that are not part of the JSON body.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag, hasTag := field.Tag.Lookup("json")
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" && tag == "-" {
+		return "", false
+	}
+
+	if field.Anonymous && name == "" {
+		embedded := field.Type
+		for embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if embedded.Kind() == reflect.Struct {
+			return "", true
+		}
+	}
+	if !field.IsExported() {
+		return "", false
+	}
+
+	// Fields bound from other parts of the request
+	if !hasTag {
+		for tag := range parameterLocations {
+			if _, ok := field.Tag.Lookup(tag); ok {
+				return "", false
+			}
+		}
+	}
+
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// hasBodyFields reports whether struct type t has JSON body fields
+func hasBodyFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		if name != "" {
+			return true
+		}
+
+		embedded := field.Type
+		for embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if hasBodyFields(embedded) {
+			return true
+		}
+	}
+	return false
+}
+
+// parameters returns the parameters bound from the tagged fields of the
+// struct type t
+func (g *schemaGenerator) parameters(t reflect.Type) []*OpenAPIParameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []*OpenAPIParameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			params = append(params, g.parameters(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		// The binder uses the first source a field is tagged with
+		tagged := false
+		for _, source := range bindSources {
+			raw, ok := field.Tag.Lookup(source.tag)
+			if !ok || raw == "-" {
+				continue
+			}
+			tagged = true
+
+			tag := parseStructTag(raw)
+			param := &OpenAPIParameter{
+				Name:        tag.Name,
+				In:          parameterLocations[source.tag],
+				Description: field.Tag.Get("description"),
+				Schema:      g.parameterSchema(field.Type),
+			}
+			if param.Name == "" {
+				param.Name = strings.ToLower(field.Name)
+			}
+
+			required := applyValidateTag(param.Schema, field.Tag.Get("validate"))
+			param.Required = param.In == "path" || (tag.Default == "" && (tag.Required || required))
+			if tag.Default != "" {
+				param.Schema.Default = schemaValue(param.Schema, tag.Default)
+			}
+
+			params = append(params, param)
+			break
+		}
+
+		// Untagged nested structs group parameters
+		if !tagged && isNestedBindStruct(field.Type) {
+			params = append(params, g.parameters(field.Type)...)
+		}
+	}
+	return params
+}
+
+// parameterSchema returns the schema of a parameter of type t
+// Durations are bound from strings such as "1m30s".
+func (g *schemaGenerator) parameterSchema(t reflect.Type) *OpenAPISchema {
+	elem := t
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem == durationType {
+		return &OpenAPISchema{Type: "string", Format: "duration"}
+	}
+	return g.schema(t)
+}
+
+// requestBody documents t as a JSON request body
+// Returns nil for struct types without body fields.
+func (g *schemaGenerator) requestBody(t reflect.Type) *OpenAPIRequestBody {
+	elem := t
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() == reflect.Struct && !hasBodyFields(elem) {
+		return nil
+	}
+
+	return &OpenAPIRequestBody{
+		Required: true,
+		Content: map[string]*OpenAPIMediaType{
+			"application/json": {Schema: g.schema(t)},
+		},
+	}
+}
+
+// response documents a response with status and body type t (nil = no body)
+func (g *schemaGenerator) response(status int, t reflect.Type) *OpenAPIResponse {
+	resp := &OpenAPIResponse{Description: http.StatusText(status)}
+	if resp.Description == "" {
+		resp.Description = "Response"
+	}
+	if t != nil {
+		resp.Content = map[string]*OpenAPIMediaType{
+			"application/json": {Schema: g.schema(t)},
+		}
+	}
+	return resp
+}
+
+// applyValidateTag adds the constraints of a validate tag to schema
+// Reports whether the tag marks the value as required. Rules after "dive"
+// apply to the items of arrays and maps.
+//
+// Supported Rules:
+//   - required
+//   - min, max, len, gt, gte, lt, lte: value range for numbers, length
+//     for strings, item count for arrays
+//   - oneof: enum
+//   - email, url, uri, uuid, ipv4, ipv6, hostname, datetime: format
+//   - alpha, alphanum, numeric: pattern
+//   - unique: uniqueItems
+func applyValidateTag(schema *OpenAPISchema, tag string) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, value, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "dive":
+			target := schema.Items
+			if target == nil {
+				target = schema.AdditionalProperties
+			}
+			if target != nil {
+				applyValidateTag(target, strings.Join(rules[i+1:], ","))
+			}
+			return required
+		case "required":
+			required = true
+		case "min", "gte":
+			applyBound(schema, value, true, false)
+		case "max", "lte":
+			applyBound(schema, value, false, false)
+		case "gt":
+			applyBound(schema, value, true, true)
+		case "lt":
+			applyBound(schema, value, false, true)
+		case "len":
+			applyBound(schema, value, true, false)
+			applyBound(schema, value, false, false)
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, schemaValue(schema, option))
+			}
+		case "email":
+			schema.Format = "email"
+		case "url", "uri", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid3", "uuid4", "uuid5":
+			schema.Format = "uuid"
+		case "ipv4", "ipv6", "hostname":
+			schema.Format = name
+		case "datetime":
+			schema.Format = "date-time"
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]+$"
+		case "alphanum":
+			schema.Pattern = "^[a-zA-Z0-9]+$"
+		case "numeric":
+			schema.Pattern = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
+		case "unique":
+			schema.UniqueItems = true
+		}
+	}
+
+	return required
+}
+
+// applyBound sets a lower or upper bound on schema
+// Numbers get a value bound, strings a length bound and arrays an item
+// count bound.
+func applyBound(schema *OpenAPISchema, value string, lower, exclusive bool) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "integer", "number":
+		switch {
+		case lower && exclusive:
+			schema.ExclusiveMinimum = float64Ptr(n)
+		case lower:
+			schema.Minimum = float64Ptr(n)
+		case exclusive:
+			schema.ExclusiveMaximum = float64Ptr(n)
+		default:
+			schema.Maximum = float64Ptr(n)
+		}
+	case "string", "array":
+		length := int(n)
+		if exclusive && lower {
+			length++
+		} else if exclusive {
+			length--
+		}
+		switch {
+		case schema.Type == "string" && lower:
+			schema.MinLength = &length
+		case schema.Type == "string":
+			schema.MaxLength = &length
+		case lower:
+			schema.MinItems = &length
+		default:
+			schema.MaxItems = &length
+		}
+	}
+}
+
+// schemaValue converts a tag value to the JSON type of schema
+func schemaValue(schema *OpenAPISchema, value string) interface{} {
+	switch schema.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,545 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ==================== OpenAPI Specification ====================
+
+// OpenAPISpec is a loaded OpenAPI 3 document prepared for validation
+// Load it once at startup with LoadOpenAPISpec, LoadOpenAPISpecFS or
+// ParseOpenAPISpec and pass it to OpenAPIValidation. Path templates,
+// parameters, bodies and schemas are compiled when the document is
+// loaded, so requests are validated without reparsing.
+//
+// Supported:
+//   - OpenAPI 3.0 and 3.1 documents, as JSON or YAML
+//   - Local references ("#/components/schemas/User"), including recursive schemas
+//   - Server URL paths ("/v1") as base paths of the operations
+type OpenAPISpec struct {
+	// Version is the "openapi" version of the document (e.g. "3.1.0")
+	Version string
+
+	// Title is the title of the API
+	Title string
+
+	// basePaths are the server URL paths prefixing all operations
+	basePaths []string
+
+	// paths are the compiled path items, most specific first
+	paths []*specPath
+}
+
+// specPath is a compiled path item
+type specPath struct {
+	template   string
+	pattern    *regexp.Regexp
+	names      []string
+	params     int
+	literals   int
+	operations map[string]*specOperation
+}
+
+// specOperation is a compiled operation
+type specOperation struct {
+	id         string
+	parameters []*specParameter
+	body       *specBody
+	responses  map[string]*specResponse
+}
+
+// specParameter is a compiled parameter
+type specParameter struct {
+	name     string
+	in       string
+	required bool
+	style    string
+	explode  bool
+	schema   *specSchema
+}
+
+// specBody is a compiled request body
+type specBody struct {
+	required bool
+	content  []specMedia
+}
+
+// specResponse is a compiled response
+type specResponse struct {
+	content []specMedia
+}
+
+// specMedia is the schema of a body in one media type
+type specMedia struct {
+	mediaType string
+	schema    *specSchema
+}
+
+// specMethods are the operation keys of a path item
+var specMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// LoadOpenAPISpec loads an OpenAPI 3 document from a file
+// The file may be JSON or YAML.
+//
+// Parameters:
+//   - path: Path of the document file
+//
+// Returns:
+//   - *OpenAPISpec: Compiled specification
+//   - error: Read, parse or compile error
+//
+// Example:
+//
+//	spec, err := blaze.LoadOpenAPISpec("api/openapi.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	app.Use(blaze.OpenAPIValidation(spec))
+func LoadOpenAPISpec(path string) (*OpenAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	return ParseOpenAPISpec(data)
+}
+
+// LoadOpenAPISpecFS loads an OpenAPI 3 document from a file system
+// Use it with embed.FS to ship the document inside the binary.
+//
+// Parameters:
+//   - fsys: File system containing the document
+//   - path: Path of the document within fsys
+//
+// Returns:
+//   - *OpenAPISpec: Compiled specification
+//   - error: Read, parse or compile error
+//
+// Example:
+//
+//	//go:embed openapi.yaml
+//	var specFS embed.FS
+//
+//	spec, err := blaze.LoadOpenAPISpecFS(specFS, "openapi.yaml")
+func LoadOpenAPISpecFS(fsys fs.FS, path string) (*OpenAPISpec, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	return ParseOpenAPISpec(data)
+}
+
+// ParseOpenAPISpec parses an OpenAPI 3 document
+// Documents starting with "{" are parsed as JSON, others as YAML. Only
+// references within the document are supported; external references
+// return an error.
+//
+// Parameters:
+//   - data: JSON or YAML document
+//
+// Returns:
+//   - *OpenAPISpec: Compiled specification
+//   - error: Parse or compile error
+//
+// Example:
+//
+//	spec, err := blaze.ParseOpenAPISpec(specBytes)
+func ParseOpenAPISpec(data []byte) (*OpenAPISpec, error) {
+	var root interface{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+		if err := decoder.Decode(&root); err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+		}
+	} else {
+		var err error
+		if root, err = parseYAML(data); err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+		}
+	}
+
+	doc, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid OpenAPI document: expected an object")
+	}
+
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q: expected 3.x", version)
+	}
+
+	spec := &OpenAPISpec{Version: version}
+	if info, ok := doc["info"].(map[string]interface{}); ok {
+		spec.Title, _ = info["title"].(string)
+	}
+
+	compiler := &specCompiler{root: doc, refs: make(map[string]*specSchema), openAPI30: strings.HasPrefix(version, "3.0")}
+
+	spec.basePaths = serverBasePaths(doc["servers"])
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for template, node := range paths {
+		path, err := compiler.path(template, node)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", template, err)
+		}
+		spec.paths = append(spec.paths, path)
+	}
+
+	// Concrete paths match before templated ones
+	sort.Slice(spec.paths, func(i, j int) bool {
+		a, b := spec.paths[i], spec.paths[j]
+		if a.params != b.params {
+			return a.params < b.params
+		}
+		if a.literals != b.literals {
+			return a.literals > b.literals
+		}
+		return a.template < b.template
+	})
+
+	return spec, nil
+}
+
+// serverBasePaths returns the URL paths of the servers, longest first
+func serverBasePaths(node interface{}) []string {
+	servers, _ := node.([]interface{})
+	seen := make(map[string]bool)
+	var bases []string
+
+	for _, s := range servers {
+		server, _ := s.(map[string]interface{})
+		raw, _ := server["url"].(string)
+		if raw == "" {
+			continue
+		}
+
+		// Server variables take their default value
+		if variables, ok := server["variables"].(map[string]interface{}); ok {
+			for name, v := range variables {
+				variable, _ := v.(map[string]interface{})
+				if def, ok := variable["default"].(string); ok {
+					raw = strings.ReplaceAll(raw, "{"+name+"}", def)
+				}
+			}
+		}
+
+		path := raw
+		if u, err := url.Parse(raw); err == nil && (u.Scheme != "" || u.Host != "") {
+			path = u.Path
+		}
+		path = strings.TrimSuffix(path, "/")
+		if !seen[path] {
+			seen[path] = true
+			bases = append(bases, path)
+		}
+	}
+
+	if !seen[""] && len(bases) == 0 {
+		bases = append(bases, "")
+	}
+	sort.Slice(bases, func(i, j int) bool { return len(bases[i]) > len(bases[j]) })
+	return bases
+}
+
+// match finds the operation for a request
+// Returns the path item (nil when no path matches), the operation (nil
+// when the method is not documented) and the path parameter values.
+func (s *OpenAPISpec) match(method, requestPath string) (*specPath, *specOperation, map[string]string) {
+	for _, base := range s.basePaths {
+		rest := requestPath
+		if base != "" {
+			if !strings.HasPrefix(requestPath, base) {
+				continue
+			}
+			rest = requestPath[len(base):]
+			if rest == "" {
+				rest = "/"
+			} else if rest[0] != '/' {
+				continue
+			}
+		}
+
+		for _, path := range s.paths {
+			groups := path.pattern.FindStringSubmatch(rest)
+			if groups == nil {
+				continue
+			}
+
+			values := make(map[string]string, len(path.names))
+			for i, name := range path.names {
+				values[name] = groups[i+1]
+			}
+			return path, path.operations[strings.ToLower(method)], values
+		}
+	}
+	return nil, nil, nil
+}
+
+// ==================== Specification Compiler ====================
+
+// specCompiler compiles the parts of a document used for validation
+type specCompiler struct {
+	root      map[string]interface{}
+	refs      map[string]*specSchema
+	openAPI30 bool
+}
+
+// templateParam matches the parameters of a path template
+var templateParam = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// path compiles a path item
+func (sc *specCompiler) path(template string, node interface{}) (*specPath, error) {
+	item, err := sc.deref(node)
+	if err != nil {
+		return nil, err
+	}
+
+	path := &specPath{template: template, operations: make(map[string]*specOperation)}
+
+	// Path template to regular expression
+	var pattern strings.Builder
+	pattern.WriteByte('^')
+	last := 0
+	for _, loc := range templateParam.FindAllStringSubmatchIndex(template, -1) {
+		literal := template[last:loc[0]]
+		pattern.WriteString(regexp.QuoteMeta(literal))
+		pattern.WriteString("([^/]+)")
+		path.literals += len(literal)
+		path.names = append(path.names, template[loc[2]:loc[3]])
+		path.params++
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("/?$")
+	path.literals += len(template) - last
+	if path.pattern, err = regexp.Compile(pattern.String()); err != nil {
+		return nil, err
+	}
+
+	shared, err := sc.parameters(item["parameters"], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, method := range specMethods {
+		opNode, ok := item[method].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		op, err := sc.operation(opNode, shared)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", strings.ToUpper(method), err)
+		}
+		path.operations[method] = op
+	}
+
+	return path, nil
+}
+
+// operation compiles an operation
+// Operation parameters override path item parameters with the same name
+// and location.
+func (sc *specCompiler) operation(node map[string]interface{}, shared []*specParameter) (*specOperation, error) {
+	op := &specOperation{responses: make(map[string]*specResponse)}
+	op.id, _ = node["operationId"].(string)
+
+	var err error
+	if op.parameters, err = sc.parameters(node["parameters"], shared); err != nil {
+		return nil, err
+	}
+
+	if bodyNode, ok := node["requestBody"]; ok {
+		body, err := sc.deref(bodyNode)
+		if err != nil {
+			return nil, err
+		}
+		op.body = &specBody{required: body["required"] == true}
+		if op.body.content, err = sc.content(body["content"]); err != nil {
+			return nil, fmt.Errorf("requestBody: %w", err)
+		}
+	}
+
+	responses, _ := node["responses"].(map[string]interface{})
+	for status, respNode := range responses {
+		resp, err := sc.deref(respNode)
+		if err != nil {
+			return nil, err
+		}
+		content, err := sc.content(resp["content"])
+		if err != nil {
+			return nil, fmt.Errorf("response %s: %w", status, err)
+		}
+		op.responses[strings.ToUpper(status)] = &specResponse{content: content}
+	}
+
+	return op, nil
+}
+
+// parameters compiles a parameter list on top of inherited parameters
+func (sc *specCompiler) parameters(node interface{}, inherited []*specParameter) ([]*specParameter, error) {
+	list, _ := node.([]interface{})
+	params := make([]*specParameter, 0, len(inherited)+len(list))
+	params = append(params, inherited...)
+
+	for _, paramNode := range list {
+		p, err := sc.deref(paramNode)
+		if err != nil {
+			return nil, err
+		}
+
+		param := &specParameter{required: p["required"] == true}
+		param.name, _ = p["name"].(string)
+		param.in, _ = p["in"].(string)
+		if param.name == "" || param.in == "" {
+			return nil, fmt.Errorf("parameter without name or location")
+		}
+
+		// Header names are case-insensitive; these headers are described
+		// elsewhere in the document and are ignored by the specification
+		if param.in == "header" {
+			switch strings.ToLower(param.name) {
+			case "accept", "content-type", "authorization":
+				continue
+			}
+		}
+
+		param.style, _ = p["style"].(string)
+		if param.style == "" {
+			param.style = map[string]string{"query": "form", "cookie": "form"}[param.in]
+			if param.style == "" {
+				param.style = "simple"
+			}
+		}
+		if explode, ok := p["explode"].(bool); ok {
+			param.explode = explode
+		} else {
+			param.explode = param.style == "form"
+		}
+
+		schemaNode, ok := p["schema"]
+		if !ok {
+			// Parameters described with content use the first media type
+			if content, err := sc.content(p["content"]); err == nil && len(content) > 0 {
+				param.schema = content[0].schema
+			}
+		} else {
+			var err error
+			if param.schema, err = sc.schema(schemaNode); err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", param.name, err)
+			}
+		}
+
+		// Replace an inherited parameter with the same name and location
+		replaced := false
+		for i, existing := range params[:len(inherited)] {
+			if existing.in == param.in && strings.EqualFold(existing.name, param.name) {
+				params[i] = param
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, param)
+		}
+	}
+
+	return params, nil
+}
+
+// content compiles a media type map, most specific media types first
+func (sc *specCompiler) content(node interface{}) ([]specMedia, error) {
+	mediaTypes, _ := node.(map[string]interface{})
+	content := make([]specMedia, 0, len(mediaTypes))
+
+	for mediaType, mediaNode := range mediaTypes {
+		media, _ := mediaNode.(map[string]interface{})
+		entry := specMedia{mediaType: strings.ToLower(mediaType)}
+		if schemaNode, ok := media["schema"]; ok {
+			var err error
+			if entry.schema, err = sc.schema(schemaNode); err != nil {
+				return nil, fmt.Errorf("%s: %w", mediaType, err)
+			}
+		}
+		content = append(content, entry)
+	}
+
+	sort.Slice(content, func(i, j int) bool {
+		a, b := mediaSpecificity(content[i].mediaType), mediaSpecificity(content[j].mediaType)
+		if a != b {
+			return a > b
+		}
+		return content[i].mediaType < content[j].mediaType
+	})
+	return content, nil
+}
+
+// mediaSpecificity ranks exact media types over type/* over */*
+func mediaSpecificity(mediaType string) int {
+	switch {
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// deref follows $ref chains to an object
+func (sc *specCompiler) deref(node interface{}) (map[string]interface{}, error) {
+	for depth := 0; depth < 32; depth++ {
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object")
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return obj, nil
+		}
+		var err error
+		if node, err = sc.resolve(ref); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("too many nested references")
+}
+
+// resolve returns the node a local reference points to
+func (sc *specCompiler) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("external reference %q is not supported", ref)
+	}
+
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %q", ref)
+	}
+
+	var node interface{} = sc.root
+	if pointer == "" {
+		return node, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid reference %q", ref)
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolved reference %q", ref)
+		}
+		if node, ok = obj[token]; !ok {
+			return nil, fmt.Errorf("unresolved reference %q", ref)
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,221 @@
+package blaze_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// assertGolden compares got with the golden file testdata/name
+// Run the tests with -update to write the golden file instead.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run the test with -update to create it)", err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	gotLines, wantLines := strings.Split(string(got), "\n"), strings.Split(string(want), "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Errorf("%s: line %d = %q, want %q (run the test with -update to accept)", path, i+1, g, w)
+			return
+		}
+	}
+}
+
+type Address struct {
+	Street  string `json:"street" validate:"required"`
+	City    string `json:"city" validate:"required,min=2,max=64"`
+	Country string `json:"country" validate:"len=2,alpha" description:"ISO 3166-1 alpha-2 code"`
+}
+
+type Audit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type Account struct {
+	Audit
+
+	ID       string            `json:"id" validate:"required,uuid"`
+	Email    string            `json:"email" validate:"required,email"`
+	Age      int               `json:"age,omitempty" validate:"gte=18,lt=130"`
+	Score    float64           `json:"score" validate:"gt=0,lte=1"`
+	Role     string            `json:"role" validate:"oneof=admin member guest"`
+	Level    int               `json:"level" validate:"oneof=1 2 3"`
+	Website  string            `json:"website,omitempty" validate:"omitempty,url"`
+	Handle   string            `json:"handle" validate:"alphanum"`
+	Tags     []string          `json:"tags" validate:"max=5,unique,dive,min=1"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Address  *Address          `json:"address,omitempty"`
+	Avatar   []byte            `json:"avatar,omitempty"`
+	Settings json.RawMessage   `json:"settings,omitempty"`
+	Secret   string            `json:"-"`
+}
+
+type createAccountRequest struct {
+	Org     string `param:"org"`
+	DryRun  bool   `query:"dry_run,default=false"`
+	Tenant  string `header:"X-Tenant-ID,required" description:"Tenant identifier"`
+	Session string `cookie:"session"`
+
+	Email   string  `json:"email" validate:"required,email"`
+	Address Address `json:"address" validate:"required"`
+}
+
+type listAccountsRequest struct {
+	Page    int           `query:"page,default=1" validate:"min=1"`
+	Limit   int           `query:"limit" validate:"required,max=100"`
+	Role    []string      `query:"role" validate:"dive,oneof=admin member guest"`
+	Timeout time.Duration `query:"timeout"`
+}
+
+// openAPIApp documents every kind of route the generator handles
+func openAPIApp() *blaze.App {
+	app := blaze.New()
+	api := app.Group("/api")
+
+	// Typed handlers are documented from their signature
+	blaze.POST(api, "/orgs/:org/accounts", func(c *blaze.Context, req createAccountRequest) (Account, error) {
+		return Account{}, nil
+	}, blaze.WithName("createAccount"), blaze.WithTags("accounts"), blaze.WithSummary("Create an account"))
+	blaze.GET(api, "/accounts", func(c *blaze.Context, req listAccountsRequest) ([]Account, error) {
+		return nil, nil
+	}, blaze.WithName("listAccounts"), blaze.WithTags("accounts"))
+
+	// Untyped handlers declare their body and responses
+	api.GET("/accounts/:id<uuid>", text("account"),
+		blaze.WithName("getAccount"),
+		blaze.WithTags("accounts"),
+		blaze.WithResponse(http.StatusOK, Account{}),
+		blaze.WithResponse(http.StatusNotFound, blaze.ErrorResponse{}),
+	)
+	api.PUT("/accounts/:id<uuid>", text("account"),
+		blaze.WithRequestBody(Account{}),
+		blaze.WithResponse(http.StatusNoContent, nil),
+		blaze.WithDescription("Replaces the account.\n\nFields left out are **cleared**."),
+	)
+
+	// Path parameters and their constraints
+	api.GET("/items/:id<int>", text("item"))
+	api.GET("/days/:day<date>", text("day"))
+	api.GET("/posts/{slug:[a-z-]+}", text("post"))
+	api.GET("/users/:name", text("user"), blaze.WithAlphaConstraint("name"))
+	api.GET("/archive/:year?/:month?", text("archive"), blaze.WithIntConstraint("year"))
+	api.GET("/files/*path", text("file"))
+	api.GET("/export/:name.:ext", text("export"))
+
+	api.GET("/internal", text("internal"), blaze.WithoutOpenAPI())
+
+	admin := blaze.New()
+	admin.DELETE("/cache", text("cleared"), blaze.WithTags("admin"))
+	app.Mount("/admin", admin)
+
+	app.ServeOpenAPI("/docs", blaze.OpenAPIInfo{
+		Title:       "Accounts API",
+		Version:     "2.0.0",
+		Description: "Manage accounts",
+	})
+	return app
+}
+
+func TestOpenAPIGolden(t *testing.T) {
+	app := openAPIApp()
+	srv := blazetest.New(t, app)
+
+	jsonDoc := srv.Get("/docs/openapi.json").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/json")
+	assertGolden(t, "openapi.golden.json", []byte(jsonDoc.BodyString()))
+
+	yamlDoc := srv.Get("/docs/openapi.yaml").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/yaml")
+	assertGolden(t, "openapi.golden.yaml", []byte(yamlDoc.BodyString()))
+
+	// The served document is the generated one
+	data, err := app.OpenAPI(blaze.OpenAPIInfo{Title: "Accounts API", Version: "2.0.0", Description: "Manage accounts"}).JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != jsonDoc.BodyString() {
+		t.Error("served JSON document differs from App.OpenAPI")
+	}
+
+	// The YAML document loads back as the JSON one
+	fromYAML, err := blaze.ParseOpenAPISpec([]byte(yamlDoc.BodyString()))
+	if err != nil {
+		t.Fatalf("ParseOpenAPISpec(YAML): %v", err)
+	}
+	fromJSON, err := blaze.ParseOpenAPISpec([]byte(jsonDoc.BodyString()))
+	if err != nil {
+		t.Fatalf("ParseOpenAPISpec(JSON): %v", err)
+	}
+	if fromYAML.Title != fromJSON.Title || fromYAML.Version != fromJSON.Version {
+		t.Errorf("YAML document loads as %q %q", fromYAML.Title, fromYAML.Version)
+	}
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	srv := blazetest.New(t, openAPIApp())
+
+	srv.Get("/docs").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "text/html").
+		AssertBodyContains(`"/docs/openapi.json"`)
+
+	// Routes registered after ServeOpenAPI are documented, the
+	// documentation routes are not
+	app := openAPIApp()
+	app.GET("/late", text("late"))
+	res := blazetest.New(t, app).Get("/docs/openapi.json").Do().
+		AssertJSONPathExists("paths./late.get")
+	if strings.Contains(res.BodyString(), `"/docs`) {
+		t.Error("documentation routes are listed in the document")
+	}
+
+	// A mounted app lists its mount path as server
+	sub := blaze.New()
+	sub.GET("/ping", text("pong"))
+	sub.ServeOpenAPI("/docs")
+	parent := blaze.New()
+	parent.Mount("/v2", sub)
+
+	blazetest.New(t, parent).Get("/v2/docs/openapi.json").Do().
+		AssertStatus(http.StatusOK).
+		AssertJSONPath("servers.0.url", "/v2").
+		AssertJSONPath("info.title", "Blaze API").
+		AssertJSONPathExists("paths./ping.get")
+	blazetest.New(t, parent).Get("/v2/docs").Do().
+		AssertBodyContains(`"/v2/docs/openapi.json"`)
+}
@@ -0,0 +1,96 @@
+package blaze
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+)
+
+// ==================== OpenAPI Documentation UI ====================
+
+// openAPIPage is the bundled API reference page
+// It renders the document fetched from {{SPEC_URL}} without loading any
+// external scripts, styles or fonts, so it works offline and under
+// restrictive Content-Security-Policy settings that allow inline code.
+//
+//go:embed assets/openapi.html
+var openAPIPage string
+
+// ServeOpenAPI serves the OpenAPI document and an API reference UI
+// The document is regenerated on every request, so routes registered
+// after ServeOpenAPI are included. The documentation routes themselves
+// are left out of the document.
+//
+// Routes:
+//   - GET {path}: Interactive API reference (bundled, no CDN)
+//   - GET {path}/openapi.json: OpenAPI document as JSON
+//   - GET {path}/openapi.yaml: OpenAPI document as YAML
+//
+// On a mounted app, the document lists the mount path as its server so
+// the paths stay relative to the sub-app.
+//
+// Parameters:
+//   - path: Base path of the documentation (e.g. "/docs")
+//   - info: Optional API title, version and description
+//
+// Returns:
+//   - *App: App instance for method chaining
+//
+// Example:
+//
+//	app.ServeOpenAPI("/docs", blaze.OpenAPIInfo{
+//	    Title:       "Users API",
+//	    Version:     "1.2.0",
+//	    Description: "Manage users and organizations",
+//	})
+func (a *App) ServeOpenAPI(path string, info ...OpenAPIInfo) *App {
+	var apiInfo OpenAPIInfo
+	if len(info) > 0 {
+		apiInfo = info[0]
+	}
+
+	base := strings.TrimSuffix(path, "/")
+	specPath := base + "/openapi.json"
+
+	document := func() *OpenAPIDocument {
+		doc := a.OpenAPI(apiInfo)
+		if prefix := a.mountPath(); prefix != "" && len(doc.Servers) == 0 {
+			doc.Servers = []OpenAPIServer{{URL: prefix}}
+		}
+		return doc
+	}
+
+	if path == "" {
+		path = "/"
+	}
+	a.GET(path, func(c *Context) error {
+		specURL, err := json.Marshal(a.mountPath() + specPath)
+		if err != nil {
+			return err
+		}
+		// json.Marshal escapes "<", so the URL cannot close the script element
+		return c.HTML(strings.Replace(openAPIPage, "{{SPEC_URL}}", string(specURL), 1))
+	}, WithoutOpenAPI())
+
+	a.GET(specPath, func(c *Context) error {
+		data, err := document().JSON()
+		if err != nil {
+			return err
+		}
+		c.SetContentType("application/json; charset=utf-8")
+		c.SetBody(data)
+		return nil
+	}, WithoutOpenAPI())
+
+	a.GET(base+"/openapi.yaml", func(c *Context) error {
+		data, err := document().YAML()
+		if err != nil {
+			return err
+		}
+		c.SetContentType("application/yaml; charset=utf-8")
+		c.SetBody(data)
+		return nil
+	}, WithoutOpenAPI())
+
+	return a
+}
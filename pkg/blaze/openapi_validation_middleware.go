@@ -0,0 +1,460 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ==================== OpenAPI Request Validation ====================
+
+// OpenAPIValidationConfig configures the OpenAPI validation middleware
+type OpenAPIValidationConfig struct {
+	// RejectUnknown returns 404 for paths and 405 for methods the
+	// specification does not describe. When false, such requests are
+	// passed to the handler unchecked.
+	RejectUnknown bool
+
+	// ValidateResponses checks JSON response bodies of handlers against
+	// the documented responses. Intended for development and tests: it
+	// decodes every response body.
+	ValidateResponses bool
+
+	// OnInvalidResponse handles response validation failures.
+	// When nil, the response is replaced with a 500 error listing the
+	// violations.
+	OnInvalidResponse func(c *Context, errs ValidationErrors) error
+
+	// SkipPaths is a list of URL paths to exclude from validation.
+	//
+	// Example:
+	//   SkipPaths: []string{"/health", "/metrics"}
+	SkipPaths []string
+}
+
+// DefaultOpenAPIValidationConfig returns the default validation configuration
+//
+// Default Values:
+//   - RejectUnknown: false - Undocumented routes are not validated
+//   - ValidateResponses: false - Only requests are validated
+//   - OnInvalidResponse: nil - Invalid responses become 500 errors
+//   - SkipPaths: Empty - All paths validated
+//
+// Returns:
+//   - OpenAPIValidationConfig: Default configuration
+func DefaultOpenAPIValidationConfig() OpenAPIValidationConfig {
+	return OpenAPIValidationConfig{
+		SkipPaths: []string{},
+	}
+}
+
+// OpenAPIValidation validates requests against an OpenAPI specification
+// Each request is matched to an operation of the specification, and its
+// path, query, header and cookie parameters and its body are validated
+// against the documented schemas before the handler runs.
+//
+// Validation:
+//   - Parameters: required, type (strings are converted by the schema
+//     type), enum, bounds, lengths, patterns and formats
+//   - Arrays: comma-separated or repeated values per the parameter style
+//   - Objects in queries: deepObject (filter[name]=x) and exploded form
+//   - Bodies: required, Content-Type against the documented media types,
+//     JSON bodies against their schema, URL-encoded forms by property
+//   - Schemas: allOf/anyOf/oneOf/not, $ref (recursive), nullable,
+//     readOnly properties ignored in requests
+//
+// Errors:
+//   - Invalid parameters or body: 422 with ValidationError details
+//     ("query.page", "body.items[0].name", ...)
+//   - Malformed JSON body: 400
+//   - Undocumented Content-Type: 415
+//   - Unknown path or method: 404 / 405 when RejectUnknown is set
+//
+// Parameters:
+//   - spec: Loaded specification
+//   - config: Optional configuration (DefaultOpenAPIValidationConfig when omitted)
+//
+// Returns:
+//   - MiddlewareFunc: Validation middleware
+//
+// Example:
+//
+//	//go:embed openapi.yaml
+//	var specFS embed.FS
+//
+//	spec, err := blaze.LoadOpenAPISpecFS(specFS, "openapi.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	config := blaze.DefaultOpenAPIValidationConfig()
+//	config.ValidateResponses = appConfig.Development
+//	app.Use(blaze.OpenAPIValidation(spec, config))
+func OpenAPIValidation(spec *OpenAPISpec, config ...OpenAPIValidationConfig) MiddlewareFunc {
+	cfg := DefaultOpenAPIValidationConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			path := c.Path()
+			for _, skipPath := range cfg.SkipPaths {
+				if path == skipPath {
+					return next(c)
+				}
+			}
+
+			item, op, pathValues := spec.match(c.Method(), path)
+			if op == nil {
+				switch {
+				case !cfg.RejectUnknown:
+					return next(c)
+				case item == nil:
+					return ErrNotFound("Route not found in API specification")
+				default:
+					return ErrMethodNotAllowed("Method not allowed by API specification")
+				}
+			}
+
+			if err := validateOpenAPIRequest(c, op, pathValues); err != nil {
+				return err
+			}
+
+			err := next(c)
+			if err != nil || !cfg.ValidateResponses {
+				return err
+			}
+
+			errs := validateOpenAPIResponse(c, op)
+			if len(errs) == 0 {
+				return nil
+			}
+			if cfg.OnInvalidResponse != nil {
+				return cfg.OnInvalidResponse(c, ValidationErrors{Errors: errs})
+			}
+			c.RequestCtx.Response.ResetBody()
+			return NewHTTPError(http.StatusInternalServerError, ErrCodeInternalServer, "Response validation failed").WithDetails(errs)
+		}
+	}
+}
+
+// validateOpenAPIRequest validates the parameters and body of a request
+func validateOpenAPIRequest(c *Context, op *specOperation, pathValues map[string]string) error {
+	v := &schemaValidation{}
+
+	for _, param := range op.parameters {
+		field := param.in + "." + param.name
+		value, present := param.value(c, pathValues)
+		if !present {
+			if param.required {
+				v.fail(field, "required", nil, "is required")
+			}
+			continue
+		}
+		if param.schema != nil {
+			param.schema.validate(v, field, value)
+		}
+	}
+
+	// Bodies streamed to the handler are not read here
+	if op.body != nil && !c.IsBodyStreamed() {
+		body := c.RequestCtx.Request.Body()
+		if len(body) == 0 {
+			if op.body.required {
+				v.fail("body", "required", nil, "is required")
+			}
+		} else {
+			contentType := mediaTypeOf(c.RequestCtx.Request.Header.ContentType())
+			media := matchMedia(op.body.content, contentType)
+			if media == nil {
+				return NewHTTPError(http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType,
+					fmt.Sprintf("Unsupported content type %q", contentType)).AsProblem()
+			}
+
+			if media.schema != nil {
+				switch {
+				case isJSONMediaType(contentType):
+					value, err := decodeJSONValue(body)
+					if err != nil {
+						return ErrBadRequest("Invalid request body: " + err.Error())
+					}
+					media.schema.validate(v, "body", value)
+				case contentType == "application/x-www-form-urlencoded":
+					media.schema.validate(v, "body", formValue(c, media.schema))
+				}
+			}
+		}
+	}
+
+	if len(v.errors) > 0 {
+		return ErrValidation("Request validation failed", v.errors)
+	}
+	return nil
+}
+
+// validateOpenAPIResponse validates the response written by the handler
+// Streamed and encoded (compressed) bodies are not checked.
+func validateOpenAPIResponse(c *Context, op *specOperation) []ValidationError {
+	resp := &c.RequestCtx.Response
+	status := resp.StatusCode()
+
+	documented := op.responses[strconv.Itoa(status)]
+	if documented == nil {
+		documented = op.responses[strconv.Itoa(status/100)+"XX"]
+	}
+	if documented == nil {
+		documented = op.responses["DEFAULT"]
+	}
+
+	v := &schemaValidation{response: true}
+	if documented == nil {
+		v.fail("response", "status", status, "status %d is not documented", status)
+		return v.errors
+	}
+
+	// Reading a streamed body would wait for the whole stream
+	if len(documented.content) == 0 || resp.IsBodyStream() || len(resp.Header.ContentEncoding()) > 0 {
+		return nil
+	}
+	body := resp.Body()
+	if len(body) == 0 {
+		return nil
+	}
+
+	contentType := mediaTypeOf(resp.Header.ContentType())
+	media := matchMedia(documented.content, contentType)
+	if media == nil {
+		v.fail("response", "contentType", contentType, "content type %q is not documented for status %d", contentType, status)
+		return v.errors
+	}
+
+	if media.schema != nil && isJSONMediaType(contentType) {
+		value, err := decodeJSONValue(body)
+		if err != nil {
+			v.fail("response.body", "json", nil, "is not valid JSON: %v", err)
+			return v.errors
+		}
+		media.schema.validate(v, "response.body", value)
+	}
+	return v.errors
+}
+
+// value extracts a parameter from the request and converts it to the
+// type of its schema
+// present is false when the parameter was not sent.
+func (p *specParameter) value(c *Context, pathValues map[string]string) (interface{}, bool) {
+	var raw []string
+
+	switch p.in {
+	case "path":
+		value, ok := pathValues[p.name]
+		if !ok {
+			return nil, false
+		}
+		raw = []string{value}
+
+	case "query":
+		args := c.RequestCtx.QueryArgs()
+		if p.schema.primaryType() == "object" && (p.style == "deepObject" || p.explode) {
+			return p.queryObject(c)
+		}
+		for _, value := range args.PeekMulti(p.name) {
+			raw = append(raw, string(value))
+		}
+
+	case "header":
+		if value := c.RequestCtx.Request.Header.Peek(p.name); value != nil {
+			raw = []string{string(value)}
+		}
+
+	case "cookie":
+		if value := c.RequestCtx.Request.Header.Cookie(p.name); value != nil {
+			raw = []string{string(value)}
+		}
+	}
+
+	if len(raw) == 0 {
+		return nil, false
+	}
+	return p.convert(raw), true
+}
+
+// convert converts raw parameter strings according to the schema type
+// and the parameter style
+func (p *specParameter) convert(raw []string) interface{} {
+	switch p.schema.primaryType() {
+	case "array":
+		values := raw
+		if !p.explode || p.style != "form" || len(raw) == 1 {
+			separator := map[string]string{"spaceDelimited": " ", "pipeDelimited": "|"}[p.style]
+			if separator == "" {
+				separator = ","
+			}
+			values = nil
+			for _, r := range raw {
+				values = append(values, strings.Split(r, separator)...)
+			}
+		}
+
+		var itemSchema *specSchema
+		if p.schema != nil {
+			itemSchema = p.schema.items
+		}
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			items[i] = convertScalar(itemSchema, value)
+		}
+		return items
+
+	case "object":
+		// simple and non-exploded form: "k,v,k,v"; exploded simple: "k=v,k=v"
+		obj := make(map[string]interface{})
+		parts := strings.Split(raw[0], ",")
+		if p.explode {
+			for _, part := range parts {
+				key, value, _ := strings.Cut(part, "=")
+				obj[key] = convertScalar(p.schema.property(key), value)
+			}
+		} else {
+			for i := 0; i+1 < len(parts); i += 2 {
+				obj[parts[i]] = convertScalar(p.schema.property(parts[i]), parts[i+1])
+			}
+		}
+		return obj
+
+	default:
+		return convertScalar(p.schema, raw[0])
+	}
+}
+
+// queryObject collects an object parameter sent as deepObject
+// (filter[name]=x) or as exploded form properties (name=x)
+func (p *specParameter) queryObject(c *Context) (interface{}, bool) {
+	obj := make(map[string]interface{})
+	args := c.RequestCtx.QueryArgs()
+
+	if p.style == "deepObject" {
+		prefix := p.name + "["
+		args.VisitAll(func(key, value []byte) {
+			k := string(key)
+			if strings.HasPrefix(k, prefix) && strings.HasSuffix(k, "]") {
+				name := k[len(prefix) : len(k)-1]
+				obj[name] = convertScalar(p.schema.property(name), string(value))
+			}
+		})
+	} else {
+		for name, prop := range p.schema.properties {
+			if value := args.Peek(name); value != nil {
+				obj[name] = convertScalar(prop, string(value))
+			}
+		}
+	}
+
+	if len(obj) == 0 {
+		return nil, false
+	}
+	return obj, true
+}
+
+// formValue converts a URL-encoded request body to an object
+// Properties are converted by their schema type; repeated keys of array
+// properties become arrays.
+func formValue(c *Context, schema *specSchema) map[string]interface{} {
+	obj := make(map[string]interface{})
+	args := c.RequestCtx.PostArgs()
+
+	args.VisitAll(func(key, _ []byte) {
+		name := string(key)
+		if _, done := obj[name]; done {
+			return
+		}
+
+		prop := schema.property(name)
+		if prop.primaryType() == "array" {
+			values := args.PeekMulti(name)
+			items := make([]interface{}, len(values))
+			for i, value := range values {
+				items[i] = convertScalar(prop.items, string(value))
+			}
+			obj[name] = items
+			return
+		}
+		obj[name] = convertScalar(prop, string(args.Peek(name)))
+	})
+	return obj
+}
+
+// convertScalar converts a string to the scalar type of the schema
+// Values that do not convert are kept as strings, so validation reports
+// the type mismatch.
+func convertScalar(schema *specSchema, value string) interface{} {
+	switch schema.primaryType() {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil && (value == "true" || value == "false") {
+			return b
+		}
+	case "null":
+		if value == "" || value == "null" {
+			return nil
+		}
+	}
+	return value
+}
+
+// decodeJSONValue decodes a JSON document, keeping numbers exact
+func decodeJSONValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+// mediaTypeOf returns the lowercased media type of a Content-Type header
+func mediaTypeOf(contentType []byte) string {
+	mediaType, _, _ := strings.Cut(string(contentType), ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// matchMedia finds the documented media type matching contentType
+// Content is sorted most specific first, so exact matches win over
+// "type/*" and "*/*".
+func matchMedia(content []specMedia, contentType string) *specMedia {
+	for i, media := range content {
+		switch {
+		case media.mediaType == contentType, media.mediaType == "*/*":
+			return &content[i]
+		case strings.HasSuffix(media.mediaType, "/*") &&
+			strings.HasPrefix(contentType, strings.TrimSuffix(media.mediaType, "*")):
+			return &content[i]
+		}
+	}
+	return nil
+}
+
+// isJSONMediaType reports whether a media type carries JSON
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
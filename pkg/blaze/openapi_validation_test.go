@@ -0,0 +1,268 @@
+package blaze_test
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"net/http"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+//go:embed testdata/petstore.yaml
+var specFS embed.FS
+
+const requestID = "6f1c2a9e-4b7d-4a51-9a0e-3c8f1d2b7e64"
+
+// loadPetstore loads the sample specification every way it can be loaded
+func loadPetstore(t *testing.T) map[string]*blaze.OpenAPISpec {
+	t.Helper()
+
+	fromFile, err := blaze.LoadOpenAPISpec("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatalf("LoadOpenAPISpec: %v", err)
+	}
+	fromFS, err := blaze.LoadOpenAPISpecFS(specFS, "testdata/petstore.yaml")
+	if err != nil {
+		t.Fatalf("LoadOpenAPISpecFS: %v", err)
+	}
+	return map[string]*blaze.OpenAPISpec{"file": fromFile, "embed": fromFS}
+}
+
+// petstoreApp serves the sample specification
+// Pet 2 is returned without its id and pet 3 with an undocumented status,
+// for response validation.
+func petstoreApp(spec *blaze.OpenAPISpec, config blaze.OpenAPIValidationConfig) *blaze.App {
+	app := blaze.New()
+	app.Use(blaze.OpenAPIValidation(spec, config))
+
+	api := app.Group("/v1")
+	api.GET("/pets", func(c *blaze.Context) error {
+		return c.JSON([]blaze.Map{{"id": 1, "name": "rex", "kind": "dog"}})
+	})
+	api.POST("/pets", func(c *blaze.Context) error {
+		return c.Status(http.StatusCreated).JSON(blaze.Map{"id": 7, "name": "tom", "kind": "cat"})
+	})
+	api.GET("/pets/:id", func(c *blaze.Context) error {
+		switch c.Param("id") {
+		case "2":
+			return c.JSON(blaze.Map{"name": "rex", "kind": "dog"})
+		case "3":
+			return c.Status(http.StatusTeapot).JSON(blaze.Map{})
+		}
+		return c.JSON(blaze.Map{"id": 1, "name": "rex", "kind": "dog"})
+	})
+	api.GET("/undocumented", text("ok"))
+	return app
+}
+
+func TestLoadOpenAPISpec(t *testing.T) {
+	for name, spec := range loadPetstore(t) {
+		if spec.Title != "Petstore" || spec.Version != "3.0.3" {
+			t.Errorf("%s: loaded %q version %q", name, spec.Title, spec.Version)
+		}
+	}
+
+	if _, err := blaze.LoadOpenAPISpec("testdata/missing.yaml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: %v", err)
+	}
+
+	invalid := map[string]string{
+		"swagger 2":       "swagger: \"2.0\"\n",
+		"not an object":   "- a\n",
+		"external ref":    "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      requestBody:\n        content:\n          application/json:\n            schema:\n              $ref: other.yaml#/Pet\n",
+		"missing ref":     "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      requestBody:\n        content:\n          application/json:\n            schema:\n              $ref: \"#/components/schemas/Nope\"\n",
+		"malformed JSON":  `{"openapi": "3.0.0",`,
+		"malformed YAML":  "openapi: [3.0.0\n",
+		"invalid pattern": "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      parameters:\n        - name: q\n          in: query\n          schema:\n            type: string\n            pattern: \"[\"\n",
+		"path without in": "openapi: 3.0.0\npaths:\n  /a:\n    get:\n      parameters:\n        - name: q\n",
+	}
+	for name, doc := range invalid {
+		if _, err := blaze.ParseOpenAPISpec([]byte(doc)); err == nil {
+			t.Errorf("%s: parsed without an error", name)
+		}
+	}
+}
+
+func TestOpenAPIValidationRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		query  map[string]string
+		header string
+		status int
+		field  string
+		tag    string
+	}{
+		{"valid", "/v1/pets", map[string]string{"limit": "10", "tags": "cat,dog"}, requestID, http.StatusOK, "", ""},
+		{"path parameter type", "/v1/pets/rex", nil, "", http.StatusUnprocessableEntity, "path.id", "type"},
+		{"path parameter minimum", "/v1/pets/0", nil, "", http.StatusUnprocessableEntity, "path.id", "minimum"},
+		{"query parameter maximum", "/v1/pets", map[string]string{"limit": "500"}, requestID, http.StatusUnprocessableEntity, "query.limit", "maximum"},
+		{"query parameter type", "/v1/pets", map[string]string{"limit": "ten"}, requestID, http.StatusUnprocessableEntity, "query.limit", "type"},
+		{"query array item", "/v1/pets", map[string]string{"tags": "cat,fish"}, requestID, http.StatusUnprocessableEntity, "query.tags[1]", "enum"},
+		{"missing header", "/v1/pets", nil, "", http.StatusUnprocessableEntity, "header.X-Request-ID", "required"},
+		{"header format", "/v1/pets", nil, "not-a-uuid", http.StatusUnprocessableEntity, "header.X-Request-ID", "format"},
+		{"undocumented path", "/v1/undocumented", nil, "", http.StatusOK, "", ""},
+	}
+
+	for name, spec := range loadPetstore(t) {
+		srv := blazetest.New(t, petstoreApp(spec, blaze.DefaultOpenAPIValidationConfig()))
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				req := srv.Get(tt.path)
+				for key, value := range tt.query {
+					req.WithQuery(key, value)
+				}
+				if tt.header != "" {
+					req.WithHeader("X-Request-ID", tt.header)
+				}
+
+				res := req.Do().AssertStatus(tt.status)
+				if tt.field == "" {
+					return
+				}
+				res.AssertJSONPath("error.code", "VALIDATION_ERROR").
+					AssertJSONPath("error.details.0.field", tt.field).
+					AssertJSONPath("error.details.0.tag", tt.tag).
+					AssertJSONPathExists("error.details.0.message")
+			})
+		}
+	}
+}
+
+func TestOpenAPIValidationBodies(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		details []blaze.ValidationError
+	}{
+		{
+			name:   "valid",
+			body:   `{"name": "tom", "kind": "cat", "tags": ["grey"]}`,
+			status: http.StatusCreated,
+		},
+		{
+			name:   "missing required properties",
+			body:   `{"tags": []}`,
+			status: http.StatusUnprocessableEntity,
+			details: []blaze.ValidationError{
+				{Field: "body.name", Tag: "required"},
+				{Field: "body.kind", Tag: "required"},
+			},
+		},
+		{
+			name:   "nested properties",
+			body:   `{"name": "", "kind": "fish", "tags": ["a", 2, "c", "d"], "owner": {"email": "nope"}}`,
+			status: http.StatusUnprocessableEntity,
+			details: []blaze.ValidationError{
+				{Field: "body.kind", Tag: "enum", Value: "fish"},
+				{Field: "body.name", Tag: "minLength", Value: ""},
+				{Field: "body.owner.email", Tag: "format", Value: "nope"},
+				{Field: "body.tags", Tag: "maxItems"},
+				{Field: "body.tags[1]", Tag: "type", Value: float64(2)},
+			},
+		},
+		{
+			name:   "not an object",
+			body:   `[1, 2]`,
+			status: http.StatusUnprocessableEntity,
+			details: []blaze.ValidationError{
+				{Field: "body", Tag: "type"},
+			},
+		},
+		{
+			name:   "malformed",
+			body:   `{"name":`,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for name, spec := range loadPetstore(t) {
+		srv := blazetest.New(t, petstoreApp(spec, blaze.DefaultOpenAPIValidationConfig()))
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				res := srv.Post("/v1/pets").WithBody("application/json", []byte(tt.body)).Do().
+					AssertStatus(tt.status)
+				if tt.details == nil {
+					return
+				}
+
+				var got struct {
+					Error struct {
+						Code    string                  `json:"code"`
+						Details []blaze.ValidationError `json:"details"`
+					} `json:"error"`
+				}
+				if err := res.JSON(&got); err != nil {
+					t.Fatal(err)
+				}
+				if got.Error.Code != "VALIDATION_ERROR" {
+					t.Errorf("code = %q", got.Error.Code)
+				}
+				if len(got.Error.Details) != len(tt.details) {
+					t.Fatalf("got %d errors, want %d: %+v", len(got.Error.Details), len(tt.details), got.Error.Details)
+				}
+				for i, want := range tt.details {
+					detail := got.Error.Details[i]
+					if detail.Field != want.Field || detail.Tag != want.Tag || detail.Value != want.Value || detail.Message == "" {
+						t.Errorf("error %d = %+v, want %+v", i, detail, want)
+					}
+				}
+			})
+		}
+
+		t.Run(name+"/content type", func(t *testing.T) {
+			srv.Post("/v1/pets").WithBody("text/plain", []byte("tom")).Do().
+				AssertStatus(http.StatusUnsupportedMediaType)
+			srv.Post("/v1/pets").Do().
+				AssertStatus(http.StatusUnprocessableEntity).
+				AssertJSONPath("error.details.0.field", "body")
+		})
+	}
+}
+
+func TestOpenAPIValidationResponses(t *testing.T) {
+	for name, spec := range loadPetstore(t) {
+		t.Run(name, func(t *testing.T) {
+			config := blaze.DefaultOpenAPIValidationConfig()
+			config.ValidateResponses = true
+			config.RejectUnknown = true
+			srv := blazetest.New(t, petstoreApp(spec, config))
+
+			srv.Get("/v1/pets/1").Do().AssertStatus(http.StatusOK)
+			srv.Get("/v1/pets").WithHeader("X-Request-ID", requestID).Do().AssertStatus(http.StatusOK)
+
+			srv.Get("/v1/pets/2").Do().
+				AssertStatus(http.StatusInternalServerError).
+				AssertJSONPath("error.message", "Response validation failed").
+				AssertJSONPath("error.details.0.field", "response.body.id").
+				AssertJSONPath("error.details.0.tag", "required")
+			srv.Get("/v1/pets/3").Do().
+				AssertStatus(http.StatusInternalServerError).
+				AssertJSONPath("error.details.0.field", "response").
+				AssertJSONPath("error.details.0.tag", "status")
+
+			// Undocumented routes and methods are rejected
+			srv.Get("/v1/undocumented").Do().AssertStatus(http.StatusNotFound)
+			srv.Delete("/v1/pets/1").Do().AssertStatus(http.StatusMethodNotAllowed)
+		})
+	}
+
+	// A custom handler decides what invalid responses become
+	config := blaze.DefaultOpenAPIValidationConfig()
+	config.ValidateResponses = true
+	var reported blaze.ValidationErrors
+	config.OnInvalidResponse = func(c *blaze.Context, errs blaze.ValidationErrors) error {
+		reported = errs
+		return nil
+	}
+
+	srv := blazetest.New(t, petstoreApp(loadPetstore(t)["file"], config))
+	srv.Get("/v1/pets/2").Do().AssertStatus(http.StatusOK).AssertJSONPath("name", "rex")
+	if len(reported.Errors) != 1 || reported.Errors[0].Field != "response.body.id" {
+		t.Errorf("reported %+v", reported.Errors)
+	}
+}
@@ -0,0 +1,704 @@
+package blaze
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ==================== Schema Validation ====================
+
+// specSchema is a compiled JSON Schema of an OpenAPI document
+// Covers the keywords of OpenAPI 3.0 schema objects and the JSON Schema
+// 2020-12 keywords commonly used in OpenAPI 3.1 documents.
+type specSchema struct {
+	// never rejects every value (the "false" schema)
+	never bool
+
+	types    []string
+	nullable bool
+	enum     []interface{}
+	hasConst bool
+	constVal interface{}
+	format   string
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	items       *specSchema
+	prefixItems []*specSchema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	properties           map[string]*specSchema
+	required             []string
+	additionalProperties *specSchema
+	minProperties        *int
+	maxProperties        *int
+
+	allOf []*specSchema
+	anyOf []*specSchema
+	oneOf []*specSchema
+	not   *specSchema
+
+	readOnly  bool
+	writeOnly bool
+}
+
+// schema compiles a schema object
+func (sc *specCompiler) schema(node interface{}) (*specSchema, error) {
+	switch n := node.(type) {
+	case bool:
+		return &specSchema{never: !n}, nil
+	case map[string]interface{}:
+		if ref, ok := n["$ref"].(string); ok && len(n) == 1 {
+			return sc.ref(ref)
+		}
+		s := &specSchema{}
+		if err := sc.fillSchema(s, n); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("schema must be an object")
+	}
+}
+
+// ref compiles the schema a reference points to, once per reference
+// The schema is cached before it is filled, so recursive schemas resolve
+// to themselves.
+func (sc *specCompiler) ref(ref string) (*specSchema, error) {
+	if s, ok := sc.refs[ref]; ok {
+		return s, nil
+	}
+
+	node, err := sc.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &specSchema{}
+	sc.refs[ref] = s
+
+	switch n := node.(type) {
+	case bool:
+		s.never = !n
+	case map[string]interface{}:
+		if err := sc.fillSchema(s, n); err != nil {
+			return nil, fmt.Errorf("%s: %w", ref, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: schema must be an object", ref)
+	}
+	return s, nil
+}
+
+// fillSchema compiles the keywords of a schema object into s
+func (sc *specCompiler) fillSchema(s *specSchema, n map[string]interface{}) error {
+	// A reference with sibling keywords applies both
+	if ref, ok := n["$ref"].(string); ok {
+		target, err := sc.ref(ref)
+		if err != nil {
+			return err
+		}
+		s.allOf = append(s.allOf, target)
+	}
+
+	switch t := n["type"].(type) {
+	case string:
+		s.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				s.types = append(s.types, name)
+			}
+		}
+	}
+	for _, t := range s.types {
+		if t == "null" {
+			s.nullable = true
+		}
+	}
+	if nullable, ok := n["nullable"].(bool); ok && nullable {
+		s.nullable = true
+	}
+
+	if enum, ok := n["enum"].([]interface{}); ok {
+		s.enum = enum
+	}
+	if value, ok := n["const"]; ok {
+		s.hasConst = true
+		s.constVal = value
+	}
+	s.format, _ = n["format"].(string)
+	s.readOnly = n["readOnly"] == true
+	s.writeOnly = n["writeOnly"] == true
+
+	// Numeric bounds; OpenAPI 3.0 uses boolean exclusive flags
+	s.minimum = schemaNumber(n["minimum"])
+	s.maximum = schemaNumber(n["maximum"])
+	if n["exclusiveMinimum"] == true {
+		s.exclusiveMinimum, s.minimum = s.minimum, nil
+	} else {
+		s.exclusiveMinimum = schemaNumber(n["exclusiveMinimum"])
+	}
+	if n["exclusiveMaximum"] == true {
+		s.exclusiveMaximum, s.maximum = s.maximum, nil
+	} else {
+		s.exclusiveMaximum = schemaNumber(n["exclusiveMaximum"])
+	}
+	s.multipleOf = schemaNumber(n["multipleOf"])
+
+	s.minLength = schemaInt(n["minLength"])
+	s.maxLength = schemaInt(n["maxLength"])
+	if pattern, ok := n["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		s.pattern = re
+	}
+
+	s.minItems = schemaInt(n["minItems"])
+	s.maxItems = schemaInt(n["maxItems"])
+	s.uniqueItems = n["uniqueItems"] == true
+
+	s.minProperties = schemaInt(n["minProperties"])
+	s.maxProperties = schemaInt(n["maxProperties"])
+	if required, ok := n["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				s.required = append(s.required, name)
+			}
+		}
+	}
+
+	var err error
+	if items, ok := n["items"]; ok {
+		if s.items, err = sc.schema(items); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	}
+	if s.prefixItems, err = sc.schemaList(n["prefixItems"]); err != nil {
+		return fmt.Errorf("prefixItems: %w", err)
+	}
+
+	if properties, ok := n["properties"].(map[string]interface{}); ok {
+		s.properties = make(map[string]*specSchema, len(properties))
+		for name, propNode := range properties {
+			if s.properties[name], err = sc.schema(propNode); err != nil {
+				return fmt.Errorf("property %s: %w", name, err)
+			}
+		}
+	}
+	if additional, ok := n["additionalProperties"]; ok {
+		if s.additionalProperties, err = sc.schema(additional); err != nil {
+			return fmt.Errorf("additionalProperties: %w", err)
+		}
+	}
+
+	allOf, err := sc.schemaList(n["allOf"])
+	if err != nil {
+		return fmt.Errorf("allOf: %w", err)
+	}
+	s.allOf = append(s.allOf, allOf...)
+	if s.anyOf, err = sc.schemaList(n["anyOf"]); err != nil {
+		return fmt.Errorf("anyOf: %w", err)
+	}
+	if s.oneOf, err = sc.schemaList(n["oneOf"]); err != nil {
+		return fmt.Errorf("oneOf: %w", err)
+	}
+	if not, ok := n["not"]; ok {
+		if s.not, err = sc.schema(not); err != nil {
+			return fmt.Errorf("not: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// schemaList compiles a list of schemas
+func (sc *specCompiler) schemaList(node interface{}) ([]*specSchema, error) {
+	list, _ := node.([]interface{})
+	schemas := make([]*specSchema, 0, len(list))
+	for _, item := range list {
+		s, err := sc.schema(item)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+// schemaValidation collects the errors of one validation pass
+type schemaValidation struct {
+	// response is true when validating a response body: readOnly
+	// properties are then required and writeOnly properties are not
+	response bool
+
+	errors []ValidationError
+}
+
+// fail records a validation error
+// Only scalar values are included in the error.
+func (v *schemaValidation) fail(field, tag string, value interface{}, format string, args ...interface{}) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		value = nil
+	}
+	v.errors = append(v.errors, ValidationError{
+		Field:   field,
+		Tag:     tag,
+		Value:   value,
+		Message: field + " " + fmt.Sprintf(format, args...),
+	})
+}
+
+// matches reports whether value is valid against s, without recording errors
+func (s *specSchema) matches(value interface{}, response bool) bool {
+	v := &schemaValidation{response: response}
+	s.validate(v, "", value)
+	return len(v.errors) == 0
+}
+
+// validate checks value against the schema
+// field is the path of the value in errors ("body.items[0].name").
+func (s *specSchema) validate(v *schemaValidation, field string, value interface{}) {
+	if s.never {
+		v.fail(field, "false", value, "is not allowed")
+		return
+	}
+
+	kind := jsonKind(value)
+	if len(s.types) > 0 && !s.allowsKind(kind) {
+		v.fail(field, "type", value, "must be of type %s", strings.Join(s.types, " or "))
+		return
+	}
+	if kind == "null" && s.nullable {
+		return
+	}
+
+	if s.enum != nil {
+		found := false
+		for _, allowed := range s.enum {
+			if jsonEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(field, "enum", value, "must be one of %s", formatEnum(s.enum))
+		}
+	}
+	if s.hasConst && !jsonEqual(value, s.constVal) {
+		v.fail(field, "const", value, "must be %v", s.constVal)
+	}
+
+	switch kind {
+	case "integer", "number":
+		s.validateNumber(v, field, value)
+	case "string":
+		s.validateString(v, field, value.(string))
+	case "array":
+		s.validateArray(v, field, value.([]interface{}))
+	case "object":
+		s.validateObject(v, field, value.(map[string]interface{}))
+	}
+
+	for _, sub := range s.allOf {
+		sub.validate(v, field, value)
+	}
+
+	if len(s.anyOf) > 0 {
+		matched := false
+		for _, sub := range s.anyOf {
+			if sub.matches(value, v.response) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(field, "anyOf", value, "must match at least one of the allowed schemas")
+		}
+	}
+
+	if len(s.oneOf) > 0 {
+		matched := 0
+		for _, sub := range s.oneOf {
+			if sub.matches(value, v.response) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.fail(field, "oneOf", value, "must match exactly one of the allowed schemas (matched %d)", matched)
+		}
+	}
+
+	if s.not != nil && s.not.matches(value, v.response) {
+		v.fail(field, "not", value, "must not match the disallowed schema")
+	}
+}
+
+// allowsKind reports whether the declared types accept a JSON kind
+func (s *specSchema) allowsKind(kind string) bool {
+	if kind == "null" && s.nullable {
+		return true
+	}
+	for _, t := range s.types {
+		if t == kind || (t == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNumber checks numeric keywords
+func (s *specSchema) validateNumber(v *schemaValidation, field string, value interface{}) {
+	n, _ := jsonFloat(value)
+
+	if s.minimum != nil && n < *s.minimum {
+		v.fail(field, "minimum", value, "must be greater than or equal to %v", *s.minimum)
+	}
+	if s.maximum != nil && n > *s.maximum {
+		v.fail(field, "maximum", value, "must be less than or equal to %v", *s.maximum)
+	}
+	if s.exclusiveMinimum != nil && n <= *s.exclusiveMinimum {
+		v.fail(field, "exclusiveMinimum", value, "must be greater than %v", *s.exclusiveMinimum)
+	}
+	if s.exclusiveMaximum != nil && n >= *s.exclusiveMaximum {
+		v.fail(field, "exclusiveMaximum", value, "must be less than %v", *s.exclusiveMaximum)
+	}
+	if s.multipleOf != nil && *s.multipleOf > 0 {
+		q := n / *s.multipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(field, "multipleOf", value, "must be a multiple of %v", *s.multipleOf)
+		}
+	}
+
+	switch s.format {
+	case "int32":
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			v.fail(field, "format", value, "must be a 32-bit integer")
+		}
+	case "int64":
+		if n < math.MinInt64 || n > math.MaxInt64 {
+			v.fail(field, "format", value, "must be a 64-bit integer")
+		}
+	}
+}
+
+// validateString checks string keywords
+func (s *specSchema) validateString(v *schemaValidation, field, value string) {
+	length := utf8.RuneCountInString(value)
+	if s.minLength != nil && length < *s.minLength {
+		v.fail(field, "minLength", value, "must be at least %d characters long", *s.minLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		v.fail(field, "maxLength", value, "must be at most %d characters long", *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		v.fail(field, "pattern", value, "must match the pattern %s", s.pattern)
+	}
+	if description, ok := stringFormats[s.format]; ok && !validStringFormat(s.format, value) {
+		v.fail(field, "format", value, "must be %s", description)
+	}
+}
+
+// validateArray checks array keywords and the items
+func (s *specSchema) validateArray(v *schemaValidation, field string, items []interface{}) {
+	if s.minItems != nil && len(items) < *s.minItems {
+		v.fail(field, "minItems", nil, "must contain at least %d items", *s.minItems)
+	}
+	if s.maxItems != nil && len(items) > *s.maxItems {
+		v.fail(field, "maxItems", nil, "must contain at most %d items", *s.maxItems)
+	}
+
+	if s.uniqueItems {
+	unique:
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if jsonEqual(items[i], items[j]) {
+					v.fail(field, "uniqueItems", nil, "must not contain duplicate items")
+					break unique
+				}
+			}
+		}
+	}
+
+	for i, item := range items {
+		itemField := field + "[" + strconv.Itoa(i) + "]"
+		switch {
+		case i < len(s.prefixItems):
+			s.prefixItems[i].validate(v, itemField, item)
+		case s.items != nil:
+			s.items.validate(v, itemField, item)
+		}
+	}
+}
+
+// validateObject checks object keywords and the properties
+func (s *specSchema) validateObject(v *schemaValidation, field string, obj map[string]interface{}) {
+	if s.minProperties != nil && len(obj) < *s.minProperties {
+		v.fail(field, "minProperties", nil, "must have at least %d properties", *s.minProperties)
+	}
+	if s.maxProperties != nil && len(obj) > *s.maxProperties {
+		v.fail(field, "maxProperties", nil, "must have at most %d properties", *s.maxProperties)
+	}
+
+	for _, name := range s.required {
+		if _, ok := obj[name]; ok {
+			continue
+		}
+		// readOnly properties are not sent in requests, writeOnly
+		// properties are not returned in responses
+		if prop := s.properties[name]; prop != nil && ((prop.readOnly && !v.response) || (prop.writeOnly && v.response)) {
+			continue
+		}
+		v.fail(joinField(field, name), "required", nil, "is required")
+	}
+
+	// Sorted for stable error order
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if prop, ok := s.properties[name]; ok {
+			prop.validate(v, joinField(field, name), obj[name])
+			continue
+		}
+		if s.additionalProperties == nil {
+			continue
+		}
+		if s.additionalProperties.never {
+			v.fail(joinField(field, name), "additionalProperties", nil, "is not allowed")
+			continue
+		}
+		s.additionalProperties.validate(v, joinField(field, name), obj[name])
+	}
+}
+
+// primaryType returns the first non-null type of the schema, looking
+// into allOf, anyOf and oneOf when no type is declared
+func (s *specSchema) primaryType() string {
+	return s.findType(0)
+}
+
+func (s *specSchema) findType(depth int) string {
+	if s == nil || depth > 8 {
+		return ""
+	}
+	for _, t := range s.types {
+		if t != "null" {
+			return t
+		}
+	}
+	for _, group := range [][]*specSchema{s.allOf, s.anyOf, s.oneOf} {
+		for _, sub := range group {
+			if t := sub.findType(depth + 1); t != "" {
+				return t
+			}
+		}
+	}
+	return ""
+}
+
+// property returns the schema of a property, looking into allOf
+func (s *specSchema) property(name string) *specSchema {
+	if s == nil {
+		return nil
+	}
+	if prop, ok := s.properties[name]; ok {
+		return prop
+	}
+	for _, sub := range s.allOf {
+		if prop := sub.property(name); prop != nil {
+			return prop
+		}
+	}
+	return nil
+}
+
+// joinField appends a property name to a field path
+func joinField(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+// ==================== JSON Values ====================
+
+// jsonKind returns the JSON Schema type of a decoded value
+func jsonKind(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case int, int64, uint64:
+		return "integer"
+	case float64, json.Number:
+		if f, ok := jsonFloat(val); ok && f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return "unknown"
+	}
+}
+
+// jsonFloat returns the numeric value of a decoded number
+func jsonFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// jsonEqual compares decoded values, treating numbers by value
+func jsonEqual(a, b interface{}) bool {
+	if x, ok := jsonFloat(a); ok {
+		y, ok := jsonFloat(b)
+		return ok && x == y
+	}
+
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// formatEnum lists enum values for error messages
+func formatEnum(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		if data, err := json.Marshal(value); err == nil {
+			parts[i] = string(data)
+		} else {
+			parts[i] = fmt.Sprint(value)
+		}
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// schemaNumber reads a numeric keyword
+func schemaNumber(value interface{}) *float64 {
+	if n, ok := jsonFloat(value); ok {
+		return &n
+	}
+	return nil
+}
+
+// schemaInt reads a non-negative integer keyword
+func schemaInt(value interface{}) *int {
+	if n, ok := jsonFloat(value); ok && n >= 0 {
+		i := int(n)
+		return &i
+	}
+	return nil
+}
+
+// ==================== Formats ====================
+
+// stringFormats describes the checked string formats; other formats are
+// accepted without checks
+var stringFormats = map[string]string{
+	"date-time": "a valid RFC 3339 date-time",
+	"date":      "a valid date (YYYY-MM-DD)",
+	"email":     "a valid email address",
+	"uuid":      "a valid UUID",
+	"uri":       "a valid absolute URI",
+	"ipv4":      "a valid IPv4 address",
+	"ipv6":      "a valid IPv6 address",
+}
+
+// uuidPattern matches UUIDs in their canonical textual form
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validStringFormat checks a string against a format
+func validStringFormat(format, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "email":
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case "uuid":
+		return uuidPattern.MatchString(value)
+	case "uri":
+		u, err := url.Parse(value)
+		return err == nil && u.IsAbs()
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	case "ipv6":
+		return net.ParseIP(value) != nil && strings.Contains(value, ":")
+	default:
+		return true
+	}
+}
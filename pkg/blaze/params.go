@@ -0,0 +1,51 @@
+package blaze
+
+// ==================== Route Parameters ====================
+
+// routeParam is a single captured route parameter
+type routeParam struct {
+	key   string
+	value string
+}
+
+// paramList stores captured route parameters in capture order
+// A slice is reused across requests by pooled contexts, so matching a
+// route does not allocate a map. Routes have few parameters, which makes
+// a linear scan faster than hashing.
+type paramList []routeParam
+
+// get returns the value of the named parameter
+// Later values win, so a path parameter shadows a host label of the same name
+func (ps paramList) get(key string) (string, bool) {
+	for i := len(ps) - 1; i >= 0; i-- {
+		if ps[i].key == key {
+			return ps[i].value, true
+		}
+	}
+	return "", false
+}
+
+// add appends a captured parameter
+func (ps *paramList) add(key, value string) {
+	*ps = append(*ps, routeParam{key: key, value: value})
+}
+
+// set replaces the value of the named parameter or appends it
+func (ps *paramList) set(key, value string) {
+	for i := len(*ps) - 1; i >= 0; i-- {
+		if (*ps)[i].key == key {
+			(*ps)[i].value = value
+			return
+		}
+	}
+	ps.add(key, value)
+}
+
+// toMap returns the parameters as a map
+func (ps paramList) toMap() map[string]string {
+	m := make(map[string]string, len(ps))
+	for _, p := range ps {
+		m[p.key] = p.value
+	}
+	return m
+}
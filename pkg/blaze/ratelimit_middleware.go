@@ -284,8 +284,8 @@ func getClientIP(c *Context) string {
 //	strict := blaze.RateLimitOptions{Requests: 10, Window: time.Minute}
 //	generous := blaze.RateLimitOptions{Requests: 1000, Window: time.Minute}
 //
-//	app.POST("/login", handler, blaze.RateLimitMiddleware(strict))
-//	app.GET("/api/data", handler, blaze.RateLimitMiddleware(generous))
+//	app.POST("/login", handler, blaze.WithRateLimit(strict))
+//	app.GET("/api/data", handler, blaze.WithRateLimit(generous))
 //
 // Example - With custom error response:
 //
@@ -310,6 +310,11 @@ func RateLimitMiddleware(opts RateLimitOptions) MiddlewareFunc {
 			limiter.cleanup()
 		}
 	}()
+	return limiter.middleware()
+}
+
+// middleware returns middleware rejecting clients over the limit with 429
+func (limiter *RateLimiter) middleware() MiddlewareFunc {
 	return func(next HandlerFunc) HandlerFunc {
 		return func(c *Context) error {
 			ip := getClientIP(c)
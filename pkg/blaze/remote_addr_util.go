@@ -1,6 +1,7 @@
 package blaze
 
 import (
+	"log"
 	"net"
 	"strings"
 
@@ -274,3 +275,68 @@ func IPMiddleware() MiddlewareFunc {
 //           }
 //       }
 //   }
+
+// IsFromTrustedProxy reports whether the request came directly from a
+// proxy listed in Config.TrustedProxies
+// Forwarded headers such as X-Forwarded-Proto can be sent by any client,
+// so they are only meaningful when this returns true. Mounted apps use
+// the configuration of the app serving the request.
+//
+// Returns:
+//   - bool: true when the remote address is a trusted proxy
+//
+// Example:
+//
+//	config := blaze.DefaultConfig()
+//	config.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.10"}
+//	app := blaze.NewWithConfig(config)
+//
+//	app.GET("/", func(c *blaze.Context) error {
+//	    return c.JSON(blaze.Map{"proxied": c.IsFromTrustedProxy()})
+//	})
+func (c *Context) IsFromTrustedProxy() bool {
+	app, ok := c.Locals("__app__").(*App)
+	if !ok {
+		return false
+	}
+	for app.parent != nil {
+		app = app.parent
+	}
+	return app.trustsProxy(c.RemoteIP())
+}
+
+// trustsProxy reports whether ip is listed in Config.TrustedProxies
+func (a *App) trustsProxy(ip net.IP) bool {
+	a.trustedProxiesOnce.Do(func() {
+		a.trustedProxies = parseTrustedProxies(a.config.TrustedProxies)
+	})
+
+	for _, network := range a.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses IPs and CIDR ranges
+// Invalid entries are logged and skipped, so they never trust a client.
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		log.Printf("blaze: ignoring invalid trusted proxy %q", proxy)
+	}
+	return networks
+}
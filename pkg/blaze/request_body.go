@@ -0,0 +1,228 @@
+package blaze
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// ==================== Streaming Request Bodies ====================
+
+// ErrBodyTooLarge is wrapped by the 413 HTTPError returned when a request
+// body exceeds its limit while it is read
+//
+// Example:
+//
+//	if _, err := io.Copy(file, c.BodyStream()); errors.Is(err, blaze.ErrBodyTooLarge) {
+//	    os.Remove(file.Name())
+//	}
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// BodyStream returns a reader for the request body
+// On routes registered with WithStreamingBody, and with
+// Config.StreamRequestBody enabled, the body is read from the connection
+// as the reader is consumed, so uploads of any size can be copied to disk
+// or processed without being held in memory. Other requests read from the
+// buffered body.
+//
+// The body size is checked while it is read: once the route's limit
+// (WithBodyLimit, or Config.MaxRequestBodySize without one) is exceeded,
+// Read returns a 413 HTTPError wrapping ErrBodyTooLarge, which the handler
+// can return as is.
+//
+// The stream can only be read once and only until the handler returns.
+// Body, Bind and form methods buffer the remaining stream (within the
+// same limit); do not mix them with BodyStream.
+//
+// Returns:
+//   - io.Reader: Request body reader
+//
+// Example:
+//
+//	app.PUT("/files/:name", func(c *blaze.Context) error {
+//	    file, err := os.Create(filepath.Join("uploads", filepath.Base(c.Param("name"))))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    defer file.Close()
+//
+//	    n, err := io.Copy(file, c.BodyStream())
+//	    if err != nil {
+//	        os.Remove(file.Name())
+//	        return err
+//	    }
+//	    return c.JSON(blaze.Map{"size": n})
+//	}, blaze.WithStreamingBody(), blaze.WithBodyLimit(10<<30))
+func (c *Context) BodyStream() io.Reader {
+	if c.body != nil {
+		return c.body
+	}
+	return bytes.NewReader(c.RequestCtx.PostBody())
+}
+
+// IsBodyStreamed reports whether the request body is read from the
+// connection by BodyStream rather than buffered in memory
+//
+// Returns:
+//   - bool: true on streaming routes while the body is unread
+func (c *Context) IsBodyStreamed() bool {
+	return c.body != nil
+}
+
+// requestBody reads a request body streamed by the server, enforcing the
+// body limit and extending the read deadline as data arrives
+type requestBody struct {
+	r        io.Reader
+	length   int   // Content-Length, -1 when chunked
+	limit    int64 // 0 = no limit
+	read     int64
+	deadline readDeadline
+
+	eof    bool
+	err    error       // Sticky read error
+	closed atomic.Bool // Set when the handler returns
+}
+
+// newRequestBody wraps the streamed body of the request handled by c
+func newRequestBody(c *Context, limit int64, readTimeout time.Duration) *requestBody {
+	return &requestBody{
+		r:        c.RequestCtx.RequestBodyStream(),
+		length:   c.RequestCtx.Request.Header.ContentLength(),
+		limit:    limit,
+		deadline: readDeadline{conn: c.RequestCtx.Conn(), timeout: readTimeout},
+	}
+}
+
+func (b *requestBody) Read(p []byte) (int, error) {
+	if b.closed.Load() {
+		return 0, ErrStreamClosed
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	if b.eof {
+		return 0, io.EOF
+	}
+	if b.limit > 0 && (int64(b.length) > b.limit || b.read > b.limit) {
+		return 0, b.fail(b.tooLarge())
+	}
+
+	// Read one byte past the limit to detect oversized chunked bodies
+	if b.limit > 0 && int64(len(p)) > b.limit-b.read+1 {
+		p = p[:b.limit-b.read+1]
+	}
+
+	b.deadline.extend()
+	n, err := b.r.Read(p)
+	if b.limit > 0 && b.read+int64(n) > b.limit {
+		n = int(b.limit - b.read)
+		b.read = b.limit
+		return n, b.fail(b.tooLarge())
+	}
+	b.read += int64(n)
+
+	switch {
+	case err == io.EOF:
+		b.eof = true
+	case err != nil:
+		b.fail(err)
+	}
+	return n, err
+}
+
+// setLimit changes the body limit; limits below the bytes already read
+// fail the next read
+func (b *requestBody) setLimit(limit int64) {
+	b.limit = limit
+}
+
+// lowerLimit applies limit if it is stricter than the current one
+func (b *requestBody) lowerLimit(limit int64) {
+	if limit > 0 && (b.limit <= 0 || limit < b.limit) {
+		b.limit = limit
+	}
+}
+
+// fail makes err the result of every later read
+func (b *requestBody) fail(err error) error {
+	b.err = err
+	return err
+}
+
+// tooLarge returns the 413 error for the current limit
+func (b *requestBody) tooLarge() error {
+	message := fmt.Sprintf("Request body exceeds the limit of %d bytes", b.limit)
+	return ErrPayloadTooLarge(message).WithInternal(ErrBodyTooLarge)
+}
+
+// close ends the stream when the handler returns
+// It reports whether the body was read to the end; unread bytes are left
+// on the connection, which then cannot serve another request.
+func (b *requestBody) close() bool {
+	b.closed.Store(true)
+	return b.eof && b.err == nil
+}
+
+// bufferBody reads a streamed body into memory, so it can be accessed
+// like a buffered one
+// The limit applies as for BodyStream; on failure the body is left empty.
+//
+// Returns:
+//   - error: 413 HTTPError or read error, nil when the body is not streamed
+func (c *Context) bufferBody() error {
+	body := c.body
+	if body == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		// The server may not read the rest of the body; the connection is
+		// closed after the response
+		c.RequestCtx.Request.SetBody(nil)
+		return err
+	}
+
+	c.body = nil
+	c.RequestCtx.Request.SetBody(data)
+	return nil
+}
+
+// finishBody ends the streamed body when the handler returns, closing the
+// connection after the response if part of the body is still unread
+func (c *Context) finishBody() {
+	body := c.body
+	if body == nil {
+		return
+	}
+
+	if c.retained {
+		// A handler abandoned on timeout may still be reading; the server
+		// closes that connection itself
+		body.closed.Store(true)
+		return
+	}
+	if !body.close() {
+		c.RequestCtx.SetConnectionClose()
+	}
+}
+
+// readDeadline extends the read deadline of a streamed request body
+// The server sets Config.ReadTimeout once for the whole request, which
+// would cut long uploads short, so streamed bodies extend it before every
+// read instead. A stalled client still times out.
+type readDeadline struct {
+	conn    net.Conn
+	timeout time.Duration
+}
+
+// extend moves the read deadline one timeout into the future
+func (d readDeadline) extend() {
+	if d.conn != nil && d.timeout > 0 {
+		d.conn.SetReadDeadline(time.Now().Add(d.timeout))
+	}
+}
@@ -1,7 +1,7 @@
 package blaze
 
 import (
-	"bufio"
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -597,7 +597,8 @@ func (c *Context) AppendBody(body []byte) *Context {
 // ==================== JSON Responses ====================
 
 // JSON sends a JSON response with 200 status
-// Automatically sets Content-Type to application/json
+// Automatically sets Content-Type to application/json. Data is encoded
+// with the App's JSON encoder (see SetJSONEncoder).
 //
 // Parameters:
 //   - data: Data to serialize as JSON
@@ -610,8 +611,13 @@ func (c *Context) AppendBody(body []byte) *Context {
 //	return c.JSON(blaze.Map{"message": "Hello"})
 //	return c.JSON(user)
 func (c *Context) JSON(data interface{}) error {
+	jsonData, err := c.jsonEncoder().Marshal(data)
+	if err != nil {
+		return err
+	}
 	c.SetContentType("application/json; charset=utf-8")
-	return fastjson.NewEncoder(c.RequestCtx).Encode(data)
+	_, err = c.RequestCtx.Write(jsonData)
+	return err
 }
 
 // JSONStatus sends a JSON response with custom status code
@@ -633,6 +639,27 @@ func (c *Context) JSONStatus(status int, data interface{}) error {
 	return c.JSON(data)
 }
 
+// Problem sends err as RFC 9457 problem details
+// Sets Content-Type to application/problem+json; the status code is left
+// to the caller. The body is encoded with the App's JSON encoder.
+//
+// Parameters:
+//   - err: Error to send
+//
+// Returns:
+//   - error: JSON encoding error or nil
+//
+// Example:
+//
+//	return c.Status(409).Problem(blaze.ErrConflict("User already exists"))
+func (c *Context) Problem(err *HTTPError) error {
+	if err := c.JSON(err.ToProblemDetails(c)); err != nil {
+		return err
+	}
+	c.SetContentType(ProblemContentType)
+	return nil
+}
+
 // JSONPretty sends a pretty-printed JSON response
 // Useful for debugging and human-readable responses
 //
@@ -647,12 +674,17 @@ func (c *Context) JSONStatus(status int, data interface{}) error {
 //
 //	return c.JSONPretty(data, "  ")
 func (c *Context) JSONPretty(data interface{}, indent string) error {
-	c.SetContentType("application/json; charset=utf-8")
-	jsonData, err := json.MarshalIndent(data, "", indent)
+	jsonData, err := c.jsonEncoder().Marshal(data)
 	if err != nil {
 		return err
 	}
-	c.SetBody(jsonData)
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, jsonData, "", indent); err != nil {
+		return err
+	}
+	c.SetContentType("application/json; charset=utf-8")
+	c.SetBody(pretty.Bytes())
 	return nil
 }
 
@@ -862,24 +894,20 @@ func (c *Context) NotModified() error {
 	return nil
 }
 
-// Stream sets up response for streaming
-// Configures response for incremental data writing
+// Stream sets the content type and returns the response body writer
+// Data written is buffered and sent when the handler returns; nothing
+// reaches the client incrementally.
+//
+// Deprecated: Use StreamWriter to stream a body, NDJSON or
+// JSONArrayStream to stream JSON values, or SSE for Server-Sent Events.
 //
 // Parameters:
-//   - contentType: MIME type of streamed data
+//   - contentType: MIME type of the body
 //
 // Returns:
-//   - io.Writer: Stream writer
-//
-// Example:
-//
-//	writer := c.Stream("text/event-stream")
-//	fmt.Fprintf(writer, "data: %s\n\n", event)
+//   - io.Writer: Response body writer
 func (c *Context) Stream(contentType string) io.Writer {
 	c.SetContentType(contentType)
-	c.RequestCtx.SetBodyStreamWriter(func(w *bufio.Writer) {
-		// Writer will be used by caller
-	})
 	return c.RequestCtx.Response.BodyWriter()
 }
 
@@ -0,0 +1,208 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ==================== Route Conflicts ====================
+
+// RouteError describes a route that could not be registered
+// Returned by App.ValidateRoutes and Router.Validate
+//
+// Error Causes:
+//   - Malformed pattern (Existing is nil)
+//   - Same method and pattern registered twice
+//   - Different parameter names at the same position (:id vs :user_id)
+//   - Static segment and wildcard at the same position (/users/new vs /users/:id)
+//   - Catch-all next to a route ending at the same position
+type RouteError struct {
+	// Method and Pattern identify the rejected route
+	Method  string
+	Pattern string
+
+	// Source is the file:line where the rejected route was registered
+	Source string
+
+	// Existing is the registered route it conflicts with
+	// nil when the pattern itself is invalid
+	Existing *Route
+
+	// Reason describes the problem
+	Reason string
+}
+
+// Error implements the error interface
+func (e *RouteError) Error() string {
+	route := e.Method + " " + e.Pattern
+	if e.Source != "" {
+		route += " (" + e.Source + ")"
+	}
+
+	if e.Existing == nil {
+		return fmt.Sprintf("blaze: invalid route %s: %s", route, e.Reason)
+	}
+
+	existing := e.Existing.Method + " " + e.Existing.Pattern
+	if e.Existing.Source != "" {
+		existing += " (" + e.Existing.Source + ")"
+	}
+	return fmt.Sprintf("blaze: route %s conflicts with %s: %s", route, existing, e.Reason)
+}
+
+// treeEntry records a path inserted into the tree for conflict checks
+type treeEntry struct {
+	method string
+	path   string
+	route  *Route
+}
+
+// findConflict checks a tree path against every path in the table
+// Returns the conflicting route and the reason, or nil
+func (t *routeTable) findConflict(method, path string) (*Route, string) {
+	for _, entry := range t.entries {
+		reason, conflict := pathConflict(path, entry.path)
+		if !conflict {
+			continue
+		}
+		if reason == "" {
+			if entry.method != method {
+				continue
+			}
+			reason = "route already registered"
+		}
+		return entry.route, reason
+	}
+	return nil, ""
+}
+
+// pathConflict compares two normalized tree paths
+// Returns conflict with an empty reason when the paths are identical,
+// which only conflicts for the same method
+func pathConflict(a, b string) (string, bool) {
+	for i := 0; ; i++ {
+		if i == len(a) || i == len(b) {
+			if len(a) == len(b) {
+				return "", true
+			}
+
+			// A catch-all cannot share its segment root with another route
+			rest := a[i:]
+			if rest == "" {
+				rest = b[i:]
+			}
+			if rest[0] == '*' {
+				return fmt.Sprintf("catch-all %q conflicts with a route ending at the same position", segmentAt(rest, 0)), true
+			}
+			return "", false
+		}
+
+		ca, cb := a[i], b[i]
+		wa := ca == ':' || ca == '*'
+		wb := cb == ':' || cb == '*'
+
+		switch {
+		case wa && wb:
+			ta, tb := segmentAt(a, i), segmentAt(b, i)
+			if ta != tb {
+				return fmt.Sprintf("wildcard %q conflicts with %q at the same position", ta, tb), true
+			}
+
+			// Everything after a catch-all is matched as a tail,
+			// so only identical tails conflict
+			if ca == '*' {
+				if a[i:] == b[i:] {
+					return "", true
+				}
+				return "", false
+			}
+			i += len(ta) - 1
+
+		case wa || wb:
+			static, wildcard := b, a
+			if wb {
+				static, wildcard = a, b
+			}
+			start := strings.LastIndexByte(static[:i], '/') + 1
+			return fmt.Sprintf("static segment %q conflicts with wildcard %q",
+				segmentAt(static, start), segmentAt(wildcard, i)), true
+
+		case ca != cb:
+			return "", false
+		}
+	}
+}
+
+// segmentAt returns path from i up to the next '/'
+func segmentAt(path string, i int) string {
+	if j := strings.IndexByte(path[i:], '/'); j >= 0 {
+		return path[i : i+j]
+	}
+	return path[i:]
+}
+
+// Validate reports every route that could not be registered
+//
+// Returns:
+//   - error: Joined *RouteError values, or nil
+func (r *Router) Validate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return errors.Join(r.errors...)
+}
+
+// ValidateRoutes reports every route that could not be registered
+// Covers the app's routes, all virtual hosts and mounted apps. Registration
+// never panics on malformed or conflicting routes; each rejected route is
+// logged when it is registered and collected for ValidateRoutes.
+// ListenAndServe calls ValidateRoutes before starting the server, and
+// Handler and HTTPHandler panic with its error on their first call; call it
+// yourself to handle the error. Mounted apps are validated with their
+// parent.
+//
+// Returns:
+//   - error: Joined *RouteError values, or nil
+//
+// Example:
+//
+//	app.GET("/users/:id", getUser)
+//	app.GET("/users/:user_id/posts", listPosts)
+//
+//	if err := app.ValidateRoutes(); err != nil {
+//	    log.Fatal(err)
+//	    // blaze: route GET /users/:user_id/posts (main.go:12) conflicts with
+//	    // GET /users/:id (main.go:11): wildcard ":user_id" conflicts with ":id" at the same position
+//	}
+func (a *App) ValidateRoutes() error {
+	errs := []error{a.router.Validate()}
+	for _, vh := range a.hosts {
+		errs = append(errs, vh.router.Validate())
+	}
+	for _, m := range a.mounts {
+		errs = append(errs, m.app.ValidateRoutes())
+	}
+	return errors.Join(errs...)
+}
+
+// blazePackage is the function name prefix of this package
+var blazePackage = reflect.TypeOf(Route{}).PkgPath() + "."
+
+// callerSource returns the file:line of the first caller outside this package
+func callerSource() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, blazePackage) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
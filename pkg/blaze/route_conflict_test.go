@@ -0,0 +1,165 @@
+package blaze_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+// captureLog redirects the standard logger until the test ends
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+	return &buf
+}
+
+// fatalRecorder is a TB whose Fatalf stops the caller without failing
+// the test, so harness failures can be checked
+type fatalRecorder struct {
+	*testing.T
+	fatal string
+}
+
+// errFatal unwinds the caller of Fatalf
+var errFatal = errors.New("fatal")
+
+func (r *fatalRecorder) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+	panic(errFatal)
+}
+
+func TestRouteConflicts(t *testing.T) {
+	logs := captureLog(t)
+
+	app := blaze.New()
+	app.GET("/users/:id", text("user"))
+	app.GET("/users/new", text("new"))
+	app.GET("/users/:user_id/posts", text("posts"))
+	app.GET("/users/:id", text("again"))
+	app.POST("/users/:id", text("post"))
+	app.GET("/files/", text("files"))
+	app.GET("/files/*path", text("file"))
+	app.GET("/bad/:", text("bad"))
+
+	rejected := []string{
+		"GET /users/new",
+		"GET /users/:user_id/posts",
+		"GET /users/:id (",
+		"GET /files/*path",
+		"GET /bad/:",
+	}
+
+	// Each rejection is logged as soon as it is registered
+	for _, route := range rejected {
+		if !strings.Contains(logs.String(), "route "+route) && !strings.Contains(logs.String(), "invalid route "+route) {
+			t.Errorf("rejection of %s was not logged:\n%s", route, logs)
+		}
+	}
+	if !strings.Contains(logs.String(), "route_conflict_test.go:") {
+		t.Errorf("log does not name the registering file:\n%s", logs)
+	}
+
+	err := app.ValidateRoutes()
+	if err == nil {
+		t.Fatal("ValidateRoutes returned nil")
+	}
+
+	var routeErr *blaze.RouteError
+	if !errors.As(err, &routeErr) {
+		t.Fatalf("%v is not a *RouteError", err)
+	}
+	if routeErr.Method != "GET" || routeErr.Pattern != "/users/new" || routeErr.Existing == nil || routeErr.Existing.Pattern != "/users/:id" {
+		t.Errorf("first error = %+v", routeErr)
+	}
+	if n := strings.Count(err.Error(), "blaze: "); n != len(rejected) {
+		t.Errorf("ValidateRoutes reported %d errors, want %d:\n%v", n, len(rejected), err)
+	}
+
+	// Handing the app out as a handler fails like ListenAndServe
+	for name, handler := range map[string]func(){
+		"Handler":     func() { app.Handler() },
+		"HTTPHandler": func() { app.HTTPHandler() },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || r.(error).Error() != err.Error() {
+					t.Errorf("%s panicked with %v, want the ValidateRoutes error", name, r)
+				}
+			}()
+			handler()
+		}()
+	}
+}
+
+func TestRouteConflictsKeepFirstRoute(t *testing.T) {
+	captureLog(t)
+
+	router := blaze.NewRouter()
+	router.AddRoute("GET", "/users/:id", text("user"))
+	router.AddRoute("GET", "/users/new", text("new"))
+	router.AddRoute("POST", "/users/:id", text("post"))
+
+	if router.Validate() == nil {
+		t.Fatal("Validate returned nil")
+	}
+
+	// The routes registered first keep matching
+	for _, tt := range []struct{ method, path, pattern string }{
+		{"GET", "/users/new", "/users/:id"},
+		{"GET", "/users/1", "/users/:id"},
+		{"POST", "/users/1", "/users/:id"},
+	} {
+		route, _, ok := router.FindRoute(tt.method, tt.path)
+		if !ok || route.Method != tt.method || route.Pattern != tt.pattern {
+			t.Errorf("%s %s matched %+v", tt.method, tt.path, route)
+		}
+	}
+}
+
+func TestRouteConflictsFailHarness(t *testing.T) {
+	captureLog(t)
+
+	app := blaze.New()
+	app.GET("/users/:id", text("user"))
+	app.GET("/users/new", text("new"))
+
+	rec := &fatalRecorder{T: t}
+	func() {
+		defer func() {
+			if r := recover(); r != nil && r != errFatal {
+				panic(r)
+			}
+		}()
+		blazetest.New(rec, app)
+	}()
+
+	if !strings.Contains(rec.fatal, "GET /users/new") {
+		t.Errorf("blazetest.New failure = %q, want the rejected route", rec.fatal)
+	}
+}
+
+func TestRouteConflictsInMounts(t *testing.T) {
+	captureLog(t)
+
+	sub := blaze.New()
+	sub.GET("/users/:id", text("user"))
+	sub.GET("/users/new", text("new"))
+
+	app := blaze.New()
+	app.Mount("/app", sub)
+
+	err := app.ValidateRoutes()
+	if err == nil || !strings.Contains(err.Error(), "/users/new") {
+		t.Errorf("ValidateRoutes = %v, want the mounted app's conflict", err)
+	}
+}
@@ -0,0 +1,486 @@
+package blaze
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ==================== Route Pattern Syntax ====================
+//
+// Route patterns are parsed once at registration and normalized to the
+// ":name" / "*name" form stored in the radix tree.
+//
+// Supported Syntax:
+//   - Named parameter: /users/:id
+//   - Inline constraint: /posts/:id<int>, /users/:name<alpha>, /f/:n<regex:[a-z]+>
+//   - Brace parameter: /items/{id}, /items/{id:[0-9]+}
+//   - Optional trailing parameters: /archive/:year?/:month?
+//   - Composite segments: /files/:name.:ext, /img/thumb-:size.png
+//   - Catch-all: /static/*filepath (unnamed "*" is named "wildcard")
+//   - Mid-path catch-all: /repos/*path/edit
+//
+// Parameter names consist of letters, digits and underscores; any other
+// character ends the name and starts a literal (e.g. "." in :name.:ext).
+
+// segmentPart is a literal, parameter or catch-all inside a path segment
+type segmentPart struct {
+	// text is the literal text, or the parameter name
+	text string
+
+	// param is true for ":name" parts
+	param bool
+
+	// catchAll is true for "*name" parts
+	catchAll bool
+}
+
+// routeSegment is one "/"-separated segment of a parsed pattern
+type routeSegment struct {
+	parts []segmentPart
+
+	// optional segments may be omitted from the end of the path
+	optional bool
+}
+
+// parsedPattern is the result of parsing a route pattern
+type parsedPattern struct {
+	segments    []routeSegment
+	params      []string
+	constraints map[string]*RouteConstraint
+}
+
+// parseRoutePattern parses a route pattern with inline syntax
+//
+// Returns an error for malformed patterns such as unnamed or duplicate
+// parameters, unknown constraint types, adjacent parameters without a
+// separator, more than one catch-all, or required segments after
+// optional ones.
+func parseRoutePattern(pattern string) (*parsedPattern, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, fmt.Errorf("pattern must begin with '/'")
+	}
+
+	p := &parsedPattern{constraints: make(map[string]*RouteConstraint)}
+	seen := make(map[string]bool)
+	catchAlls := 0
+
+	segment := routeSegment{}
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segment.parts = append(segment.parts, segmentPart{text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	addParam := func(name string, catchAll bool) error {
+		if seen[name] {
+			return fmt.Errorf("duplicate parameter %q", name)
+		}
+		seen[name] = true
+		p.params = append(p.params, name)
+
+		if n := len(segment.parts); n > 0 && !catchAll {
+			if prev := segment.parts[n-1]; prev.param || prev.catchAll {
+				return fmt.Errorf("parameters %q and %q must be separated by a literal", prev.text, name)
+			}
+		}
+		segment.parts = append(segment.parts, segmentPart{text: name, param: !catchAll, catchAll: catchAll})
+		return nil
+	}
+
+	endSegment := func() error {
+		flushLiteral()
+		for _, part := range segment.parts {
+			if part.catchAll && len(segment.parts) > 1 {
+				return fmt.Errorf("catch-all %q must be a whole path segment", part.text)
+			}
+		}
+		if segment.optional && (len(segment.parts) != 1 || !segment.parts[0].param) {
+			return fmt.Errorf("only a whole-segment parameter can be optional")
+		}
+		if n := len(p.segments); n > 0 && p.segments[n-1].optional && !segment.optional {
+			return fmt.Errorf("required segment after optional parameter")
+		}
+		p.segments = append(p.segments, segment)
+		segment = routeSegment{}
+		return nil
+	}
+
+	for i := 1; i < len(pattern); {
+		c := pattern[i]
+
+		switch c {
+		case '/':
+			if err := endSegment(); err != nil {
+				return nil, err
+			}
+			i++
+
+		case ':':
+			flushLiteral()
+			name, next := scanParamName(pattern, i+1)
+			if name == "" {
+				return nil, fmt.Errorf("parameter at offset %d has no name", i)
+			}
+			if err := addParam(name, false); err != nil {
+				return nil, err
+			}
+			i = next
+
+			// Inline constraint: :id<int>
+			if i < len(pattern) && pattern[i] == '<' {
+				end := matchingBracket(pattern, i, '<', '>')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated constraint for parameter %q", name)
+				}
+				constraint, err := inlineConstraint(name, pattern[i+1:end])
+				if err != nil {
+					return nil, err
+				}
+				p.constraints[name] = constraint
+				i = end + 1
+			}
+
+			if i < len(pattern) && pattern[i] == '?' {
+				segment.optional = true
+				i++
+			}
+
+		case '{':
+			flushLiteral()
+			end := matchingBracket(pattern, i, '{', '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '{' at offset %d", i)
+			}
+			body := pattern[i+1 : end]
+
+			name, expr := body, ""
+			if j := strings.IndexByte(body, ':'); j >= 0 {
+				name, expr = body[:j], body[j+1:]
+			}
+			if n, _ := scanParamName(name, 0); n == "" || n != name {
+				return nil, fmt.Errorf("invalid parameter name %q", name)
+			}
+			if err := addParam(name, false); err != nil {
+				return nil, err
+			}
+			if expr != "" {
+				constraint, err := regexConstraint(name, expr)
+				if err != nil {
+					return nil, err
+				}
+				p.constraints[name] = constraint
+			}
+			i = end + 1
+
+			if i < len(pattern) && pattern[i] == '?' {
+				segment.optional = true
+				i++
+			}
+
+		case '*':
+			flushLiteral()
+			catchAlls++
+			if catchAlls > 1 {
+				return nil, fmt.Errorf("only one catch-all is allowed per pattern")
+			}
+			name, next := scanParamName(pattern, i+1)
+			if name == "" {
+				name = "wildcard"
+			}
+			if err := addParam(name, true); err != nil {
+				return nil, err
+			}
+			i = next
+
+		default:
+			literal.WriteByte(c)
+			i++
+		}
+	}
+
+	if err := endSegment(); err != nil {
+		return nil, err
+	}
+
+	for _, seg := range p.segments {
+		if seg.optional {
+			for _, other := range p.segments {
+				for _, part := range other.parts {
+					if part.catchAll {
+						return nil, fmt.Errorf("optional parameters cannot be combined with a catch-all")
+					}
+				}
+			}
+			break
+		}
+	}
+
+	return p, nil
+}
+
+// expansions returns the tree paths for the pattern, one per optional suffix
+// "/archive/:year?/:month?" -> "/archive", "/archive/:year", "/archive/:year/:month"
+func (p *parsedPattern) expansions() []string {
+	required := len(p.segments)
+	for required > 0 && p.segments[required-1].optional {
+		required--
+	}
+
+	paths := make([]string, 0, len(p.segments)-required+1)
+	for n := required; n <= len(p.segments); n++ {
+		paths = append(paths, joinSegments(p.segments[:n]))
+	}
+	return paths
+}
+
+// joinSegments renders segments in normalized ":name" / "*name" form
+func joinSegments(segments []routeSegment) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		b.WriteByte('/')
+		for _, part := range seg.parts {
+			switch {
+			case part.param:
+				b.WriteByte(':')
+			case part.catchAll:
+				b.WriteByte('*')
+			}
+			b.WriteString(part.text)
+		}
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+// scanParamName reads a parameter name starting at offset i
+func scanParamName(s string, i int) (string, int) {
+	start := i
+	for i < len(s) && isParamNameChar(s[i]) {
+		i++
+	}
+	return s[start:i], i
+}
+
+// isParamNameChar reports whether c may appear in a parameter name
+func isParamNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// matchingBracket returns the index of the bracket closing the one at i
+func matchingBracket(s string, i int, open, close byte) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// inlineConstraint resolves the text inside :name<...>
+// Accepts a registered type name or "regex:<expr>"
+func inlineConstraint(param, spec string) (*RouteConstraint, error) {
+	if expr, ok := strings.CutPrefix(spec, "regex:"); ok {
+		return regexConstraint(param, expr)
+	}
+
+	constraint, ok := lookupConstraintType(param, spec)
+	if !ok {
+		return nil, fmt.Errorf("unknown constraint type %q for parameter %q", spec, param)
+	}
+	return constraint, nil
+}
+
+// regexConstraint compiles an inline regex anchored to the whole value
+func regexConstraint(param, expr string) (*RouteConstraint, error) {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex for parameter %q: %w", param, err)
+	}
+	return &RouteConstraint{Name: param, Type: RegexConstraint, Pattern: re}, nil
+}
+
+// ==================== Segment Matchers ====================
+
+// segmentMatcher extracts parameters from a composite segment or from the
+// remainder of a path after a mid-path catch-all
+type segmentMatcher struct {
+	regex *regexp.Regexp
+	names []string
+}
+
+// compileSegmentMatcher builds a matcher for a normalized fragment such as
+// ":name.:ext" or "/v/:version". When catchAll is set the fragment is
+// preceded by a catch-all capturing one or more segments.
+func compileSegmentMatcher(fragment, catchAll string) (*segmentMatcher, error) {
+	m := &segmentMatcher{}
+
+	var b strings.Builder
+	b.WriteByte('^')
+	if catchAll != "" {
+		b.WriteString("(/.+)")
+		m.names = append(m.names, catchAll)
+	}
+
+	for i := 0; i < len(fragment); {
+		switch fragment[i] {
+		case ':':
+			name, next := scanParamName(fragment, i+1)
+			if name == "" {
+				return nil, fmt.Errorf("parameter in %q has no name", fragment)
+			}
+			b.WriteString("([^/]+)")
+			m.names = append(m.names, name)
+			i = next
+		case '*':
+			return nil, fmt.Errorf("only one catch-all is allowed per pattern")
+		default:
+			j := i
+			for j < len(fragment) && fragment[j] != ':' && fragment[j] != '*' {
+				j++
+			}
+			b.WriteString(regexp.QuoteMeta(fragment[i:j]))
+			i = j
+		}
+	}
+	b.WriteByte('$')
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, err
+	}
+	m.regex = re
+	return m, nil
+}
+
+// capture stores the values of a regex match on the case-folded path in
+// params, slicing them from orig so their casing is preserved
+func (m *segmentMatcher) capture(loc []int, orig string, params *paramList) {
+	for i, name := range m.names {
+		params.add(name, orig[loc[2*i+2]:loc[2*i+3]])
+	}
+}
+
+// isCompositeParam reports whether a param wildcard (":name...") contains
+// more than a single parameter name
+func isCompositeParam(wildcard string) bool {
+	_, next := scanParamName(wildcard, 1)
+	return next < len(wildcard)
+}
+
+// ==================== Constraint Registry ====================
+
+var (
+	constraintTypesMu sync.RWMutex
+	constraintTypes   = map[string]*regexp.Regexp{
+		string(IntConstraint):   regexp.MustCompile(`^\d+$`),
+		string(UUIDConstraint):  regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+		string(AlphaConstraint): regexp.MustCompile(`^[a-zA-Z]+$`),
+		string(DateConstraint):  regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`),
+		string(SlugConstraint):  regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`),
+		string(ULIDConstraint):  regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`),
+	}
+)
+
+// RegisterConstraintType registers a named constraint type
+// Registered types can be used inline (/:code<iso>) and with WithTypedConstraint
+//
+// Built-in types: int, uuid, alpha, date, slug, ulid
+// Registering an existing name replaces it. The pattern always has to
+// match the whole value: it is wrapped as ^(?:pattern)$, so anchors in the
+// pattern are harmless.
+//
+// Parameters:
+//   - name: Constraint type name
+//   - pattern: Regex the parameter value must match
+//
+// Returns:
+//   - error: Empty name or invalid pattern; nothing is registered
+//
+// Example:
+//
+//	if err := blaze.RegisterConstraintType("iso", `[A-Z]{2}`); err != nil {
+//	    log.Fatal(err)
+//	}
+//	app.GET("/countries/:code<iso>", handler)
+func RegisterConstraintType(name, pattern string) error {
+	if name == "" {
+		return errors.New("blaze: constraint type name must not be empty")
+	}
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("blaze: constraint type %q: %w", name, err)
+	}
+
+	constraintTypesMu.Lock()
+	constraintTypes[name] = re
+	constraintTypesMu.Unlock()
+	return nil
+}
+
+// lookupConstraintType builds a constraint for param from a registered type
+func lookupConstraintType(param, name string) (*RouteConstraint, bool) {
+	constraintTypesMu.RLock()
+	re, ok := constraintTypes[name]
+	constraintTypesMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return &RouteConstraint{Name: param, Type: ConstraintType(name), Pattern: re}, true
+}
+
+// WithTypedConstraint adds a constraint from the constraint type registry
+// An unknown type rejects the route at registration
+//
+// Parameters:
+//   - param: Parameter name
+//   - typeName: Registered constraint type (int, uuid, alpha, date, slug, ulid, ...)
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/posts/:slug", handler, blaze.WithTypedConstraint("slug", "slug"))
+func WithTypedConstraint(param, typeName string) RouteOption {
+	constraint, ok := lookupConstraintType(param, typeName)
+	return func(r *Route) {
+		if !ok {
+			r.setOptionErr(fmt.Errorf("unknown constraint type %q for parameter %q", typeName, param))
+			return
+		}
+		r.Constraints[param] = constraint
+	}
+}
+
+// WithAlphaConstraint adds an alphabetic constraint
+// Validates that parameter contains only ASCII letters
+//
+// Parameters:
+//   - param: Parameter name
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/categories/:name", handler, blaze.WithAlphaConstraint("name"))
+func WithAlphaConstraint(param string) RouteOption {
+	return WithTypedConstraint(param, string(AlphaConstraint))
+}
@@ -0,0 +1,291 @@
+package blaze
+
+import (
+	"sync"
+	"time"
+)
+
+// ==================== Route Policies ====================
+
+// RoutePolicy describes declarative per-route behavior
+// Policies are declared next to the route with RouteOptions and compiled
+// into the route's middleware chain once, at registration. Each route gets
+// its own rate limiter and cache store, so limits are never shared between
+// routes, even when the policy is declared on a group. Their cleanup
+// goroutines stop when the route is removed (RemoveRoute, ReplaceRoutes).
+//
+// Policy Order (outermost first):
+//  1. CORS - answers preflight requests before any other policy
+//  2. Rate limit - rejects with 429 before the body is inspected
+//  3. Body limit - rejects with 413
+//  4. Timeout - bounds route middleware, group middleware and the handler
+//  5. Cache - wraps only the handler, inside group and route middleware
+//     so authentication still runs for cached responses
+//
+// Example:
+//
+//	app.POST("/upload", uploadHandler,
+//	    blaze.WithBodyLimit(50*1024*1024),
+//	    blaze.WithTimeout(30*time.Second),
+//	)
+//
+//	info := app.GetRouteInfo()["POST:/upload"]
+//	log.Printf("timeout: %s", info.Policy.Timeout)
+type RoutePolicy struct {
+	// Timeout bounds the request duration (0 = no timeout)
+	// Requests exceeding it get 408 Request Timeout
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// BodyLimit is the maximum request body size in bytes (0 = no limit)
+	// Requests exceeding it get 413 Payload Too Large
+	BodyLimit int64 `json:"body_limit,omitempty"`
+
+	// StreamingBody passes the request body to the handler unread, through
+	// Context.BodyStream (requires Config.StreamRequestBody)
+	StreamingBody bool `json:"streaming_body,omitempty"`
+
+	// RateLimit limits requests per client IP (nil = no limit)
+	// Requests exceeding it get 429 Too Many Requests
+	RateLimit *RateLimitOptions `json:"rate_limit,omitempty"`
+
+	// CORS configures cross-origin access (nil = no CORS headers)
+	// Also applied to automatic OPTIONS preflight responses for the route
+	CORS *CORSOptions `json:"cors,omitempty"`
+
+	// CacheTTL is how long GET and HEAD responses are cached (0 = no cache)
+	CacheTTL time.Duration `json:"cache_ttl,omitempty"`
+
+	// cache is the cache configuration passed to WithCache
+	cache *CacheOptions
+}
+
+// routePolicies is the middleware built from a RoutePolicy
+type routePolicies struct {
+	// request is the CORS, rate limit, body limit and timeout middleware,
+	// outermost first
+	request []MiddlewareFunc
+
+	// cors answers preflight requests, nil without a CORS policy
+	cors MiddlewareFunc
+
+	// cache wraps the handler, nil without a cache policy
+	cache MiddlewareFunc
+
+	// stop ends the cleanup goroutines of the rate limiter and cache
+	// store; nil when there are none
+	stop func()
+}
+
+// close stops the policies' background work once the route is dropped
+// In-flight requests can still use the middleware.
+func (p routePolicies) close() {
+	if p.stop != nil {
+		p.stop()
+	}
+}
+
+// runCleanup calls cleanup every interval until done is closed
+func runCleanup(interval time.Duration, done <-chan struct{}, cleanup func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			cleanup()
+		case <-done:
+			return
+		}
+	}
+}
+
+// build creates the middleware enforcing the policy
+func (p RoutePolicy) build() routePolicies {
+	var policies routePolicies
+
+	// Closed by stop; ends the cleanup goroutines started below
+	done := make(chan struct{})
+	background := false
+
+	if p.CORS != nil {
+		policies.cors = CORS(*p.CORS)
+		policies.request = append(policies.request, policies.cors)
+	}
+	if p.RateLimit != nil {
+		limiter := NewRateLimiter(*p.RateLimit)
+		go runCleanup(p.RateLimit.Window*2, done, limiter.cleanup)
+		background = true
+		policies.request = append(policies.request, limiter.middleware())
+	}
+	if p.BodyLimit > 0 {
+		policies.request = append(policies.request, BodyLimit(p.BodyLimit))
+	}
+	if p.Timeout > 0 {
+		policies.request = append(policies.request, GracefulTimeout(p.Timeout))
+	}
+	if p.CacheTTL > 0 {
+		opts := DefaultCacheOptions()
+		if p.cache != nil {
+			copied := *p.cache
+			opts = &copied
+		}
+		opts.DefaultTTL = p.CacheTTL
+
+		// Cache fills in the store and interval; the cleanup is
+		// started here so it can be stopped with the route
+		cleanup := opts.EnableBackgroundCleanup
+		opts.EnableBackgroundCleanup = false
+		policies.cache = Cache(opts)
+		if cleanup {
+			store := opts.Store
+			go runCleanup(opts.CleanupInterval, done, func() { store.Cleanup() })
+			background = true
+		}
+	}
+
+	if background {
+		var once sync.Once
+		policies.stop = func() {
+			once.Do(func() { close(done) })
+		}
+	}
+	return policies
+}
+
+// info returns a copy of the policy for RouteInfo, nil when it is empty
+func (p RoutePolicy) info() *RoutePolicy {
+	if p.Timeout <= 0 && p.BodyLimit <= 0 && !p.StreamingBody && p.RateLimit == nil && p.CORS == nil && p.CacheTTL <= 0 {
+		return nil
+	}
+	return &p
+}
+
+// WithTimeout limits how long the route may take to respond
+// The client receives 408 Request Timeout at the deadline, or 503 during
+// shutdown. Like GracefulTimeout, the handler is not interrupted; pass
+// c.WithTimeout contexts to slow calls so they stop as well.
+//
+// Parameters:
+//   - timeout: Maximum request duration
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/reports/:id", buildReport, blaze.WithTimeout(10*time.Second))
+func WithTimeout(timeout time.Duration) RouteOption {
+	return func(r *Route) {
+		r.Policy.Timeout = timeout
+	}
+}
+
+// WithBodyLimit limits the request body size of the route
+// Bodies larger than Config.MaxRequestBodySize are rejected by the server
+// before routing, so the route limit can only lower the server limit.
+// Routes with WithStreamingBody are the exception: their limit replaces
+// the server limit and is enforced while the body is read.
+//
+// Parameters:
+//   - maxSize: Maximum body size in bytes
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.POST("/webhooks/github", githubWebhook, blaze.WithBodyLimit(64*1024))
+func WithBodyLimit(maxSize int64) RouteOption {
+	return func(r *Route) {
+		r.Policy.BodyLimit = maxSize
+	}
+}
+
+// WithStreamingBody passes the request body to the handler unread
+// With Config.StreamRequestBody enabled, the handler reads the body from
+// the connection through Context.BodyStream as it arrives, so large
+// uploads can be piped to disk or processed without being buffered. The
+// body limit is WithBodyLimit when set, Config.MaxRequestBodySize
+// otherwise, and is enforced while the body is read. Without
+// Config.StreamRequestBody, BodyStream reads the buffered body.
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.POST("/backups", restoreBackup,
+//	    blaze.WithStreamingBody(),
+//	    blaze.WithBodyLimit(20<<30), // 20GB
+//	)
+func WithStreamingBody() RouteOption {
+	return func(r *Route) {
+		r.Policy.StreamingBody = true
+	}
+}
+
+// WithRateLimit limits requests per client IP on the route
+// Every route gets its own limiter, dropped with the route.
+//
+// Parameters:
+//   - opts: Requests allowed per window
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.POST("/login", login, blaze.WithRateLimit(blaze.RateLimitOptions{
+//	    Requests: 5,
+//	    Window:   time.Minute,
+//	}))
+func WithRateLimit(opts RateLimitOptions) RouteOption {
+	return func(r *Route) {
+		r.Policy.RateLimit = &opts
+	}
+}
+
+// WithCORS applies a CORS policy to the route
+// Preflight OPTIONS requests for the route are answered with the same
+// policy when the router handles OPTIONS automatically.
+//
+// Parameters:
+//   - opts: CORS configuration
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	public := blaze.DefaultCORSOptions()
+//	app.GET("/api/public/stats", stats, blaze.WithCORS(public))
+func WithCORS(opts CORSOptions) RouteOption {
+	return func(r *Route) {
+		r.Policy.CORS = &opts
+	}
+}
+
+// WithCache caches GET and HEAD responses of the route
+// Uses DefaultCacheOptions unless options are given; the options are copied,
+// so one CacheOptions value can be shared by routes with different TTLs.
+// Every route gets its own store unless the options set Store.
+//
+// Parameters:
+//   - ttl: How long responses are cached
+//   - opts: Optional cache configuration
+//
+// Returns:
+//   - RouteOption: Configuration function
+//
+// Example:
+//
+//	app.GET("/products", listProducts, blaze.WithCache(time.Minute))
+func WithCache(ttl time.Duration, opts ...*CacheOptions) RouteOption {
+	return func(r *Route) {
+		r.Policy.CacheTTL = ttl
+		r.Policy.cache = nil
+		if len(opts) > 0 {
+			r.Policy.cache = opts[0]
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package blaze_test
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+func TestTimeoutPolicy(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	app := blaze.New()
+	app.GET("/slow", func(c *blaze.Context) error {
+		<-release
+		return c.Text("late")
+	}, blaze.WithTimeout(20*time.Millisecond))
+	app.GET("/fast", text("fast"), blaze.WithTimeout(time.Second))
+
+	srv := blazetest.New(t, app)
+	srv.Get("/slow").Do().AssertStatus(http.StatusRequestTimeout)
+	srv.Get("/fast").Do().AssertStatus(http.StatusOK).AssertBody("fast")
+
+	info := app.GetRouteInfo()["GET:/slow"]
+	if info == nil || info.Policy == nil || info.Policy.Timeout != 20*time.Millisecond {
+		t.Errorf("route info policy = %+v", info)
+	}
+}
+
+func TestBodyLimitPolicy(t *testing.T) {
+	app := blaze.New()
+	app.POST("/small", func(c *blaze.Context) error {
+		return c.Text(string(c.PostBody()))
+	}, blaze.WithBodyLimit(8))
+	app.POST("/large", func(c *blaze.Context) error {
+		return c.Text(fmt.Sprint(len(c.PostBody())))
+	})
+
+	srv := blazetest.New(t, app)
+	srv.Post("/small").WithBody("text/plain", []byte("tiny")).Do().AssertBody("tiny")
+	srv.Post("/small").WithBody("text/plain", []byte(strings.Repeat("x", 16))).Do().
+		AssertStatus(http.StatusRequestEntityTooLarge)
+	srv.Post("/large").WithBody("text/plain", []byte(strings.Repeat("x", 16))).Do().AssertBody("16")
+}
+
+func TestRateLimitPolicy(t *testing.T) {
+	limit := blaze.RateLimitOptions{Requests: 2, Window: time.Minute}
+
+	app := blaze.New()
+	app.POST("/login", text("ok"), blaze.WithRateLimit(limit))
+	api := app.Group("/api").WithOptions(blaze.WithRateLimit(limit))
+	api.GET("/a", text("a"))
+	api.GET("/b", text("b"))
+
+	srv := blazetest.New(t, app)
+
+	for _, path := range []string{"/api/a", "/api/b"} {
+		for i := 0; i < 2; i++ {
+			srv.Get(path).Do().AssertStatus(http.StatusOK)
+		}
+	}
+	// Each route of the group has its own budget
+	srv.Get("/api/a").Do().AssertStatus(http.StatusTooManyRequests)
+	srv.Get("/api/b").Do().AssertStatus(http.StatusTooManyRequests)
+
+	srv.Post("/login").Do().AssertStatus(http.StatusOK)
+	srv.Post("/login").Do().AssertStatus(http.StatusOK)
+	srv.Post("/login").Do().
+		AssertStatus(http.StatusTooManyRequests).
+		AssertJSONPath("error", "Too Many Requests")
+}
+
+func TestCORSPolicy(t *testing.T) {
+	cors := blaze.DefaultCORSOptions()
+	cors.AllowedOrigins = []string{"https://app.example.com"}
+
+	app := blaze.New()
+	app.GET("/public", text("public"), blaze.WithCORS(cors))
+	app.GET("/private", text("private"))
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/public").WithHeader("Origin", "https://app.example.com").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeader("Access-Control-Allow-Origin", "https://app.example.com")
+	srv.Get("/public").WithHeader("Origin", "https://evil.example.com").Do().
+		AssertHeaderMissing("Access-Control-Allow-Origin")
+	srv.Get("/private").WithHeader("Origin", "https://app.example.com").Do().
+		AssertHeaderMissing("Access-Control-Allow-Origin")
+
+	// Preflight requests use the route's policy
+	srv.Options("/public").
+		WithHeader("Origin", "https://app.example.com").
+		WithHeader("Access-Control-Request-Method", "GET").
+		Do().
+		AssertHeader("Access-Control-Allow-Origin", "https://app.example.com").
+		AssertHeaderContains("Access-Control-Allow-Methods", "GET")
+	srv.Options("/private").
+		WithHeader("Origin", "https://app.example.com").
+		WithHeader("Access-Control-Request-Method", "GET").
+		Do().
+		AssertHeaderMissing("Access-Control-Allow-Origin")
+}
+
+func TestCachePolicy(t *testing.T) {
+	var calls int
+	counter := func(c *blaze.Context) error {
+		calls++
+		return c.Text(fmt.Sprint(calls))
+	}
+
+	app := blaze.New()
+	app.GET("/cached", counter, blaze.WithCache(time.Minute))
+	app.POST("/cached", counter, blaze.WithCache(time.Minute))
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/cached").Do().AssertBody("1")
+	srv.Get("/cached").Do().AssertBody("1")
+	srv.Post("/cached").Do().AssertBody("2")
+	srv.Post("/cached").Do().AssertBody("3")
+}
+
+func TestPoliciesStopWithRoute(t *testing.T) {
+	policies := []blaze.RouteOption{
+		blaze.WithRateLimit(blaze.RateLimitOptions{Requests: 10, Window: time.Minute}),
+		blaze.WithCache(time.Minute),
+	}
+	goroutines := func() int {
+		runtime.GC()
+		return runtime.NumGoroutine()
+	}
+
+	app := blaze.New()
+	before := goroutines()
+
+	// Every swap replaces the routes and their background cleanup
+	for round := 0; round < 5; round++ {
+		err := app.ReplaceRoutes(func(r *blaze.Router) {
+			for i := 0; i < 10; i++ {
+				path := fmt.Sprintf("/plugins/%d", i)
+				r.RemoveRoute("GET", path)
+				r.AddRoute("GET", path, text("v"), policies...)
+			}
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, func() bool { return goroutines() <= before+20 })
+
+	for i := 0; i < 10; i++ {
+		app.RemoveRoute("GET", fmt.Sprintf("/plugins/%d", i))
+	}
+	waitFor(t, func() bool { return goroutines() <= before })
+
+	// A rejected batch stops what it started
+	captureLog(t)
+	err := app.ReplaceRoutes(func(r *blaze.Router) {
+		r.AddRoute("GET", "/a/:id", text("a"), policies...)
+		r.AddRoute("GET", "/a/:name", text("b"), policies...)
+	})
+	if err == nil {
+		t.Fatal("expected the conflicting batch to be rejected")
+	}
+	waitFor(t, func() bool { return goroutines() <= before })
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
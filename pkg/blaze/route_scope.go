@@ -0,0 +1,139 @@
+package blaze
+
+import (
+	"sort"
+	"strings"
+)
+
+// ==================== Route Scopes ====================
+
+// routeScope holds the handlers a group defines for its path prefix
+// Scopes are stored in the route table and resolved by the longest
+// matching prefix, field by field: a nested group that only sets a not
+// found handler still uses the error handling of its parent group.
+type routeScope struct {
+	// prefix is the group prefix without a trailing slash ("/api/v1")
+	prefix string
+
+	// notFound handles requests under prefix that match no route
+	notFound HandlerFunc
+
+	// methodNotAllowed handles requests under prefix whose path exists
+	// under other methods
+	methodNotAllowed HandlerFunc
+
+	// errors is the recovery and error handling middleware built from
+	// the group's ErrorHandlerConfig
+	errors MiddlewareFunc
+}
+
+// matches reports whether path is prefix or lies below it
+// Parameter segments in the prefix (":id") match any segment and a
+// catch-all ("*path") matches the rest of the path.
+func (s *routeScope) matches(path string, caseSensitive bool) bool {
+	prefix := s.prefix
+	for prefix != "" {
+		if path == "" || path[0] != '/' {
+			return false
+		}
+
+		// Compare one segment, skipping the leading slash
+		prefix, path = prefix[1:], path[1:]
+		want, got := segmentAt(prefix, 0), segmentAt(path, 0)
+
+		switch {
+		case strings.HasPrefix(want, "*"):
+			return true
+		case strings.HasPrefix(want, ":"):
+			if got == "" {
+				return false
+			}
+		case caseSensitive && want != got, !caseSensitive && !strings.EqualFold(want, got):
+			return false
+		}
+
+		prefix, path = prefix[len(want):], path[len(got):]
+	}
+	return true
+}
+
+// scopeHandlers resolves the scoped handlers for path
+// Returns the not found and 405 handlers of the longest matching prefix
+// that sets them, falling back to the given defaults, and the error
+// handling middleware (nil when no scope sets one).
+func (t *routeTable) scopeHandlers(path string, caseSensitive bool, notFound, methodNotAllowed HandlerFunc) (HandlerFunc, HandlerFunc, MiddlewareFunc) {
+	var errors MiddlewareFunc
+	foundNotFound, foundMethodNotAllowed := false, false
+
+	// Scopes are sorted longest prefix first
+	for _, s := range t.scopes {
+		if !s.matches(path, caseSensitive) {
+			continue
+		}
+		if !foundNotFound && s.notFound != nil {
+			notFound, foundNotFound = s.notFound, true
+		}
+		if !foundMethodNotAllowed && s.methodNotAllowed != nil {
+			methodNotAllowed, foundMethodNotAllowed = s.methodNotAllowed, true
+		}
+		if errors == nil && s.errors != nil {
+			errors = s.errors
+		}
+	}
+
+	return notFound, methodNotAllowed, errors
+}
+
+// setScope updates the scope for prefix and publishes a new table
+// Route chains are rebuilt in the new table before it is published, so
+// error handling applies to routes registered before the scope was
+// configured and requests in flight keep the chains they started with.
+func (r *Router) setScope(prefix string, update func(*routeScope)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	t := r.table.Load().clone()
+	t.scopes = make([]*routeScope, 0, len(t.scopes)+1)
+
+	var scope *routeScope
+	for _, s := range r.table.Load().scopes {
+		// Scopes are shared with published tables, so update a copy
+		copied := *s
+		if copied.prefix == prefix {
+			scope = &copied
+		}
+		t.scopes = append(t.scopes, &copied)
+	}
+	if scope == nil {
+		scope = &routeScope{prefix: prefix}
+		t.scopes = append(t.scopes, scope)
+	}
+	update(scope)
+
+	// Longest prefix first
+	sort.SliceStable(t.scopes, func(i, j int) bool {
+		return len(t.scopes[i].prefix) > len(t.scopes[j].prefix)
+	})
+
+	r.recompileLocked(t)
+	r.table.Store(t)
+}
+
+// scopeErrors returns the error handling middleware for a route pattern
+func (t *routeTable) scopeErrors(pattern string, caseSensitive bool) MiddlewareFunc {
+	_, _, errors := t.scopeHandlers(pattern, caseSensitive, nil, nil)
+	return errors
+}
+
+// errorScopeMiddleware builds recovery and error handling middleware
+// Equivalent to App.UseErrorHandler for a single scope.
+func errorScopeMiddleware(config *ErrorHandlerConfig) MiddlewareFunc {
+	recovery := RecoveryMiddleware(config)
+	errors := ErrorHandlerMiddleware(config)
+
+	return func(next HandlerFunc) HandlerFunc {
+		return recovery(errors(next))
+	}
+}
@@ -0,0 +1,244 @@
+package blaze
+
+import (
+	"maps"
+	"slices"
+)
+
+// ==================== Route Table ====================
+
+// routeTable is an immutable snapshot of a router's routes
+// Writers copy the current table, modify the copy and publish it with an
+// atomic store, so a request always sees one consistent table.
+//
+// Copy-on-Write Rules:
+//   - The radix tree is path-copied: only nodes on the insertion path are cloned
+//   - routes, entries and chains are append-only; a published table never
+//     sees elements appended past its own length
+//   - names is copied before a name is added or removed
+//   - Rebuilding chains (new middleware or scopes) fills a new chains slice
+type routeTable struct {
+	// root is the root node of the radix tree
+	root *routeNode
+
+	// routes lists registered routes in registration order
+	routes []*Route
+
+	// names indexes named routes for reverse routing
+	names map[string]*Route
+
+	// entries lists every path inserted into the tree
+	// Used for conflict checks and to rebuild the tree after removals
+	entries []treeEntry
+
+	// scopes holds group handlers for path prefixes, longest prefix first
+	scopes []*routeScope
+
+	// chains holds the compiled handler chain of every route, indexed by
+	// Route.index
+	chains []HandlerFunc
+
+	// wrap is the outer middleware the chains were compiled with
+	// Also applied to handlers that are not routes (404, 405, OPTIONS);
+	// nil when the router has no outer middleware
+	wrap MiddlewareFunc
+}
+
+// clone returns a writable copy of the table
+func (t *routeTable) clone() *routeTable {
+	return &routeTable{
+		root:    t.root.clone(),
+		routes:  t.routes,
+		names:   t.names,
+		entries: t.entries,
+		scopes:  t.scopes,
+		chains:  t.chains,
+		wrap:    t.wrap,
+	}
+}
+
+// setName indexes a named route, copying the name index first
+func (t *routeTable) setName(name string, route *Route) {
+	t.names = maps.Clone(t.names)
+	t.names[name] = route
+}
+
+// clone returns a shallow copy of the node that can be modified without
+// affecting tables sharing the original
+func (n *routeNode) clone() *routeNode {
+	c := *n
+	c.children = slices.Clone(n.children)
+	c.handlers = maps.Clone(n.handlers)
+
+	if n.tails != nil {
+		c.tails = make([]*routeTail, len(n.tails))
+		for i, tail := range n.tails {
+			copied := *tail
+			copied.node = tail.node.clone()
+			c.tails[i] = &copied
+		}
+	}
+
+	return &c
+}
+
+// RemoveRoute removes the route registered for method and pattern
+// The pattern must be the full pattern used at registration, including
+// any group prefix. Safe to call while serving requests; in-flight
+// requests keep the routes they started with.
+//
+// Parameters:
+//   - method: HTTP method
+//   - pattern: Registered route pattern
+//
+// Returns:
+//   - bool: true if a route was removed
+//
+// Example:
+//
+//	router.RemoveRoute("GET", "/beta/search")
+func (r *Router) RemoveRoute(method, pattern string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.table.Load()
+
+	var removed *Route
+	for _, route := range current.routes {
+		if route.Method == method && route.Pattern == pattern {
+			removed = route
+			break
+		}
+	}
+	if removed == nil {
+		return false
+	}
+
+	// Radix trees cannot drop a path in place, so rebuild from the
+	// remaining entries in registration order
+	t := &routeTable{
+		root:   &routeNode{},
+		names:  current.names,
+		scopes: current.scopes,
+		chains: current.chains,
+		wrap:   current.wrap,
+	}
+	for _, route := range current.routes {
+		if route != removed {
+			t.routes = append(t.routes, route)
+		}
+	}
+	for _, entry := range current.entries {
+		if entry.route == removed {
+			continue
+		}
+		if err := t.insert(entry.method, entry.path, entry.route); err != nil {
+			// Every entry was inserted before; keep the current table
+			// rather than publish a partial one
+			return false
+		}
+	}
+
+	if removed.Name != "" && current.names[removed.Name] == removed {
+		t.names = maps.Clone(current.names)
+		delete(t.names, removed.Name)
+	}
+
+	r.table.Store(t)
+
+	// A staged removal only takes effect if the batch is published
+	if r.staging {
+		r.dropped = append(r.dropped, removed)
+	} else {
+		removed.policies.close()
+	}
+	return true
+}
+
+// ReplaceRoutes applies a batch of route changes atomically
+// fn receives a staging router holding the current routes. Changes made
+// through it are published together once fn returns; requests never see
+// a partially applied batch. If any route added by fn is rejected, nothing
+// is published and the errors are returned.
+//
+// fn must only use the router it is given; calling methods on the
+// original router from inside fn deadlocks.
+//
+// Parameters:
+//   - fn: Function adding and removing routes on the staging router
+//
+// Returns:
+//   - error: Joined *RouteError values for rejected routes, or nil
+//
+// Example:
+//
+//	err := router.ReplaceRoutes(func(r *blaze.Router) {
+//	    r.RemoveRoute("GET", "/plugins/search")
+//	    r.AddRoute("GET", "/plugins/search", searchV2)
+//	})
+func (r *Router) ReplaceRoutes(fn func(*Router)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	staging := &Router{config: r.config, outer: r.outer, nameOwner: r.nameOwner, staging: true}
+	staging.table.Store(r.table.Load())
+
+	fn(staging)
+
+	if err := staging.Validate(); err != nil {
+		for _, route := range staging.added {
+			route.policies.close()
+		}
+		return err
+	}
+
+	r.table.Store(staging.table.Load())
+	for _, route := range staging.dropped {
+		route.policies.close()
+	}
+	return nil
+}
+
+// RemoveRoute removes a route registered on the app while it is running
+// Routes registered on virtual hosts are not affected.
+//
+// Parameters:
+//   - method: HTTP method
+//   - pattern: Full route pattern, including group prefixes
+//
+// Returns:
+//   - bool: true if a route was removed
+//
+// Example:
+//
+//	app.GET("/beta/search", betaSearch)
+//
+//	// Later, while serving
+//	app.RemoveRoute("GET", "/beta/search")
+func (a *App) RemoveRoute(method, pattern string) bool {
+	return a.router.RemoveRoute(method, pattern)
+}
+
+// ReplaceRoutes atomically applies a batch of route changes to the app
+// See Router.ReplaceRoutes. Global middleware still applies to the new
+// routes; group middleware must be attached with WithMiddleware.
+//
+// Parameters:
+//   - fn: Function adding and removing routes on a staging router
+//
+// Returns:
+//   - error: Rejected routes, in which case nothing changed
+//
+// Example:
+//
+//	err := app.ReplaceRoutes(func(r *blaze.Router) {
+//	    for _, p := range plugins {
+//	        r.RemoveRoute("POST", "/plugins/"+p.Name)
+//	        if p.Enabled {
+//	            r.AddRoute("POST", "/plugins/"+p.Name, p.Handler)
+//	        }
+//	    }
+//	})
+func (a *App) ReplaceRoutes(fn func(*Router)) error {
+	return a.router.ReplaceRoutes(fn)
+}
@@ -2,8 +2,12 @@ package blaze
 
 import (
 	"fmt"
+	"log"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Router implements a radix tree-based router with advanced features
@@ -30,17 +34,39 @@ import (
 //   - Zero allocations for static routes
 //   - Minimal allocations for dynamic routes
 type Router struct {
-	// root is the root node of the radix tree
-	// Each HTTP method has its own tree for isolation
-	root *routeNode
+	// table is the current routing snapshot
+	// Requests load it once; writers publish a modified copy
+	table atomic.Pointer[routeTable]
 
-	// routes stores all registered routes by key (method:pattern)
-	// Used for route introspection and management
-	routes map[string]*Route
+	// mu serializes writers
+	mu sync.Mutex
+
+	// errors collects routes rejected during registration
+	// Reported by Validate
+	errors []error
+
+	// staging marks the router passed to ReplaceRoutes, whose rejected
+	// routes are returned rather than logged
+	staging bool
+
+	// added and dropped record the routes a staging router added and
+	// removed, whose policies are stopped once the batch is settled
+	added   []*Route
+	dropped []*Route
 
 	// config holds router configuration
 	// Controls behavior like case sensitivity, trailing slashes
 	config RouterConfig
+
+	// outer returns the owning app's global and host middleware as one
+	// middleware, copied when called so it is not affected by later Use
+	// calls; nil for routers that are not attached to an app
+	outer func() MiddlewareFunc
+
+	// nameOwner returns the route using a name on another router of the
+	// owning app, so names stay unique across virtual hosts; nil for
+	// routers that are not attached to an app
+	nameOwner func(name string) *Route
 }
 
 // RouterConfig holds comprehensive router configuration
@@ -61,9 +87,18 @@ type RouterConfig struct {
 	// CaseSensitive when true, routes are case-sensitive
 	// true: /Users and /users are different routes
 	// false: /Users and /users match the same route
+	// Only static segments are folded (ASCII); parameter and
+	// catch-all values are always passed through unchanged
 	// Default: false (more user-friendly)
 	CaseSensitive bool
 
+	// RedirectCanonicalCase when true, redirects requests whose static
+	// segments differ in case from the registered pattern
+	// /USERS/AbC -> 301 /users/AbC for pattern /users/:token
+	// Only applies when CaseSensitive is false
+	// Default: false (serve any casing directly)
+	RedirectCanonicalCase bool
+
 	// StrictSlash when true, trailing slashes must match exactly
 	// true: /users/ and /users are different routes
 	// false: /users/ and /users match the same route
@@ -121,6 +156,7 @@ type RouterConfig struct {
 func DefaultRouterConfig() RouterConfig {
 	return RouterConfig{
 		CaseSensitive:          false,
+		RedirectCanonicalCase:  false,
 		StrictSlash:            false,
 		RedirectSlash:          true,
 		UseEscapedPath:         false,
@@ -186,6 +222,27 @@ type routeNode struct {
 	// constraint for parameter validation
 	// nil for non-parameter nodes
 	constraint *RouteConstraint
+
+	// matcher splits composite param segments (":name.:ext")
+	// nil for plain :param nodes
+	matcher *segmentMatcher
+
+	// tails holds mid-path catch-all routes ("/*path/edit")
+	// Only set on catchAll nodes
+	tails []*routeTail
+}
+
+// routeTail is a mid-path catch-all route hanging off a catchAll node
+// The remainder of the path is matched with a regex instead of the tree
+type routeTail struct {
+	// pattern is the catch-all and the rest of the pattern ("*path/edit")
+	pattern string
+
+	// matcher captures the catch-all and any params after it
+	matcher *segmentMatcher
+
+	// node holds the handlers registered for this tail
+	node *routeNode
 }
 
 // nodeType defines the type of route node
@@ -208,7 +265,7 @@ const (
 
 	// catchAll is a wildcard node (*path)
 	// Matches remaining path segments
-	// Always at end of path
+	// Mid-path catch-alls are stored as tails on this node
 	catchAll
 )
 
@@ -219,7 +276,11 @@ const (
 //   - int: Integer values only
 //   - uuid: Valid UUID format
 //   - alpha: Alphabetic characters only
+//   - date: Calendar date (YYYY-MM-DD)
+//   - slug: Lowercase words separated by hyphens
+//   - ulid: ULID in Crockford base32
 //   - regex: Custom regex pattern
+//   - Any name added with RegisterConstraintType
 //
 // Validation Flow:
 //  1. Extract parameter from path
@@ -260,6 +321,21 @@ const (
 	// Example: /categories/:slug<alpha>
 	AlphaConstraint ConstraintType = "alpha"
 
+	// DateConstraint validates dates
+	// Pattern: YYYY-MM-DD
+	// Example: /reports/:day<date>
+	DateConstraint ConstraintType = "date"
+
+	// SlugConstraint validates URL slugs
+	// Pattern: ^[a-z0-9]+(?:-[a-z0-9]+)*$
+	// Example: /posts/:slug<slug>
+	SlugConstraint ConstraintType = "slug"
+
+	// ULIDConstraint validates ULIDs
+	// Pattern: 26 Crockford base32 characters
+	// Example: /orders/:id<ulid>
+	ULIDConstraint ConstraintType = "ulid"
+
 	// RegexConstraint validates custom regex patterns
 	// Pattern: User-defined
 	// Example: /files/:name<regex:[a-z0-9-]+>
@@ -313,6 +389,41 @@ type Route struct {
 	// Tags categorizes routes for grouping
 	// Used for filtering and documentation
 	Tags []string
+
+	// Source is the file:line where the route was registered
+	// Used in conflict errors
+	Source string
+
+	// Policy holds declarative per-route behavior
+	// Set with WithTimeout, WithBodyLimit, WithRateLimit, WithCORS and WithCache
+	Policy RoutePolicy
+
+	// Signature holds the request and response types of the handler
+	// Set by the typed registration helpers or WithSignature, nil otherwise
+	Signature *HandlerSignature
+
+	// Docs holds the OpenAPI documentation of the route
+	// Set with WithSummary, WithDescription, WithRequestBody and WithResponse
+	Docs RouteDocs
+
+	// segments is the parsed pattern
+	// Used for reverse routing and canonical paths
+	segments []routeSegment
+
+	// optionErr is set by route options that could not be applied
+	// The route is rejected at registration
+	optionErr error
+
+	// groupMiddleware is the middleware of the group the route was
+	// registered in, applied inside route middleware
+	groupMiddleware []MiddlewareFunc
+
+	// policies is the middleware built from Policy at registration
+	policies routePolicies
+
+	// index is the position of the route's compiled chain in
+	// routeTable.chains, assigned at registration
+	index int
 }
 
 type RouteGroup struct {
@@ -343,24 +454,31 @@ func NewRouter(config ...RouterConfig) *Router {
 		cfg = DefaultRouterConfig()
 	}
 
-	return &Router{
-		root:   &routeNode{},
-		routes: make(map[string]*Route),
-		config: cfg,
-	}
+	r := &Router{config: cfg}
+	r.table.Store(&routeTable{
+		root:  &routeNode{},
+		names: make(map[string]*Route),
+	})
+	return r
 }
 
 // MergeRoutes merges multiple routes with the same pattern
+// Only routes registered with exactly this pattern are merged
 func (r *Router) MergeRoutes(pattern string) error {
 	if !r.config.EnableMerging {
 		return fmt.Errorf("route merging is disabled")
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.table.Load().clone()
 	var routesToMerge []*Route
 
-	// Find all routes with the same pattern
-	for key, route := range r.routes {
-		if strings.Contains(key, pattern) {
+	// Find all routes registered with exactly this pattern
+	// (a substring match would merge unrelated routes such as /users/:id)
+	for _, route := range t.routes {
+		if route.Pattern == pattern {
 			routesToMerge = append(routesToMerge, route)
 		}
 	}
@@ -380,7 +498,18 @@ func (r *Router) MergeRoutes(pattern string) error {
 	}
 
 	// Update the routing tree
-	r.addToTree("*", pattern, masterRoute)
+	parsed, err := parseRoutePattern(pattern)
+	if err != nil {
+		return err
+	}
+	masterRoute.segments = parsed.segments
+	t.addChain(r, masterRoute)
+	for _, path := range parsed.expansions() {
+		if err := t.insert("*", r.treePath(path), masterRoute); err != nil {
+			return err
+		}
+	}
+	r.table.Store(t)
 
 	return nil
 }
@@ -390,7 +519,7 @@ func (r *Router) createMergedHandler(routes []*Route) HandlerFunc {
 	methodMap := make(map[string]HandlerFunc)
 
 	for _, route := range routes {
-		methodMap[route.Method] = route.Handler
+		methodMap[route.Method] = route.endpoint()
 	}
 
 	return func(c *Context) error {
@@ -498,7 +627,7 @@ func (r *Router) AddRouteGroup(group *RouteGroup) {
 // GetRoutesByTag returns routes filtered by tags
 func (r *Router) GetRoutesByTag(tag string) []*Route {
 	var routes []*Route
-	for _, route := range r.routes {
+	for _, route := range r.table.Load().routes {
 		for _, routeTag := range route.Tags {
 			if routeTag == tag {
 				routes = append(routes, route)
@@ -513,18 +642,25 @@ func (r *Router) GetRoutesByTag(tag string) []*Route {
 func (r *Router) GetRouteInfo() map[string]*RouteInfo {
 	info := make(map[string]*RouteInfo)
 
-	for key, route := range r.routes {
-		info[key] = &RouteInfo{
+	for _, route := range r.table.Load().routes {
+		routeInfo := &RouteInfo{
 			Method:          route.Method,
 			Pattern:         route.Pattern,
 			Name:            route.Name,
 			Params:          route.Params,
 			HasConstraints:  len(route.Constraints) > 0,
-			MiddlewareCount: len(route.Middleware),
+			MiddlewareCount: len(route.groupMiddleware) + len(route.Middleware),
 			Priority:        route.Priority,
 			Tags:            route.Tags,
 			IsMerged:        len(route.Merged) > 0,
+			Source:          route.Source,
+			Policy:          route.Policy.info(),
+		}
+		if route.Signature != nil {
+			routeInfo.Request = route.Signature.Request.String()
+			routeInfo.Response = route.Signature.Response.String()
 		}
+		info[route.Method+":"+route.Pattern] = routeInfo
 	}
 
 	return info
@@ -541,6 +677,14 @@ type RouteInfo struct {
 	Priority        int      `json:"priority"`
 	Tags            []string `json:"tags,omitempty"`
 	IsMerged        bool     `json:"is_merged"`
+	Source          string   `json:"source,omitempty"`
+
+	// Policy is nil when the route declares no policies
+	Policy *RoutePolicy `json:"policy,omitempty"`
+
+	// Request and Response name the types of a typed handler (blaze.Handle)
+	Request  string `json:"request,omitempty"`
+	Response string `json:"response,omitempty"`
 }
 
 // WithPriority sets the route priority
@@ -590,10 +734,20 @@ func WithMerge(enable bool) RouteOption {
 //
 // Route Registration Process:
 //  1. Create route object with handler
-//  2. Apply route options (middleware, constraints, etc.)
-//  3. Parse pattern to extract parameters
-//  4. Insert into radix tree
-//  5. Store in routes map for introspection
+//  2. Parse pattern, extracting parameters and inline constraints
+//  3. Apply route options (middleware, constraints, etc.)
+//  4. Check for conflicts with registered routes
+//  5. Insert into radix tree (once per optional-parameter variant)
+//  6. Store in routes map for introspection
+//
+// A malformed or conflicting route is not registered and AddRoute does not
+// panic. The *RouteError, which names both routes and where they were
+// registered, is logged immediately and reported by Validate
+// (App.ValidateRoutes).
+//
+// AddRoute is safe to call while serving requests. The new route is
+// published atomically; requests already in flight keep the routes they
+// started with.
 //
 // Parameters:
 //   - method: HTTP method (GET, POST, etc.)
@@ -618,23 +772,74 @@ func (r *Router) AddRoute(method, pattern string, handler HandlerFunc, options .
 		Middleware:  make([]MiddlewareFunc, 0),
 		Constraints: make(map[string]*RouteConstraint),
 		Params:      make([]string, 0),
+		Source:      callerSource(),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Parse pattern, inline constraints are overridden by explicit options
+	parsed, err := parseRoutePattern(pattern)
+	if err != nil {
+		r.reject(route, nil, err.Error())
+		return route
+	}
+	route.segments = parsed.segments
+	route.Params = append(route.Params, parsed.params...)
+	for name, constraint := range parsed.constraints {
+		route.Constraints[name] = constraint
 	}
 
 	// Apply route options
 	for _, option := range options {
 		option(route)
 	}
+	if route.optionErr != nil {
+		r.reject(route, nil, route.optionErr.Error())
+		return route
+	}
 
-	// Parse pattern and extract parameters
-	r.parsePattern(route)
+	if route.Name != "" && r.nameOwner != nil {
+		if existing := r.nameOwner(route.Name); existing != nil {
+			r.reject(route, existing, fmt.Sprintf("name %q is already used on another host", route.Name))
+			return route
+		}
+	}
+
+	// Check every variant before touching the tree
+	t := r.table.Load()
+	paths := parsed.expansions()
+	for i, path := range paths {
+		paths[i] = r.treePath(path)
+		if existing, reason := t.findConflict(method, paths[i]); existing != nil {
+			r.reject(route, existing, reason)
+			return route
+		}
+	}
 
-	// Add to radix tree
-	r.addToTree(method, pattern, route)
+	route.policies = route.Policy.build()
+
+	// Add to a copy of the radix tree
+	t = t.clone()
+	t.addChain(r, route)
+	for _, path := range paths {
+		if err := t.insert(method, path, route); err != nil {
+			route.policies.close()
+			r.reject(route, nil, err.Error())
+			return route
+		}
+	}
 
 	// Store route
-	key := method + ":" + pattern
-	r.routes[key] = route
+	t.routes = append(t.routes, route)
+	if route.Name != "" {
+		t.setName(route.Name, route)
+	}
 
+	if r.staging {
+		r.added = append(r.added, route)
+	}
+	r.table.Store(t)
 	return route
 }
 
@@ -757,6 +962,7 @@ func WithUUIDConstraint(param string) RouteOption {
 }
 
 // WithRegexConstraint adds a custom regex constraint
+// An invalid pattern rejects the route at registration
 // Validates parameter against custom pattern
 //
 // Parameters:
@@ -772,51 +978,89 @@ func WithUUIDConstraint(param string) RouteOption {
 //	    blaze.WithRegexConstraint("name", `^[a-z0-9-]+$`),
 //	)
 func WithRegexConstraint(param string, pattern string) RouteOption {
+	re, err := regexp.Compile(pattern)
 	return func(r *Route) {
+		if err != nil {
+			r.setOptionErr(fmt.Errorf("invalid constraint for parameter %q: %w", param, err))
+			return
+		}
 		r.Constraints[param] = &RouteConstraint{
 			Name:    param,
 			Type:    RegexConstraint,
-			Pattern: regexp.MustCompile(pattern),
+			Pattern: re,
 		}
 	}
 }
 
-// parsePattern parses the route pattern and extracts parameters
-func (r *Router) parsePattern(route *Route) {
-	pattern := route.Pattern
-	segments := strings.Split(strings.Trim(pattern, "/"), "/")
-
-	for _, segment := range segments {
-		if strings.HasPrefix(segment, ":") {
-			paramName := segment[1:]
-			route.Params = append(route.Params, paramName)
-		} else if strings.HasPrefix(segment, "*") {
-			paramName := segment[1:]
-			if paramName == "" {
-				paramName = "wildcard"
-			}
-			route.Params = append(route.Params, paramName)
-		}
+// setOptionErr records the first route option that could not be applied
+func (r *Route) setOptionErr(err error) {
+	if r.optionErr == nil {
+		r.optionErr = err
+	}
+}
+
+// reject records a route that could not be registered
+func (r *Router) reject(route, existing *Route, reason string) {
+	err := &RouteError{
+		Method:   route.Method,
+		Pattern:  route.Pattern,
+		Source:   route.Source,
+		Existing: existing,
+		Reason:   reason,
+	}
+	r.errors = append(r.errors, err)
+
+	// Routes added while serving are not validated again, so report the
+	// route as soon as it is dropped
+	if !r.staging {
+		log.Print(err)
 	}
 }
 
-// addToTree adds a route to the radix tree
-func (r *Router) addToTree(method, pattern string, route *Route) {
+// treePath converts a normalized pattern to the form stored in the tree
+func (r *Router) treePath(pattern string) string {
 	path := pattern
 	if !r.config.CaseSensitive {
-		path = strings.ToLower(path)
+		path = lowerStaticSegments(path)
 	}
 
-	root := r.root
+	// An unnamed catch-all ("/static/*") is stored as "*wildcard"
+	if strings.HasSuffix(path, "/*") {
+		path += "wildcard"
+	}
+	return path
+}
+
+// insert adds a route to the table's radix tree
+// path must be a tree path (see treePath) and the table must not be
+// published yet. On error the table is left partially modified and must
+// be discarded.
+func (t *routeTable) insert(method, path string, route *Route) error {
+	t.entries = append(t.entries, treeEntry{method: method, path: path, route: route})
+
+	// A mid-path catch-all ("/repos/*path/edit") is inserted as a plain
+	// catch-all, with the rest of the pattern kept as a tail on that node
+	tail := ""
+	if i := strings.IndexByte(path, '*'); i >= 0 {
+		if j := strings.IndexByte(path[i:], '/'); j >= 0 {
+			tail = path[i:]
+			path = path[:i+j]
+		}
+	}
+
+	root := t.root
 	if root.handlers == nil {
 		root.handlers = make(map[string]*Route)
 	}
 
-	r.insertRoute(root, method, path, route)
+	return root.insertRoute(method, path, route, tail)
 }
 
-// insertRoute inserts a route into the tree
-func (r *Router) insertRoute(n *routeNode, method, path string, route *Route) {
+// insertRoute inserts a route below n
+// Nodes shared with published tables are copied before they are modified
+// Returns an error for paths the tree cannot hold; conflicts are normally
+// caught earlier by findConflict.
+func (n *routeNode) insertRoute(method, path string, route *Route, tail string) error {
 	// _originalPath := path
 	fullPath := path
 	n.priority++
@@ -850,6 +1094,7 @@ walk:
 			path = path[i:]
 
 			if n.wildChild {
+				n.children[0] = n.children[0].clone()
 				n = n.children[0]
 				n.priority++
 
@@ -861,15 +1106,15 @@ walk:
 					}
 				}
 
-				panic("path segment '" + path +
-					"' conflicts with existing wildcard '" + n.path +
-					"' in path '" + fullPath + "'")
+				return fmt.Errorf("path segment %q conflicts with existing wildcard %q in path %q",
+					path, n.path, fullPath)
 			}
 
 			c := path[0]
 
 			// Param node
 			if n.nodeType == param && c == '/' && len(n.children) == 1 {
+				n.children[0] = n.children[0].clone()
 				n = n.children[0]
 				n.priority++
 				continue walk
@@ -878,6 +1123,7 @@ walk:
 			// Check if a child with the next path byte exists
 			for i, max := 0, len(n.indices); i < max; i++ {
 				if c == n.indices[i] {
+					n.children[i] = n.children[i].clone()
 					i = n.incrementChildPrio(i)
 					n = n.children[i]
 					continue walk
@@ -895,21 +1141,52 @@ walk:
 				n.incrementChildPrio(len(n.indices) - 1)
 				n = child
 			}
-			n.insertChild(path, fullPath, route, method)
-			return
+			return n.insertChild(path, fullPath, route, method, tail)
 		}
 
 		// Otherwise add handler to current node
+		return n.addHandler(method, route, tail, fullPath)
+	}
+}
+
+// addHandler registers route for method on the node
+// A non-empty tail registers a mid-path catch-all route instead
+func (n *routeNode) addHandler(method string, route *Route, tail, fullPath string) error {
+	if tail == "" {
 		if n.handlers == nil {
 			n.handlers = make(map[string]*Route)
 		}
 		n.handlers[method] = route
-		return
+		return nil
+	}
+
+	for _, t := range n.tails {
+		if t.pattern == tail {
+			t.node.handlers[method] = route
+			return nil
+		}
 	}
+
+	slash := strings.IndexByte(tail, '/')
+	matcher, err := compileSegmentMatcher(tail[slash:], tail[1:slash])
+	if err != nil {
+		return fmt.Errorf("%v in path %q", err, fullPath)
+	}
+
+	n.tails = append(n.tails, &routeTail{
+		pattern: tail,
+		matcher: matcher,
+		node: &routeNode{
+			path:     n.path,
+			nodeType: catchAll,
+			handlers: map[string]*Route{method: route},
+		},
+	})
+	return nil
 }
 
 // insertChild inserts a child node
-func (n *routeNode) insertChild(path, fullPath string, route *Route, method string) {
+func (n *routeNode) insertChild(path, fullPath string, route *Route, method, tail string) error {
 	for {
 		// Find prefix until first wildcard
 		wildcard, i, valid := findWildcard(path)
@@ -917,24 +1194,25 @@ func (n *routeNode) insertChild(path, fullPath string, route *Route, method stri
 			break
 		}
 
-		// The wildcard name must not contain ':' and '*'
+		// A param segment may hold several params (":name.:ext"),
+		// but never a catch-all
 		if !valid {
-			panic("only one wildcard per path segment is allowed, has: '" +
-				wildcard + "' in path '" + fullPath + "'")
+			return fmt.Errorf("only one wildcard per path segment is allowed, has %q in path %q",
+				wildcard, fullPath)
 		}
 
 		// Check if the wildcard has a name
 		if len(wildcard) < 2 {
-			panic("wildcards must be named with a non-empty name in path '" + fullPath + "'")
-		}
-
-		// Split path at the beginning of the wildcard
-		if i > 0 {
-			n.path = path[:i]
-			path = path[i:]
+			return fmt.Errorf("wildcards must be named with a non-empty name in path %q", fullPath)
 		}
 
 		if wildcard[0] == ':' { // param
+			// Split path at the beginning of the wildcard
+			if i > 0 {
+				n.path = path[:i]
+				path = path[i:]
+			}
+
 			// Split path at the end of the wildcard
 			if i := strings.Index(wildcard, "/"); i > 0 {
 				n.path += wildcard[:i]
@@ -946,6 +1224,13 @@ func (n *routeNode) insertChild(path, fullPath string, route *Route, method stri
 				path:      wildcard,
 				maxParams: route.maxParams(),
 			}
+			if isCompositeParam(wildcard) {
+				matcher, err := compileSegmentMatcher(wildcard, "")
+				if err != nil {
+					return fmt.Errorf("%v in path %q", err, fullPath)
+				}
+				child.matcher = matcher
+			}
 			n.children = []*routeNode{child}
 			n.wildChild = true
 			n = child
@@ -965,25 +1250,21 @@ func (n *routeNode) insertChild(path, fullPath string, route *Route, method stri
 			}
 
 			// Otherwise we're done. Insert the handler in the new leaf
-			if n.handlers == nil {
-				n.handlers = make(map[string]*Route)
-			}
-			n.handlers[method] = route
-			return
+			return n.addHandler(method, route, tail, fullPath)
 
 		} else { // catchAll
 			if i+len(wildcard) != len(path) {
-				panic("catch-all routes are only allowed at the end of the path in path '" + fullPath + "'")
+				return fmt.Errorf("catch-all routes are only allowed at the end of the path in path %q", fullPath)
 			}
 
 			if len(n.path) > 0 && n.path[len(n.path)-1] == '/' {
-				panic("catch-all conflicts with existing handle for the path segment root in path '" + fullPath + "'")
+				return fmt.Errorf("catch-all conflicts with existing handle for the path segment root in path %q", fullPath)
 			}
 
 			// Currently fixed width 1 for '/'
 			i--
 			if path[i] != '/' {
-				panic("no / before catch-all in path '" + fullPath + "'")
+				return fmt.Errorf("no / before catch-all in path %q", fullPath)
 			}
 
 			n.path = path[:i]
@@ -1008,19 +1289,14 @@ func (n *routeNode) insertChild(path, fullPath string, route *Route, method stri
 				handlers:  make(map[string]*Route),
 				priority:  1,
 			}
-			child.handlers[method] = route
 			n.children = []*routeNode{child}
-
-			return
+			return child.addHandler(method, route, tail, fullPath)
 		}
 	}
 
 	// If no wildcard was found, simply insert the path and handler
 	n.path = path
-	if n.handlers == nil {
-		n.handlers = make(map[string]*Route)
-	}
-	n.handlers[method] = route
+	return n.addHandler(method, route, tail, fullPath)
 }
 
 // FindRoute finds a matching route for the given method and path
@@ -1033,6 +1309,9 @@ func (n *routeNode) insertChild(path, fullPath string, route *Route, method stri
 //  4. Validate constraints
 //  5. Return route and parameters
 //
+// HEAD requests are served by the GET route when no explicit HEAD
+// route is registered for the path.
+//
 // Parameters:
 //   - method: HTTP method
 //   - path: Request path
@@ -1049,34 +1328,108 @@ func (n *routeNode) insertChild(path, fullPath string, route *Route, method stri
 //	// params: {"id": "123"}
 //	// found: true
 func (r *Router) FindRoute(method, path string) (*Route, map[string]string, bool) {
+	var params paramList
+	route, found := r.findRoute(r.table.Load(), method, path, &params)
+	if !found {
+		return nil, nil, false
+	}
+	return route, params.toMap(), true
+}
+
+// findRoute looks up a route in the given table snapshot
+// Captured parameters are appended to params; nothing is appended when
+// no route matches.
+func (r *Router) findRoute(t *routeTable, method, path string, params *paramList) (*Route, bool) {
+	lookup := path
 	if !r.config.CaseSensitive {
-		path = strings.ToLower(path)
+		lookup = toLowerASCII(path)
 	}
 
-	root := r.root
-	params := make(map[string]string)
+	start := len(*params)
 
-	route, found := r.getValue(root, path, method, params)
-	if !found {
-		return nil, nil, false
+	route, _ := r.getValue(t.root, lookup, path, method, params)
+	if route == nil || !r.validateConstraints(route, (*params)[start:]) {
+		*params = (*params)[:start]
+		return nil, false
 	}
 
-	// Validate constraints
-	if !r.validateConstraints(route, params) {
-		return nil, nil, false
+	return route, true
+}
+
+// AllowedMethods returns the methods that can serve the given path
+// Used to build the Allow header for 405 and automatic OPTIONS responses
+//
+// The list is sorted and only includes methods whose route constraints
+// accept the path. HEAD is included when GET is registered, and OPTIONS
+// is included when HandleOPTIONS is enabled.
+//
+// Parameters:
+//   - path: Request path
+//
+// Returns:
+//   - []string: Allowed methods, or nil if no route matches the path
+//
+// Example:
+//
+//	router.AllowedMethods("/users/123")
+//	// [GET HEAD OPTIONS PUT]
+func (r *Router) AllowedMethods(path string) []string {
+	return r.allowedMethods(r.table.Load(), path)
+}
+
+// allowedMethods computes the Allow list from the given table snapshot
+func (r *Router) allowedMethods(t *routeTable, path string) []string {
+	lookup := path
+	if !r.config.CaseSensitive {
+		lookup = toLowerASCII(path)
+	}
+
+	var params paramList
+	_, n := r.getValue(t.root, lookup, path, "", &params)
+	if n == nil {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(n.handlers)+2)
+	seen := make(map[string]bool, len(n.handlers)+2)
+	for method, route := range n.handlers {
+		// Merged master routes are stored under "*" and are not method routes
+		if method == "*" || !r.validateConstraints(route, params) {
+			continue
+		}
+		allowed = append(allowed, method)
+		seen[method] = true
+	}
+
+	if len(allowed) == 0 {
+		return nil
 	}
 
-	return route, params, true
+	if seen["GET"] && !seen["HEAD"] {
+		allowed = append(allowed, "HEAD")
+	}
+	if r.config.HandleOPTIONS && !seen["OPTIONS"] {
+		allowed = append(allowed, "OPTIONS")
+	}
+
+	sort.Strings(allowed)
+	return allowed
 }
 
 // getValue traverses the tree to find a matching route
-func (r *Router) getValue(n *routeNode, path, method string, params map[string]string) (*Route, bool) {
+// Returns the route registered for method along with the node matching
+// the path; the node is returned even when no handler exists for method
+//
+// path is matched against the tree while parameter values are sliced from
+// orig, which must have the same length (the request path before case folding)
+func (r *Router) getValue(n *routeNode, path, orig, method string, params *paramList) (*Route, *routeNode) {
 walk: // Outer loop for walking the tree
 	for {
 		prefix := n.path
 		if len(path) > len(prefix) {
 			if path[:len(prefix)] == prefix {
 				path = path[len(prefix):]
+				orig = orig[len(prefix):]
 
 				// Try all the non-wildcard children first
 				for i, max := 0, len(n.indices); i < max; i++ {
@@ -1089,7 +1442,7 @@ walk: // Outer loop for walking the tree
 
 				// If there is no wildcard, we can't match the route
 				if !n.wildChild {
-					return nil, false
+					return nil, nil
 				}
 
 				// Handle wildcard child
@@ -1102,76 +1455,127 @@ walk: // Outer loop for walking the tree
 						end++
 					}
 
-					// Save param value
-					paramKey := n.path[1:] // Remove ':'
-					params[paramKey] = path[:end]
+					// Save param value, splitting composite segments
+					if n.matcher != nil {
+						loc := n.matcher.regex.FindStringSubmatchIndex(path[:end])
+						if loc == nil {
+							return nil, nil
+						}
+						n.matcher.capture(loc, orig[:end], params)
+					} else {
+						paramKey := n.path[1:] // Remove ':'
+						params.add(paramKey, orig[:end])
+					}
 
 					// We need to go deeper!
 					if end < len(path) {
 						if len(n.children) > 0 {
 							path = path[end:]
+							orig = orig[end:]
 							n = n.children[0]
 							continue walk
 						}
 
 						// ... but we can't
-						return nil, false
+						return nil, nil
 					}
 
-					if route, ok := n.handlers[method]; ok {
-						return route, true
+					if route := n.getRoute(method); route != nil {
+						return route, n
 					}
 
 					if len(n.children) == 1 {
 						// No handler found. Check if a handler for this route + a
 						// trailing slash exists for trailing slash recommendation
-						n = n.children[0]
-						if n.path == "/" && n.handlers[method] != nil {
-							return n.handlers[method], true
+						child := n.children[0]
+						if child.path == "/" {
+							if route := child.getRoute(method); route != nil {
+								return route, child
+							}
+							if len(n.handlers) == 0 && len(child.handlers) > 0 {
+								return nil, child
+							}
 						}
 					}
 
-					return nil, false
+					return nil, n.matched()
 
 				case catchAll:
-					// Save param value
-					paramKey := n.path[2:] // Remove '*'
-					params[paramKey] = path
+					// Mid-path catch-alls are tried first, in registration order
+					var tailMatch *routeTail
+					var tailLoc []int
+					for _, t := range n.tails {
+						loc := t.matcher.regex.FindStringSubmatchIndex(path)
+						if loc == nil {
+							continue
+						}
+						if route := t.node.getRoute(method); route != nil {
+							t.matcher.capture(loc, orig, params)
+							return route, t.node
+						}
+						if tailMatch == nil {
+							tailMatch, tailLoc = t, loc
+						}
+					}
 
-					if route, ok := n.handlers[method]; ok {
-						return route, true
+					if len(n.handlers) > 0 {
+						// Save param value
+						paramKey := n.path[2:] // Remove '*'
+						params.add(paramKey, orig)
+
+						if route := n.getRoute(method); route != nil {
+							return route, n
+						}
+					}
+
+					if tailMatch != nil {
+						tailMatch.matcher.capture(tailLoc, orig, params)
+						return nil, tailMatch.node
 					}
 
-					return nil, false
+					return nil, n.matched()
 
 				default:
 					panic("invalid node type")
 				}
 			}
 		} else if path == prefix {
-			if route, ok := n.handlers[method]; ok {
-				return route, true
+			if route := n.getRoute(method); route != nil {
+				return route, n
 			}
 
-			// Handle trailing slash
-			if path == "/" && r.config.RedirectSlash && method != "CONNECT" {
-				if route, ok := n.handlers[method]; ok {
-					return route, true
-				}
-			}
-
-			return nil, false
+			return nil, n.matched()
 		}
 
 		// Nothing found
-		return nil, false
+		return nil, nil
+	}
+}
+
+// getRoute returns the route registered for method on this node
+// HEAD falls back to the GET route when no explicit HEAD route exists
+func (n *routeNode) getRoute(method string) *Route {
+	if route, ok := n.handlers[method]; ok {
+		return route
 	}
+	if method == "HEAD" {
+		return n.handlers["GET"]
+	}
+	return nil
+}
+
+// matched returns the node if any route is registered on it, nil otherwise
+func (n *routeNode) matched() *routeNode {
+	if len(n.handlers) > 0 {
+		return n
+	}
+	return nil
 }
 
 // validateConstraints validates route parameter constraints
-func (r *Router) validateConstraints(route *Route, params map[string]string) bool {
+func (r *Router) validateConstraints(route *Route, params paramList) bool {
 	for paramName, constraint := range route.Constraints {
-		value, exists := params[paramName]
+		value, exists := params.get(paramName)
 		if !exists {
 			continue
 		}
@@ -1211,7 +1615,85 @@ func (n *routeNode) incrementChildPrio(pos int) int {
 	return newPos
 }
 
+// canonicalPath rebuilds the request path using the casing of the route pattern
+// Parameter and catch-all values are taken from the matched params
+func (r *Route) canonicalPath(params paramList) string {
+	var b strings.Builder
+	b.Grow(len(r.Pattern) + 16)
+
+	for _, segment := range r.segments {
+		// Omitted optional params end the path
+		if segment.optional {
+			if _, ok := params.get(segment.parts[0].text); !ok {
+				break
+			}
+		}
+
+		b.WriteByte('/')
+		for _, part := range segment.parts {
+			switch {
+			case part.param:
+				value, _ := params.get(part.text)
+				b.WriteString(value)
+			case part.catchAll:
+				// Catch-all values carry their leading slash
+				value, _ := params.get(part.text)
+				b.WriteString(strings.TrimPrefix(value, "/"))
+			default:
+				b.WriteString(part.text)
+			}
+		}
+	}
+
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
 // Helper functions
+
+// toLowerASCII lowercases ASCII letters only, so the result has the same
+// length as s and byte offsets can be shared with the original string
+func toLowerASCII(s string) string {
+	i := 0
+	for i < len(s) && (s[i] < 'A' || s[i] > 'Z') {
+		i++
+	}
+	if i == len(s) {
+		return s
+	}
+
+	b := []byte(s)
+	for ; i < len(b); i++ {
+		if c := b[i]; c >= 'A' && c <= 'Z' {
+			b[i] = c + ('a' - 'A')
+		}
+	}
+	return string(b)
+}
+
+// lowerStaticSegments lowercases a normalized route pattern except for
+// parameter and catch-all names, which must keep their casing for Param lookups
+func lowerStaticSegments(pattern string) string {
+	b := []byte(pattern)
+	inName := false
+	for i, c := range b {
+		switch {
+		case c == ':' || c == '*':
+			inName = true
+		case inName && isParamNameChar(c):
+			// Part of a parameter name
+		case c >= 'A' && c <= 'Z':
+			inName = false
+			b[i] = c + ('a' - 'A')
+		default:
+			inName = false
+		}
+	}
+	return string(b)
+}
+
 func longestCommonPrefix(a, b string) int {
 	i := 0
 	max := len(a)
@@ -1233,12 +1715,13 @@ func findWildcard(path string) (wildcard string, i int, valid bool) {
 		}
 
 		// Find end and check for invalid characters
+		// Params may share a segment with other params (":name.:ext")
 		valid = true
-		for end, c := range []byte(path[start+1:]) {
-			switch c {
-			case '/':
+		for end, b := range []byte(path[start+1:]) {
+			switch {
+			case b == '/':
 				return path[start : start+1+end], start, valid
-			case ':', '*':
+			case b == '*', b == ':' && c == '*':
 				valid = false
 			}
 		}
@@ -0,0 +1,343 @@
+package blaze_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+// param returns a handler writing the named route parameters, one per line
+func param(names ...string) blaze.HandlerFunc {
+	return func(c *blaze.Context) error {
+		var body string
+		for _, name := range names {
+			body += name + "=" + c.Param(name) + "\n"
+		}
+		return c.Text(body)
+	}
+}
+
+// text returns a handler writing s
+func text(s string) blaze.HandlerFunc {
+	return func(c *blaze.Context) error {
+		return c.Text(s)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	app := blaze.New()
+	app.GET("/users/:id", param("id"))
+	app.PUT("/users/:id", text("updated"))
+
+	srv := blazetest.New(t, app)
+
+	srv.Delete("/users/1").Do().
+		AssertStatus(http.StatusMethodNotAllowed).
+		AssertHeader("Allow", "GET, HEAD, OPTIONS, PUT")
+
+	srv.Options("/users/1").Do().
+		AssertStatus(http.StatusNoContent).
+		AssertHeader("Allow", "GET, HEAD, OPTIONS, PUT")
+
+	srv.Head("/users/1").Do().
+		AssertStatus(http.StatusOK).
+		AssertBody("")
+
+	srv.Delete("/missing").Do().AssertStatus(http.StatusNotFound)
+}
+
+func TestCaseInsensitiveParams(t *testing.T) {
+	app := blaze.New()
+	app.GET("/users/:token", param("token"))
+
+	srv := blazetest.New(t, app)
+	srv.Get("/USERS/AbC123").Do().
+		AssertStatus(http.StatusOK).
+		AssertBody("token=AbC123\n")
+
+	config := blaze.DefaultRouterConfig()
+	config.RedirectCanonicalCase = true
+	redirecting := blaze.New()
+	redirecting.SetRouterConfig(config)
+	redirecting.GET("/users/:token", param("token"))
+
+	blazetest.New(t, redirecting).Get("/USERS/AbC123?tab=1").Do().
+		AssertStatus(http.StatusMovedPermanently).
+		AssertHeaderContains("Location", "/users/AbC123?tab=1")
+}
+
+func TestRoutePatterns(t *testing.T) {
+	app := blaze.New()
+	app.GET("/archive/:year?/:month?", param("year", "month"))
+	app.GET("/repos/*path/edit", param("path"))
+	app.GET("/files/:name.:ext", param("name", "ext"))
+	app.GET("/orders/{id:[0-9]+}", param("id"))
+	app.GET("/posts/:id<int>", param("id"))
+
+	if err := blaze.RegisterConstraintType("lower", `^[a-z]+`); err != nil {
+		t.Fatal(err)
+	}
+	app.GET("/tags/:tag<lower>", param("tag"))
+
+	srv := blazetest.New(t, app)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/archive", http.StatusOK, "year=\nmonth=\n"},
+		{"/archive/2024", http.StatusOK, "year=2024\nmonth=\n"},
+		{"/archive/2024/05", http.StatusOK, "year=2024\nmonth=05\n"},
+		{"/repos/acme/blaze/edit", http.StatusOK, "path=/acme/blaze\n"},
+		{"/files/archive.tar.gz", http.StatusOK, "name=archive.tar\next=gz\n"},
+		{"/orders/42", http.StatusOK, "id=42\n"},
+		{"/orders/4x2", http.StatusNotFound, ""},
+		{"/posts/7", http.StatusOK, "id=7\n"},
+		{"/posts/seven", http.StatusNotFound, ""},
+		{"/tags/go", http.StatusOK, "tag=go\n"},
+		// Registered types are anchored at both ends
+		{"/tags/go123", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		res := srv.Get(tt.path).Do().AssertStatus(tt.status)
+		if tt.body != "" {
+			res.AssertBody(tt.body)
+		}
+	}
+}
+
+func TestConstraintErrors(t *testing.T) {
+	captureLog(t)
+
+	if err := blaze.RegisterConstraintType("broken", `[a-`); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	if err := blaze.RegisterConstraintType("", `[a-z]+`); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+
+	app := blaze.New()
+	app.GET("/a/:id", param("id"), blaze.WithTypedConstraint("id", "broken"))
+	app.GET("/b/:id", param("id"), blaze.WithRegexConstraint("id", `(`))
+	app.GET("/c/:id<broken>", param("id"))
+	app.GET("/d/:id", param("id"), blaze.WithTypedConstraint("id", "slug"))
+
+	err := app.ValidateRoutes()
+	if err == nil {
+		t.Fatal("invalid constraints were accepted")
+	}
+	for _, route := range []string{"GET /a/:id", "GET /b/:id", "GET /c/:id<broken>"} {
+		if !strings.Contains(err.Error(), "invalid route "+route) {
+			t.Errorf("%s was not rejected:\n%v", route, err)
+		}
+	}
+
+	if strings.Contains(err.Error(), "/d/:id") {
+		t.Errorf("valid route rejected:\n%v", err)
+	}
+}
+
+func TestReverseRouting(t *testing.T) {
+	app := blaze.New()
+	api := app.Group("/api/v1")
+	api.GET("/users/:id/files/*path", param("id", "path"),
+		blaze.WithName("user.file"),
+		blaze.WithIntConstraint("id"))
+
+	url, err := app.URL("user.file", "id", 42, "path", "docs/report.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "/api/v1/users/42/files/docs/report.pdf" {
+		t.Errorf("URL = %q", url)
+	}
+
+	if _, err := app.URL("user.file", "id", "abc", "path", "x"); err == nil {
+		t.Error("expected an error for a value violating the constraint")
+	}
+	if _, err := app.URL("missing"); err == nil {
+		t.Error("expected an error for an unknown route name")
+	}
+
+	blazetest.New(t, app).Get(url).Do().
+		AssertStatus(http.StatusOK).
+		AssertBody("id=42\npath=/docs/report.pdf\n")
+}
+
+func TestHostRouting(t *testing.T) {
+	app := blaze.New()
+	app.GET("/", text("landing"))
+
+	api := app.Host("api.example.com")
+	api.GET("/", text("api"))
+	api.SetNotFoundHandler(func(c *blaze.Context) error {
+		return c.Status(http.StatusNotFound).JSON(blaze.Map{"error": "unknown endpoint"})
+	})
+
+	app.Host(":tenant.example.com").GET("/", param("tenant"))
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/").WithHost("API.example.com:8080").Do().AssertBody("api")
+	srv.Get("/").WithHost("acme.example.com").Do().AssertBody("tenant=acme\n")
+	srv.Get("/").WithHost("other.org").Do().AssertBody("landing")
+	srv.Get("/nope").WithHost("api.example.com").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertJSONPath("error", "unknown endpoint")
+}
+
+func TestMount(t *testing.T) {
+	var seen string
+
+	billing := blaze.New()
+	billing.GET("/invoices/:id", func(c *blaze.Context) error {
+		return c.Text(c.Path() + " " + c.Param("id"))
+	}, blaze.WithName("invoice"))
+
+	app := blaze.New()
+	app.Use(func(next blaze.HandlerFunc) blaze.HandlerFunc {
+		return func(c *blaze.Context) error {
+			seen = c.Path()
+			return next(c)
+		}
+	})
+	app.GET("/billing/shadowed", text("parent"))
+	app.Mount("/billing", billing)
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/billing/invoices/42").Do().
+		AssertStatus(http.StatusOK).
+		AssertBody("/invoices/42 42")
+	if seen != "/billing/invoices/42" {
+		t.Errorf("parent middleware saw %q, want the original path", seen)
+	}
+
+	// The mount owns its whole prefix
+	srv.Get("/billing/shadowed").Do().AssertStatus(http.StatusNotFound)
+
+	if url, err := app.URL("invoice", "id", 7); err != nil || url != "/billing/invoices/7" {
+		t.Errorf("URL = %q, %v", url, err)
+	}
+}
+
+func TestGroupScopedHandlers(t *testing.T) {
+	app := blaze.New()
+
+	api := app.Group("/api")
+	api.GET("/users", text("users"))
+	api.SetNotFoundHandler(func(c *blaze.Context) error {
+		return c.Status(http.StatusNotFound).JSON(blaze.Map{"error": "unknown endpoint"})
+	})
+	api.SetMethodNotAllowedHandler(func(c *blaze.Context) error {
+		return c.Status(http.StatusMethodNotAllowed).JSON(blaze.Map{"allow": c.GetResponseHeader("Allow")})
+	})
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/api/nope").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertJSONPath("error", "unknown endpoint")
+	srv.Post("/api/users").Do().
+		AssertStatus(http.StatusMethodNotAllowed).
+		AssertJSONPath("allow", "GET, HEAD, OPTIONS")
+	srv.Get("/elsewhere").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertJSONPath("error.code", "NOT_FOUND")
+}
+
+// TestScopedHandlersAfterUse checks that middleware added after a group
+// handler still wraps it
+func TestScopedHandlersAfterUse(t *testing.T) {
+	app := blaze.New()
+	api := app.Group("/api")
+	api.SetNotFoundHandler(func(c *blaze.Context) error {
+		return c.Status(http.StatusNotFound).Text("api 404")
+	})
+	app.Use(func(next blaze.HandlerFunc) blaze.HandlerFunc {
+		return func(c *blaze.Context) error {
+			c.SetHeader("X-Global", "1")
+			return next(c)
+		}
+	})
+
+	blazetest.New(t, app).Get("/api/nope").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertBody("api 404").
+		AssertHeader("X-Global", "1")
+}
+
+func TestRuntimeRouteChanges(t *testing.T) {
+	app := blaze.New()
+	app.Use(func(next blaze.HandlerFunc) blaze.HandlerFunc {
+		return func(c *blaze.Context) error {
+			c.SetHeader("X-Global", "1")
+			return next(c)
+		}
+	})
+	app.GET("/plugins/search", text("v1"))
+
+	srv := blazetest.New(t, app)
+	srv.Get("/plugins/search").Do().AssertBody("v1")
+
+	// Added while serving, global middleware included
+	app.GET("/features/export", text("export"))
+	srv.Get("/features/export").Do().
+		AssertBody("export").
+		AssertHeader("X-Global", "1")
+
+	if !app.RemoveRoute("GET", "/features/export") {
+		t.Fatal("RemoveRoute reported no route")
+	}
+	srv.Get("/features/export").Do().AssertStatus(http.StatusNotFound)
+
+	err := app.ReplaceRoutes(func(r *blaze.Router) {
+		r.RemoveRoute("GET", "/plugins/search")
+		r.AddRoute("GET", "/plugins/search", text("v2"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Get("/plugins/search").Do().
+		AssertBody("v2").
+		AssertHeader("X-Global", "1")
+
+	// A rejected batch publishes nothing
+	err = app.ReplaceRoutes(func(r *blaze.Router) {
+		r.RemoveRoute("GET", "/plugins/search")
+		r.AddRoute("GET", "/plugins/:name", text("v3"))
+		r.AddRoute("GET", "/plugins/:other/x", text("v3"))
+	})
+	if err == nil {
+		t.Fatal("expected the conflicting batch to be rejected")
+	}
+	srv.Get("/plugins/search").Do().AssertBody("v2")
+}
+
+func TestForwardedProto(t *testing.T) {
+	scheme := func(c *blaze.Context) error {
+		return c.Text(c.Scheme())
+	}
+
+	untrusted := blaze.New()
+	untrusted.GET("/", scheme)
+	blazetest.New(t, untrusted).Get("/").
+		WithHeader("X-Forwarded-Proto", "https").
+		Do().
+		AssertBody("http")
+
+	config := blaze.DefaultConfig()
+	// The in-memory listener reports 0.0.0.0 as the remote address
+	config.TrustedProxies = []string{"0.0.0.0/8"}
+	trusted := blaze.NewWithConfig(config)
+	trusted.GET("/", scheme)
+	blazetest.New(t, trusted).Get("/").
+		WithHeader("X-Forwarded-Proto", "https").
+		Do().
+		AssertBody("https")
+}
@@ -67,6 +67,9 @@ func NewServer(config *Config) *Server {
 			WriteTimeout:       config.WriteTimeout,
 			MaxRequestBodySize: config.MaxRequestBodySize,
 			Concurrency:        config.Concurrency,
+			StreamRequestBody:  config.StreamRequestBody,
+
+			DisablePreParseMultipartForm: true,
 		},
 		config: config,
 	}
@@ -0,0 +1,396 @@
+package blaze
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ==================== Server-Sent Events ====================
+
+// SSEConfig configures Server-Sent Events streams
+type SSEConfig struct {
+	// HeartbeatInterval is the interval of comment lines sent to keep
+	// proxies from closing idle streams and to detect disconnected clients
+	// 0 disables heartbeats.
+	// Default: 15 seconds
+	HeartbeatInterval time.Duration
+
+	// Retry is the reconnection delay sent to the client when the stream
+	// opens (the retry field)
+	// 0 leaves the browser default (about 3 seconds).
+	Retry time.Duration
+}
+
+// DefaultSSEConfig returns the default Server-Sent Events configuration
+//
+// Returns:
+//   - SSEConfig: Configuration with 15 second heartbeats
+func DefaultSSEConfig() SSEConfig {
+	return SSEConfig{
+		HeartbeatInterval: 15 * time.Second,
+	}
+}
+
+// SSEEvent is a single Server-Sent Event
+type SSEEvent struct {
+	// ID sets the event ID, sent back by reconnecting clients in the
+	// Last-Event-ID header
+	ID string
+
+	// Event is the event type (addEventListener name); empty means "message"
+	Event string
+
+	// Data is the event payload; each line is sent as a data field
+	Data string
+
+	// Retry changes the client's reconnection delay
+	Retry time.Duration
+}
+
+// SSE streams Server-Sent Events with the default configuration
+// The response is sent with Content-Type text/event-stream and fn runs
+// once the headers are written. Every event is flushed to the client as
+// it is sent.
+//
+// fn runs after the handler returns, so it must use the SSEWriter rather
+// than c. It should return when w.Done() is closed: the client
+// disconnected (detected by a failed write or heartbeat) or the app
+// began graceful shutdown. Writes after that return ErrStreamClosed.
+// Errors returned by fn are logged, since the status has already been
+// sent.
+//
+// Parameters:
+//   - fn: Function writing the events
+//
+// Returns:
+//   - error: Always nil
+//
+// Example:
+//
+//	app.GET("/events", func(c *blaze.Context) error {
+//	    return c.SSE(func(w *blaze.SSEWriter) error {
+//	        ticker := time.NewTicker(time.Second)
+//	        defer ticker.Stop()
+//	        for {
+//	            select {
+//	            case <-w.Done():
+//	                return nil
+//	            case t := <-ticker.C:
+//	                if err := w.Data(t.Format(time.RFC3339)); err != nil {
+//	                    return err
+//	                }
+//	            }
+//	        }
+//	    })
+//	})
+func (c *Context) SSE(fn func(w *SSEWriter) error) error {
+	return c.SSEWithConfig(DefaultSSEConfig(), fn)
+}
+
+// SSEWithConfig streams Server-Sent Events with a custom configuration
+// See SSE for the stream lifecycle.
+//
+// Parameters:
+//   - config: Heartbeat and retry settings
+//   - fn: Function writing the events
+//
+// Returns:
+//   - error: Always nil
+//
+// Example:
+//
+//	config := blaze.DefaultSSEConfig()
+//	config.Retry = 5 * time.Second
+//	return c.SSEWithConfig(config, func(w *blaze.SSEWriter) error {
+//	    for _, event := range history.Since(w.LastEventID()) {
+//	        if err := w.Send(event); err != nil {
+//	            return err
+//	        }
+//	    }
+//	    <-w.Done()
+//	    return nil
+//	})
+func (c *Context) SSEWithConfig(config SSEConfig, fn func(w *SSEWriter) error) error {
+	c.SetContentType("text/event-stream")
+	c.SetHeader("Cache-Control", "no-cache")
+	// Keep reverse proxies such as nginx from buffering the stream
+	c.SetHeader("X-Accel-Buffering", "no")
+
+	// The stream outlives the handler and the pooled context, so
+	// everything it needs is captured here
+	ctx, cancel := context.WithCancel(c.ShutdownContext())
+	w := &SSEWriter{
+		lastEventID: c.Header("Last-Event-ID"),
+		encoder:     c.jsonEncoder(),
+		deadline:    c.streamDeadline(),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	route := c.Method() + " " + c.Path()
+
+	c.RequestCtx.SetBodyStreamWriter(func(bw *bufio.Writer) {
+		w.run(bw, config, route, fn)
+	})
+	return nil
+}
+
+// SSEWriter writes Server-Sent Events to a client
+// All methods are safe for concurrent use.
+type SSEWriter struct {
+	lastEventID string
+	encoder     JSONEncoder
+	deadline    streamDeadline
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	bw     *bufio.Writer
+	closed bool
+}
+
+// LastEventID returns the Last-Event-ID header sent by a reconnecting
+// client, or "" on the first connection
+// Resume the stream after this event to avoid gaps.
+//
+// Returns:
+//   - string: ID of the last event the client received
+func (w *SSEWriter) LastEventID() string {
+	return w.lastEventID
+}
+
+// Context returns a context canceled when the stream ends
+// The stream ends when the client disconnects, the app begins graceful
+// shutdown or the stream function returns.
+//
+// Returns:
+//   - context.Context: Stream context
+func (w *SSEWriter) Context() context.Context {
+	return w.ctx
+}
+
+// Done returns a channel closed when the stream ends
+//
+// Returns:
+//   - <-chan struct{}: Channel closed when the stream ends
+func (w *SSEWriter) Done() <-chan struct{} {
+	return w.ctx.Done()
+}
+
+// Send writes an event and flushes it to the client
+// Data containing newlines is sent as several data lines, which the
+// client joins back with "\n".
+//
+// Parameters:
+//   - event: Event to send
+//
+// Returns:
+//   - error: ErrStreamClosed, a write error or an invalid ID or event name
+//
+// Example:
+//
+//	w.Send(blaze.SSEEvent{ID: "42", Event: "order", Data: "shipped"})
+func (w *SSEWriter) Send(event SSEEvent) error {
+	if err := validateSSEEvent(event); err != nil {
+		return err
+	}
+	return w.write(appendSSEEvent(nil, event))
+}
+
+// Data sends an unnamed event with the given data
+//
+// Parameters:
+//   - data: Event payload
+//
+// Returns:
+//   - error: ErrStreamClosed or a write error
+func (w *SSEWriter) Data(data string) error {
+	return w.Send(SSEEvent{Data: data})
+}
+
+// JSON sends an event with v encoded by the app's JSON encoder
+//
+// Parameters:
+//   - event: Event type ("" for "message")
+//   - v: Value to encode
+//
+// Returns:
+//   - error: Encoding error, ErrStreamClosed or a write error
+//
+// Example:
+//
+//	w.JSON("price", blaze.Map{"symbol": "ACME", "price": 12.5})
+func (w *SSEWriter) JSON(event string, v interface{}) error {
+	data, err := w.encoder.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return w.Send(SSEEvent{Event: event, Data: string(data)})
+}
+
+// Comment sends a comment line, which clients ignore
+//
+// Parameters:
+//   - text: Comment text
+//
+// Returns:
+//   - error: ErrStreamClosed or a write error
+func (w *SSEWriter) Comment(text string) error {
+	var b []byte
+	for _, line := range splitSSELines(text) {
+		b = append(b, ": "...)
+		b = append(b, line...)
+		b = append(b, '\n')
+	}
+	return w.write(append(b, '\n'))
+}
+
+// Retry changes the client's reconnection delay
+//
+// Parameters:
+//   - delay: Reconnection delay
+//
+// Returns:
+//   - error: ErrStreamClosed or a write error
+func (w *SSEWriter) Retry(delay time.Duration) error {
+	return w.write(appendSSERetry(nil, delay))
+}
+
+// run serves the stream until fn returns
+func (w *SSEWriter) run(bw *bufio.Writer, config SSEConfig, route string, fn func(w *SSEWriter) error) {
+	defer w.close()
+
+	w.mu.Lock()
+	w.bw = bw
+	w.mu.Unlock()
+
+	// Send the headers right away, so the client sees the stream open
+	var opening []byte
+	if config.Retry > 0 {
+		opening = appendSSERetry(opening, config.Retry)
+	} else {
+		opening = append(opening, ":\n\n"...)
+	}
+	if w.write(opening) != nil {
+		return
+	}
+
+	var heartbeats sync.WaitGroup
+	if config.HeartbeatInterval > 0 {
+		heartbeats.Add(1)
+		go func() {
+			defer heartbeats.Done()
+			w.heartbeat(config.HeartbeatInterval)
+		}()
+	}
+	defer heartbeats.Wait()
+	defer w.cancel()
+
+	runStream(route, func() error {
+		return fn(w)
+	})
+}
+
+// heartbeat sends a comment every interval until the stream ends
+func (w *SSEWriter) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			if w.write([]byte(": heartbeat\n\n")) != nil {
+				return
+			}
+		}
+	}
+}
+
+// write writes and flushes b, ending the stream on failure
+func (w *SSEWriter) write(b []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed || w.ctx.Err() != nil {
+		return ErrStreamClosed
+	}
+
+	w.deadline.extend()
+	_, err := w.bw.Write(b)
+	if err == nil {
+		err = w.bw.Flush()
+	}
+	if err != nil {
+		// The client is gone
+		w.cancel()
+		return ErrStreamClosed
+	}
+	return nil
+}
+
+// close ends the stream; the buffered writer is reused by the server
+// afterwards, so no write may reach it
+func (w *SSEWriter) close() {
+	w.cancel()
+	w.mu.Lock()
+	w.closed = true
+	w.bw = nil
+	w.mu.Unlock()
+}
+
+// validateSSEEvent rejects IDs and event names that would break the
+// event framing
+func validateSSEEvent(event SSEEvent) error {
+	if strings.ContainsAny(event.ID, "\r\n\x00") {
+		return fmt.Errorf("blaze: invalid SSE event ID %q", event.ID)
+	}
+	if strings.ContainsAny(event.Event, "\r\n") {
+		return fmt.Errorf("blaze: invalid SSE event name %q", event.Event)
+	}
+	return nil
+}
+
+// appendSSEEvent appends the wire form of event to b
+func appendSSEEvent(b []byte, event SSEEvent) []byte {
+	if event.ID != "" {
+		b = append(b, "id: "...)
+		b = append(b, event.ID...)
+		b = append(b, '\n')
+	}
+	if event.Event != "" {
+		b = append(b, "event: "...)
+		b = append(b, event.Event...)
+		b = append(b, '\n')
+	}
+	if event.Retry > 0 {
+		b = strconv.AppendInt(append(b, "retry: "...), event.Retry.Milliseconds(), 10)
+		b = append(b, '\n')
+	}
+	for _, line := range splitSSELines(event.Data) {
+		b = append(b, "data: "...)
+		b = append(b, line...)
+		b = append(b, '\n')
+	}
+	return append(b, '\n')
+}
+
+// appendSSERetry appends a retry-only message to b
+func appendSSERetry(b []byte, delay time.Duration) []byte {
+	b = strconv.AppendInt(append(b, "retry: "...), delay.Milliseconds(), 10)
+	return append(b, "\n\n"...)
+}
+
+// splitSSELines splits s at CRLF, CR and LF, the line endings of the
+// event stream format
+func splitSSELines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,494 @@
+package blaze
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ==================== SSE Broker ====================
+
+// SSEBackpressure decides what happens to an event published to a
+// subscriber whose queue is full
+type SSEBackpressure int
+
+const (
+	// SSEDisconnect ends the slow subscriber's stream
+	// The client reconnects and resumes from the replay buffer with
+	// Last-Event-ID, so no event is lost while it is still buffered.
+	SSEDisconnect SSEBackpressure = iota
+
+	// SSEDropOldest discards the oldest queued event to make room
+	SSEDropOldest
+
+	// SSEDropNewest discards the event being published
+	SSEDropNewest
+)
+
+// String returns the name of the policy
+func (p SSEBackpressure) String() string {
+	switch p {
+	case SSEDisconnect:
+		return "disconnect"
+	case SSEDropOldest:
+		return "drop-oldest"
+	case SSEDropNewest:
+		return "drop-newest"
+	default:
+		return "SSEBackpressure(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// SSEBrokerConfig configures an SSE broker
+type SSEBrokerConfig struct {
+	// ReplaySize is the number of recent events kept per topic for
+	// clients resuming with Last-Event-ID
+	// 0 disables replay.
+	// Default: 100
+	ReplaySize int
+
+	// BufferSize is the number of events queued per subscriber before
+	// Backpressure applies
+	// Default: 64
+	BufferSize int
+
+	// Backpressure is the policy for subscribers whose queue is full
+	// Each subscriber has its own queue, so a slow client never delays
+	// the others.
+	// Default: SSEDisconnect
+	Backpressure SSEBackpressure
+
+	// SSE configures the streams served by Subscribe
+	// Default: DefaultSSEConfig()
+	SSE SSEConfig
+}
+
+// DefaultSSEBrokerConfig returns the default broker configuration
+//
+// Returns:
+//   - SSEBrokerConfig: Configuration with a 100 event replay buffer per
+//     topic, 64 event subscriber queues and the disconnect policy
+func DefaultSSEBrokerConfig() SSEBrokerConfig {
+	return SSEBrokerConfig{
+		ReplaySize:   100,
+		BufferSize:   64,
+		Backpressure: SSEDisconnect,
+		SSE:          DefaultSSEConfig(),
+	}
+}
+
+// SSEBroker fans Server-Sent Events out to the subscribers of topics
+// Published events are kept in a bounded replay buffer per topic, so
+// reconnecting clients receive the events they missed. The broker is
+// safe for concurrent use and needs no goroutine of its own.
+type SSEBroker struct {
+	config SSEBrokerConfig
+
+	mu     sync.RWMutex
+	topics map[string]*sseTopic
+	seq    uint64
+	closed bool
+
+	dropped      atomic.Uint64
+	disconnected atomic.Uint64
+}
+
+// SSEBrokerStats is a snapshot of broker metrics
+type SSEBrokerStats struct {
+	// Subscribers is the number of open subscriptions
+	Subscribers int `json:"subscribers"`
+
+	// Topics holds the metrics of every known topic
+	Topics map[string]SSETopicStats `json:"topics"`
+
+	// Dropped counts events discarded by SSEDropOldest and SSEDropNewest
+	Dropped uint64 `json:"dropped"`
+
+	// Disconnected counts subscribers ended by SSEDisconnect
+	Disconnected uint64 `json:"disconnected"`
+}
+
+// SSETopicStats holds the metrics of one topic
+type SSETopicStats struct {
+	// Subscribers is the number of subscriptions to the topic
+	Subscribers int `json:"subscribers"`
+
+	// Published counts events published to the topic
+	Published uint64 `json:"published"`
+
+	// Buffered is the number of events in the replay buffer
+	Buffered int `json:"buffered"`
+}
+
+// sseTopic holds the subscribers and replay buffer of a topic
+type sseTopic struct {
+	subscribers map[*sseSubscriber]struct{}
+	replay      []sseEntry // ring buffer, oldest event at start
+	start       int
+	published   uint64
+}
+
+// sseEntry is a published event with its position in the broker
+type sseEntry struct {
+	seq   uint64
+	event SSEEvent
+}
+
+// sseSubscriber is one subscription stream
+type sseSubscriber struct {
+	topics    []string
+	queue     chan sseEntry
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSSEBroker creates a broker with the default configuration
+//
+// Returns:
+//   - *SSEBroker: New broker
+//
+// Example:
+//
+//	broker := blaze.NewSSEBroker()
+//
+//	app.GET("/dashboards/:id/events", func(c *blaze.Context) error {
+//	    return broker.Subscribe(c, "dashboard:"+c.Param("id"), "alerts")
+//	})
+//
+//	broker.Publish("alerts", blaze.SSEEvent{Event: "alert", Data: "disk full"})
+func NewSSEBroker() *SSEBroker {
+	return NewSSEBrokerWithConfig(DefaultSSEBrokerConfig())
+}
+
+// NewSSEBrokerWithConfig creates a broker with a custom configuration
+//
+// Parameters:
+//   - config: Replay, queue and backpressure settings
+//
+// Returns:
+//   - *SSEBroker: New broker
+//
+// Example:
+//
+//	config := blaze.DefaultSSEBrokerConfig()
+//	config.ReplaySize = 1000
+//	config.Backpressure = blaze.SSEDropOldest
+//	broker := blaze.NewSSEBrokerWithConfig(config)
+func NewSSEBrokerWithConfig(config SSEBrokerConfig) *SSEBroker {
+	if config.ReplaySize < 0 {
+		config.ReplaySize = 0
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1
+	}
+	return &SSEBroker{
+		config: config,
+		topics: make(map[string]*sseTopic),
+	}
+}
+
+// Subscribe streams the events of topics to the client
+// The request becomes an SSE stream (see Context.SSE). A client
+// reconnecting with Last-Event-ID first receives the buffered events it
+// missed, in publication order; when the ID is no longer buffered, all
+// buffered events of the topics are replayed. The stream then carries
+// new events until the client disconnects, the app shuts down, the
+// backpressure policy ends it or the broker is closed.
+//
+// Parameters:
+//   - c: Request context
+//   - topics: Topics to receive events from
+//
+// Returns:
+//   - error: Error when no topic is given or the broker is closed
+//
+// Example:
+//
+//	app.GET("/events", func(c *blaze.Context) error {
+//	    user := c.Locals("user").(*User)
+//	    return broker.Subscribe(c, "news", "user:"+user.ID)
+//	})
+func (b *SSEBroker) Subscribe(c *Context, topics ...string) error {
+	if len(topics) == 0 {
+		return errors.New("blaze: SSE subscription needs at least one topic")
+	}
+	topics = slices.Compact(slices.Sorted(slices.Values(topics)))
+
+	b.mu.RLock()
+	closed := b.closed
+	b.mu.RUnlock()
+	if closed {
+		return ErrServiceUnavailable("Event stream is closed")
+	}
+
+	return c.SSEWithConfig(b.config.SSE, func(w *SSEWriter) error {
+		sub := &sseSubscriber{
+			topics: topics,
+			queue:  make(chan sseEntry, b.config.BufferSize),
+			done:   make(chan struct{}),
+		}
+
+		replay, ok := b.subscribe(sub, w.LastEventID())
+		if !ok {
+			return nil
+		}
+		defer b.unsubscribe(sub)
+
+		for _, entry := range replay {
+			if err := w.Send(entry.event); err != nil {
+				return err
+			}
+		}
+
+		for {
+			select {
+			case <-w.Done():
+				return nil
+			case <-sub.done:
+				return nil
+			case entry := <-sub.queue:
+				if err := w.Send(entry.event); err != nil {
+					return err
+				}
+			}
+		}
+	})
+}
+
+// Publish sends an event to the subscribers of a topic
+// Events without an ID are given one that increases with every event
+// published to the broker. The event is added to the topic's replay
+// buffer even when nobody is subscribed.
+//
+// Parameters:
+//   - topic: Topic to publish to
+//   - event: Event to send
+//
+// Returns:
+//   - error: Invalid event ID or name
+//
+// Example:
+//
+//	broker.Publish("orders", blaze.SSEEvent{Event: "created", Data: string(payload)})
+func (b *SSEBroker) Publish(topic string, event SSEEvent) error {
+	if err := validateSSEEvent(event); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	if event.ID == "" {
+		event.ID = strconv.FormatUint(b.seq, 10)
+	}
+	entry := sseEntry{seq: b.seq, event: event}
+
+	t := b.topic(topic)
+	t.published++
+	if b.config.ReplaySize > 0 {
+		if len(t.replay) < b.config.ReplaySize {
+			t.replay = append(t.replay, entry)
+		} else {
+			t.replay[t.start] = entry
+			t.start = (t.start + 1) % len(t.replay)
+		}
+	}
+
+	for sub := range t.subscribers {
+		b.deliver(sub, entry)
+	}
+	if len(t.subscribers) == 0 && len(t.replay) == 0 {
+		delete(b.topics, topic)
+	}
+	return nil
+}
+
+// SubscriberCount returns the number of subscriptions to a topic
+//
+// Parameters:
+//   - topic: Topic name
+//
+// Returns:
+//   - int: Number of subscribers
+func (b *SSEBroker) SubscriberCount(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if t, ok := b.topics[topic]; ok {
+		return len(t.subscribers)
+	}
+	return 0
+}
+
+// Stats returns a snapshot of the broker metrics
+//
+// Returns:
+//   - SSEBrokerStats: Subscribers, per-topic metrics and drop counters
+//
+// Example:
+//
+//	app.GET("/debug/sse", func(c *blaze.Context) error {
+//	    return c.JSON(broker.Stats())
+//	})
+func (b *SSEBroker) Stats() SSEBrokerStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := SSEBrokerStats{
+		Topics:       make(map[string]SSETopicStats, len(b.topics)),
+		Dropped:      b.dropped.Load(),
+		Disconnected: b.disconnected.Load(),
+	}
+
+	subscribers := make(map[*sseSubscriber]struct{})
+	for name, t := range b.topics {
+		stats.Topics[name] = SSETopicStats{
+			Subscribers: len(t.subscribers),
+			Published:   t.published,
+			Buffered:    len(t.replay),
+		}
+		for sub := range t.subscribers {
+			subscribers[sub] = struct{}{}
+		}
+	}
+	stats.Subscribers = len(subscribers)
+	return stats
+}
+
+// Close ends every subscription and rejects new ones
+// Publish keeps filling the replay buffers.
+func (b *SSEBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, t := range b.topics {
+		for sub := range t.subscribers {
+			sub.close()
+		}
+	}
+}
+
+// subscribe registers sub and returns the events to replay after
+// lastEventID, atomically with respect to Publish so no event is missed
+// or sent twice
+func (b *SSEBroker) subscribe(sub *sseSubscriber, lastEventID string) ([]sseEntry, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, false
+	}
+
+	var replay []sseEntry
+	if lastEventID != "" {
+		after, found := uint64(0), false
+		for _, name := range sub.topics {
+			if t, ok := b.topics[name]; ok {
+				for _, entry := range t.replay {
+					if entry.event.ID == lastEventID {
+						after, found = entry.seq, true
+					}
+				}
+			}
+		}
+
+		// An unknown ID fell out of the buffers (or predates a restart),
+		// so everything still buffered may have been missed
+		for _, name := range sub.topics {
+			if t, ok := b.topics[name]; ok {
+				for _, entry := range t.replay {
+					if !found || entry.seq > after {
+						replay = append(replay, entry)
+					}
+				}
+			}
+		}
+		slices.SortFunc(replay, func(x, y sseEntry) int {
+			return cmp.Compare(x.seq, y.seq)
+		})
+	}
+
+	for _, name := range sub.topics {
+		b.topic(name).subscribers[sub] = struct{}{}
+	}
+	return replay, true
+}
+
+// unsubscribe removes sub from its topics
+func (b *SSEBroker) unsubscribe(sub *sseSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(sub)
+}
+
+// remove removes sub from its topics
+// Must be called with b.mu held for writing.
+func (b *SSEBroker) remove(sub *sseSubscriber) {
+	for _, name := range sub.topics {
+		if t, ok := b.topics[name]; ok {
+			delete(t.subscribers, sub)
+			if len(t.subscribers) == 0 && len(t.replay) == 0 {
+				delete(b.topics, name)
+			}
+		}
+	}
+}
+
+// topic returns the topic with the given name, creating it if needed
+// Must be called with b.mu held for writing.
+func (b *SSEBroker) topic(name string) *sseTopic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &sseTopic{subscribers: make(map[*sseSubscriber]struct{})}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// deliver queues entry for sub, applying the backpressure policy when
+// the queue is full
+func (b *SSEBroker) deliver(sub *sseSubscriber, entry sseEntry) {
+	select {
+	case sub.queue <- entry:
+		return
+	default:
+	}
+
+	switch b.config.Backpressure {
+	case SSEDropOldest:
+		select {
+		case <-sub.queue:
+			b.dropped.Add(1)
+		default:
+		}
+		select {
+		case sub.queue <- entry:
+		default:
+			b.dropped.Add(1)
+		}
+	case SSEDropNewest:
+		b.dropped.Add(1)
+	default:
+		// The stream may stay blocked writing to the client for a while,
+		// so the subscriber stops counting right away
+		b.remove(sub)
+		if sub.close() {
+			b.disconnected.Add(1)
+		}
+	}
+}
+
+// close ends the subscription; it reports whether this call closed it
+func (s *sseSubscriber) close() bool {
+	closed := false
+	s.closeOnce.Do(func() {
+		close(s.done)
+		closed = true
+	})
+	return closed
+}
@@ -0,0 +1,204 @@
+package blaze_test
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+// openStream sends a GET request over a raw connection and returns a reader
+// positioned at the start of the body, so a streamed response can be read
+// while the server is still writing it
+func openStream(t *testing.T, srv *blazetest.Server, path string, headers ...string) *bufio.Reader {
+	t.Helper()
+
+	conn, err := srv.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	request := fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\n", path, srv.Host)
+	for i := 0; i+1 < len(headers); i += 2 {
+		request += headers[i] + ": " + headers[i+1] + "\r\n"
+	}
+	if _, err := fmt.Fprint(conn, request+"\r\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading response headers: %v", err)
+		}
+		if line == "\r\n" {
+			return reader
+		}
+	}
+}
+
+// readSSEEvent reads the next event from a chunked event stream, skipping
+// comments and retry-only messages
+// Chunk size lines never start with a field name, so they are skipped too.
+func readSSEEvent(t *testing.T, r *bufio.Reader) map[string]string {
+	t.Helper()
+
+	event := map[string]string{}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading event: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		name, value, ok := strings.Cut(line, ": ")
+		switch {
+		case line == "" && event["data"] != "":
+			return event
+		case ok && (name == "id" || name == "event" || name == "data"):
+			if name == "data" && event["data"] != "" {
+				value = event["data"] + "\n" + value
+			}
+			event[name] = value
+		}
+	}
+}
+
+func TestSSE(t *testing.T) {
+	app := blaze.New()
+	app.GET("/events", func(c *blaze.Context) error {
+		config := blaze.DefaultSSEConfig()
+		config.Retry = 2 * time.Second
+
+		return c.SSEWithConfig(config, func(w *blaze.SSEWriter) error {
+			w.Send(blaze.SSEEvent{ID: "1", Event: "order", Data: "created\nshipped"})
+			w.JSON("price", blaze.Map{"symbol": "ACME"})
+			w.Comment("keep-alive")
+			return w.Data("last after " + w.LastEventID())
+		})
+	})
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/events").
+		WithHeader("Last-Event-ID", "41").
+		Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "text/event-stream").
+		AssertHeader("Cache-Control", "no-cache").
+		AssertBody("retry: 2000\n\n" +
+			"id: 1\nevent: order\ndata: created\ndata: shipped\n\n" +
+			"event: price\ndata: {\"symbol\":\"ACME\"}\n\n" +
+			": keep-alive\n\n" +
+			"data: last after 41\n\n")
+}
+
+func TestSSERejectsInvalidEvents(t *testing.T) {
+	errs := make(chan error, 1)
+
+	app := blaze.New()
+	app.GET("/events", func(c *blaze.Context) error {
+		return c.SSE(func(w *blaze.SSEWriter) error {
+			errs <- w.Send(blaze.SSEEvent{ID: "1\ndata: injected", Data: "x"})
+			return nil
+		})
+	})
+
+	blazetest.New(t, app).Get("/events").Do().AssertBody(":\n\n")
+	if err := <-errs; err == nil {
+		t.Error("expected an error for an ID containing a newline")
+	}
+}
+
+func TestSSEClientDisconnect(t *testing.T) {
+	done := make(chan error, 1)
+
+	app := blaze.New()
+	app.GET("/events", func(c *blaze.Context) error {
+		config := blaze.DefaultSSEConfig()
+		config.HeartbeatInterval = 10 * time.Millisecond
+
+		return c.SSEWithConfig(config, func(w *blaze.SSEWriter) error {
+			w.Data("hello")
+			select {
+			case <-w.Done():
+				// Writes after the client left fail
+				done <- w.Data("gone")
+			case <-time.After(5 * time.Second):
+				done <- nil
+			}
+			return nil
+		})
+	})
+
+	srv := blazetest.New(t, app)
+	conn, err := srv.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintf(conn, "GET /events HTTP/1.1\r\nHost: %s\r\n\r\n", srv.Host)
+	reader := bufio.NewReader(conn)
+	if event := readSSEEvent(t, reader); event["data"] != "hello" {
+		t.Fatalf("got event %v", event)
+	}
+	conn.Close()
+
+	// The next heartbeat notices the disconnect
+	if err := <-done; err == nil {
+		t.Error("stream was not closed after the client disconnected")
+	}
+}
+
+func TestSSEBrokerReplay(t *testing.T) {
+	config := blaze.DefaultSSEBrokerConfig()
+	config.ReplaySize = 2
+	broker := blaze.NewSSEBrokerWithConfig(config)
+
+	app := blaze.New()
+	app.GET("/news", func(c *blaze.Context) error {
+		return broker.Subscribe(c, "news")
+	})
+
+	srv := blazetest.New(t, app)
+	// Streams end with the broker, before the server shuts down
+	t.Cleanup(broker.Close)
+
+	for i := 1; i <= 3; i++ {
+		broker.Publish("news", blaze.SSEEvent{Data: fmt.Sprintf("story %d", i)})
+	}
+	broker.Publish("sports", blaze.SSEEvent{Data: "score"})
+
+	// Event 1 has left the replay buffer, so everything buffered is sent
+	stream := openStream(t, srv, "/news", "Last-Event-ID", "1")
+	for _, want := range []string{"story 2", "story 3"} {
+		if event := readSSEEvent(t, stream); event["data"] != want {
+			t.Fatalf("replayed %v, want %q", event, want)
+		}
+	}
+
+	// A client resuming from a buffered ID only gets what it missed
+	resumed := openStream(t, srv, "/news", "Last-Event-ID", "2")
+	if event := readSSEEvent(t, resumed); event["data"] != "story 3" || event["id"] != "3" {
+		t.Fatalf("resumed with %v, want story 3", event)
+	}
+
+	waitFor(t, func() bool { return broker.SubscriberCount("news") == 2 })
+	broker.Publish("news", blaze.SSEEvent{ID: "live", Event: "breaking", Data: "story 4"})
+
+	for _, r := range []*bufio.Reader{stream, resumed} {
+		event := readSSEEvent(t, r)
+		if event["data"] != "story 4" || event["id"] != "live" || event["event"] != "breaking" {
+			t.Errorf("live event %v, want story 4", event)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package blaze
+
+import (
+	"bufio"
+	"errors"
+	"iter"
+	"log"
+	"net"
+	"time"
+)
+
+// ==================== Streaming Responses ====================
+
+// ErrStreamClosed is returned by writes to a stream that has ended,
+// usually because the client disconnected
+var ErrStreamClosed = errors.New("stream closed")
+
+// StreamWriter streams the response body written by fn
+// fn runs after the handler returns, once the status and headers have
+// been sent, and writes the body incrementally: every Flush sends the
+// buffered data to the client. Set the status and Content-Type before
+// calling StreamWriter; the default is text/plain.
+//
+// Writes fail with ErrStreamClosed once the client has gone away, so fn
+// should return on the first write error. fn must not use c, which is
+// recycled for other requests by then. Errors returned by fn are logged,
+// since the status has already been sent, and the response is cut short.
+//
+// Parameters:
+//   - fn: Function writing the body
+//
+// Returns:
+//   - error: Always nil
+//
+// Example:
+//
+//	c.SetContentType("text/csv")
+//	return c.StreamWriter(func(w *bufio.Writer) error {
+//	    for rows.Next() {
+//	        fmt.Fprintf(w, "%d,%s\n", id, name)
+//	        if err := w.Flush(); err != nil {
+//	            return err
+//	        }
+//	    }
+//	    return rows.Err()
+//	})
+func (c *Context) StreamWriter(fn func(w *bufio.Writer) error) error {
+	// The stream outlives the handler and the pooled context, so
+	// everything it needs is captured here
+	deadline := c.streamDeadline()
+	route := c.Method() + " " + c.Path()
+
+	c.RequestCtx.SetBodyStreamWriter(func(bw *bufio.Writer) {
+		w := bufio.NewWriter(&streamBody{bw: bw, deadline: deadline})
+		runStream(route, func() error {
+			if err := fn(w); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+	})
+	return nil
+}
+
+// NDJSON streams the values of seq as newline-delimited JSON
+// Each value is encoded by the app's JSON encoder and flushed to the
+// client as soon as seq yields it, so large result sets are never held
+// in memory. The response is sent with Content-Type
+// application/x-ndjson. Iteration stops when the client goes away.
+//
+// seq is consumed after the handler returns and must not use c. An
+// encoding error ends the response early and is logged.
+//
+// Parameters:
+//   - c: Request context
+//   - seq: Values to stream
+//
+// Returns:
+//   - error: Always nil
+//
+// Example:
+//
+//	app.GET("/export", func(c *blaze.Context) error {
+//	    return blaze.NDJSON(c, store.AllOrders())
+//	})
+func NDJSON[T any](c *Context, seq iter.Seq[T]) error {
+	encoder := c.jsonEncoder()
+	c.SetContentType("application/x-ndjson")
+
+	return c.StreamWriter(func(w *bufio.Writer) error {
+		for item := range seq {
+			data, err := encoder.Marshal(item)
+			if err != nil {
+				return err
+			}
+			w.Write(data)
+			w.WriteByte('\n')
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// JSONArrayStream streams the values of seq as a JSON array
+// Each value is encoded by the app's JSON encoder and flushed to the
+// client as soon as seq yields it, so the body is a regular JSON array
+// that never has to be held in memory. The response is sent with
+// Content-Type application/json. Iteration stops when the client goes
+// away.
+//
+// seq is consumed after the handler returns and must not use c. An
+// encoding error ends the response early, leaving the array unclosed so
+// clients see invalid JSON rather than a silently truncated list.
+//
+// Parameters:
+//   - c: Request context
+//   - seq: Values to stream
+//
+// Returns:
+//   - error: Always nil
+//
+// Example:
+//
+//	app.GET("/users", func(c *blaze.Context) error {
+//	    return blaze.JSONArrayStream(c, users.Iterate(ctx))
+//	})
+func JSONArrayStream[T any](c *Context, seq iter.Seq[T]) error {
+	encoder := c.jsonEncoder()
+	c.SetContentType("application/json; charset=utf-8")
+
+	return c.StreamWriter(func(w *bufio.Writer) error {
+		w.WriteByte('[')
+		first := true
+		for item := range seq {
+			data, err := encoder.Marshal(item)
+			if err != nil {
+				return err
+			}
+			if !first {
+				w.WriteByte(',')
+			}
+			first = false
+			w.Write(data)
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	})
+}
+
+// runStream runs the body of a streamed response, logging its error or
+// panic: the status has been sent, so neither can reach the client
+func runStream(route string, fn func() error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("blaze: panic in stream %s: %v", route, r)
+		}
+	}()
+	if err := fn(); err != nil && !errors.Is(err, ErrStreamClosed) {
+		log.Printf("blaze: stream %s: %v", route, err)
+	}
+}
+
+// streamBody passes writes to the server's body stream, flushing each
+// one so the client receives it immediately
+type streamBody struct {
+	bw       *bufio.Writer
+	deadline streamDeadline
+	closed   bool
+}
+
+func (s *streamBody) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, ErrStreamClosed
+	}
+
+	s.deadline.extend()
+	n, err := s.bw.Write(p)
+	if err == nil {
+		err = s.bw.Flush()
+	}
+	if err != nil {
+		// The client is gone
+		s.closed = true
+		return n, ErrStreamClosed
+	}
+	return n, nil
+}
+
+// streamDeadline extends the write deadline of a streamed response
+// The server sets Config.WriteTimeout once for the whole response, which
+// would cut long-lived streams short, so streams extend it before every
+// write instead. A stalled client still times out.
+type streamDeadline struct {
+	conn    net.Conn
+	timeout time.Duration
+}
+
+// streamDeadline returns the write deadline of the request's connection
+func (c *Context) streamDeadline() streamDeadline {
+	var timeout time.Duration
+	if app, ok := c.Locals("__app__").(*App); ok {
+		// The server is configured by the outermost app
+		for app.parent != nil {
+			app = app.parent
+		}
+		timeout = app.config.WriteTimeout
+	}
+	return streamDeadline{conn: c.RequestCtx.Conn(), timeout: timeout}
+}
+
+// extend moves the write deadline one timeout into the future
+func (d streamDeadline) extend() {
+	if d.conn != nil && d.timeout > 0 {
+		d.conn.SetWriteDeadline(time.Now().Add(d.timeout))
+	}
+}
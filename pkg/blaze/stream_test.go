@@ -0,0 +1,207 @@
+package blaze_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+// count yields 1 to n
+func count(n int) iter.Seq[blaze.Map] {
+	return func(yield func(blaze.Map) bool) {
+		for i := 1; i <= n; i++ {
+			if !yield(blaze.Map{"n": i}) {
+				return
+			}
+		}
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	app := blaze.New()
+	app.GET("/export", func(c *blaze.Context) error {
+		return blaze.NDJSON(c, count(3))
+	})
+	app.GET("/list", func(c *blaze.Context) error {
+		return blaze.JSONArrayStream(c, count(3))
+	})
+	app.GET("/empty", func(c *blaze.Context) error {
+		return blaze.JSONArrayStream(c, count(0))
+	})
+
+	srv := blazetest.New(t, app)
+
+	srv.Get("/export").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/x-ndjson").
+		AssertBody("{\"n\":1}\n{\"n\":2}\n{\"n\":3}\n")
+
+	srv.Get("/list").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/json").
+		AssertJSONPath("[2].n", 3)
+
+	srv.Get("/empty").Do().AssertBody("[]")
+}
+
+func TestStreamWriterFlushes(t *testing.T) {
+	next := make(chan struct{})
+
+	app := blaze.New()
+	app.GET("/progress", func(c *blaze.Context) error {
+		c.SetContentType("text/plain")
+		return c.StreamWriter(func(w *bufio.Writer) error {
+			for i := 1; i <= 3; i++ {
+				<-next
+				fmt.Fprintf(w, "step %d\n", i)
+				if err := w.Flush(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+
+	srv := blazetest.New(t, app)
+
+	// The headers go out with the first flush
+	go func() { next <- struct{}{} }()
+	stream := openStream(t, srv, "/progress")
+
+	// Each flushed line arrives before the next one is written
+	for i := 1; i <= 3; i++ {
+		if i > 1 {
+			next <- struct{}{}
+		}
+
+		want := fmt.Sprintf("step %d", i)
+		for {
+			line, err := stream.ReadString('\n')
+			if err != nil {
+				t.Fatalf("waiting for %q: %v", want, err)
+			}
+			if strings.TrimRight(line, "\r\n") == want {
+				break
+			}
+		}
+	}
+}
+
+func TestStreamingRequestBody(t *testing.T) {
+	config := blaze.DefaultConfig()
+	config.StreamRequestBody = true
+	config.MaxRequestBodySize = 1 << 10
+
+	app := blaze.NewWithConfig(config)
+	app.PUT("/upload", func(c *blaze.Context) error {
+		streamed := c.IsBodyStreamed()
+		n, err := io.Copy(io.Discard, c.BodyStream())
+		if err != nil {
+			return err
+		}
+		return c.JSON(blaze.Map{"size": n, "streamed": streamed})
+	}, blaze.WithStreamingBody(), blaze.WithBodyLimit(1<<20))
+
+	srv := blazetest.New(t, app)
+
+	// Larger than MaxRequestBodySize, within the route limit
+	srv.Put("/upload").
+		WithBody("application/octet-stream", bytes.Repeat([]byte("x"), 64<<10)).
+		Do().
+		AssertStatus(http.StatusOK).
+		AssertJSONPath("size", 64<<10).
+		AssertJSONPath("streamed", true)
+
+	res, err := srv.Put("/upload").
+		WithBody("application/octet-stream", bytes.Repeat([]byte("x"), 2<<20)).
+		Send()
+	if err == nil && res.StatusCode() != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized upload: status %d, want 413", res.StatusCode())
+	}
+}
+
+func TestMultipartReader(t *testing.T) {
+	app := blaze.New()
+	app.POST("/upload", func(c *blaze.Context) error {
+		var parts []string
+		err := c.MultipartReader(func(part *blaze.MultipartPart) error {
+			if !part.IsFile() {
+				value, err := part.Value()
+				parts = append(parts, part.FieldName+"="+value)
+				return err
+			}
+			n, err := io.Copy(io.Discard, part)
+			parts = append(parts, fmt.Sprintf("%s:%s:%d", part.FieldName, part.Filename, n))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return c.Text(strings.Join(parts, ","))
+	}, blaze.WithStreamingBody())
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField("title", "report")
+	file, _ := form.CreateFormFile("doc", "report.pdf")
+	file.Write(bytes.Repeat([]byte("%"), 5000))
+	form.WriteField("tag", "q3")
+	form.Close()
+
+	srv := blazetest.New(t, app)
+	srv.Post("/upload").
+		WithBody(form.FormDataContentType(), body.Bytes()).
+		Do().
+		AssertStatus(http.StatusOK).
+		AssertBody("title=report,doc:report.pdf:5000,tag=q3")
+
+	srv.Post("/upload").
+		WithBody("application/json", []byte("{}")).
+		Do().
+		AssertStatus(http.StatusUnsupportedMediaType)
+
+	// A truncated form is rejected instead of ending silently
+	truncated := body.Bytes()[:body.Len()/2]
+	res := srv.Post("/upload").WithBody(form.FormDataContentType(), truncated).Do()
+	if res.StatusCode() == http.StatusOK {
+		t.Errorf("truncated form accepted: %s", res.BodyString())
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	app := blaze.New()
+	app.GET("/broken", func(c *blaze.Context) error {
+		return blaze.JSONArrayStream(c, func(yield func(any) bool) {
+			if yield(1) {
+				yield(make(chan int))
+			}
+		})
+	})
+	app.GET("/failing", func(c *blaze.Context) error {
+		return c.StreamWriter(func(w *bufio.Writer) error {
+			w.WriteString("partial")
+			return errors.New("backend went away")
+		})
+	})
+
+	captureLog(t)
+	srv := blazetest.New(t, app)
+
+	// The array is left unclosed so clients see invalid JSON
+	res := srv.Get("/broken").Do().AssertStatus(http.StatusOK)
+	if body := res.BodyString(); strings.HasSuffix(body, "]") {
+		t.Errorf("broken stream ended as valid JSON: %q", body)
+	}
+
+	srv.Get("/failing").Do().AssertStatus(http.StatusOK)
+}
@@ -0,0 +1,443 @@
+package blaze
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ==================== Reverse Routing ====================
+
+// URL builds the path for this route from parameter values
+// Substitutes :param and *catchall segments and appends unused params as a query string
+//
+// Building Rules:
+//   - Every required :param in the pattern must have a value
+//   - Optional params (:year?) may be omitted, which drops them and
+//     every optional param after them
+//   - Values must satisfy the route's constraints
+//   - A missing *catchall produces an empty tail
+//   - Params not in the pattern are appended as query parameters (sorted)
+//   - Slice values in the query produce repeated keys
+//
+// Parameters:
+//   - params: Parameter values keyed by name (may be nil)
+//
+// Returns:
+//   - string: Escaped path with optional query string
+//   - error: Missing parameter or constraint violation
+//
+// Example:
+//
+//	// Pattern: /users/:id/files/*path
+//	route.URL(blaze.Map{"id": 42, "path": "docs/a b.txt", "v": 2})
+//	// "/users/42/files/docs/a%20b.txt?v=2"
+func (r *Route) URL(params Map) (string, error) {
+	segments := r.segments
+	if segments == nil {
+		parsed, err := parseRoutePattern(r.Pattern)
+		if err != nil {
+			return "", fmt.Errorf("route %s: %w", r.displayName(), err)
+		}
+		segments = parsed.segments
+	}
+
+	used := make(map[string]bool, len(r.Params))
+
+	var b strings.Builder
+	omitted := ""
+	for _, segment := range segments {
+		if segment.optional {
+			name := segment.parts[0].text
+			if formatURLValue(params[name]) == "" {
+				if omitted == "" {
+					omitted = name
+				}
+				if _, ok := params[name]; ok {
+					used[name] = true
+				}
+				continue
+			}
+			if omitted != "" {
+				return "", fmt.Errorf("route %s: parameter %q requires %q", r.displayName(), name, omitted)
+			}
+		}
+
+		b.WriteByte('/')
+		for _, part := range segment.parts {
+			switch {
+			case part.param:
+				name := part.text
+				value, ok := params[name]
+				if !ok {
+					return "", fmt.Errorf("route %s: missing parameter %q", r.displayName(), name)
+				}
+
+				str := formatURLValue(value)
+				if str == "" {
+					return "", fmt.Errorf("route %s: parameter %q must not be empty", r.displayName(), name)
+				}
+				if err := r.checkConstraint(name, str); err != nil {
+					return "", err
+				}
+
+				b.WriteString(url.PathEscape(str))
+				used[name] = true
+
+			case part.catchAll:
+				name := part.text
+				value, ok := params[name]
+				str := strings.TrimPrefix(formatURLValue(value), "/")
+
+				// Matching captures the tail with its leading slash, so
+				// constraints see the same value here
+				if err := r.checkConstraint(name, "/"+str); err != nil {
+					return "", err
+				}
+
+				parts := strings.Split(str, "/")
+				for j, p := range parts {
+					parts[j] = url.PathEscape(p)
+				}
+				b.WriteString(strings.Join(parts, "/"))
+				if ok {
+					used[name] = true
+				}
+
+			default:
+				b.WriteString(part.text)
+			}
+		}
+	}
+
+	if b.Len() == 0 {
+		b.WriteByte('/')
+	}
+
+	if query := buildQuery(params, used); query != "" {
+		b.WriteByte('?')
+		b.WriteString(query)
+	}
+
+	return b.String(), nil
+}
+
+// checkConstraint validates a parameter value against the route's constraint
+func (r *Route) checkConstraint(name, value string) error {
+	constraint, ok := r.Constraints[name]
+	if !ok || constraint.Pattern == nil {
+		return nil
+	}
+
+	if !constraint.Pattern.MatchString(value) {
+		return fmt.Errorf("route %s: parameter %q value %q does not satisfy %s constraint",
+			r.displayName(), name, value, constraint.Type)
+	}
+	return nil
+}
+
+// displayName returns the route name, falling back to method and pattern
+func (r *Route) displayName() string {
+	if r.Name != "" {
+		return fmt.Sprintf("%q", r.Name)
+	}
+	return r.Method + " " + r.Pattern
+}
+
+// URL builds the path for a named route
+//
+// Parameters:
+//   - name: Route name set with WithName
+//   - params: Parameter values (may be nil)
+//
+// Returns:
+//   - string: Escaped path with optional query string
+//   - error: Unknown route name, missing parameter or constraint violation
+//
+// Example:
+//
+//	path, err := router.URL("get_user", blaze.Map{"id": 42})
+func (r *Router) URL(name string, params Map) (string, error) {
+	route, ok := r.GetRoute(name)
+	if !ok {
+		return "", fmt.Errorf("route %q not found", name)
+	}
+	return route.URL(params)
+}
+
+// GetRoute returns the route registered with the given name
+//
+// Parameters:
+//   - name: Route name set with WithName
+//
+// Returns:
+//   - *Route: Named route
+//   - bool: true if a route with this name exists
+func (r *Router) GetRoute(name string) (*Route, bool) {
+	route, ok := r.table.Load().names[name]
+	return route, ok
+}
+
+// URL builds the path for a named route
+// Params are given either as a single Map or as alternating key/value pairs
+//
+// Parameters:
+//   - name: Route name set with WithName
+//   - params: Map or key/value pairs
+//
+// Returns:
+//   - string: Escaped path with optional query string
+//   - error: Unknown route, invalid params, missing parameter or constraint violation
+//
+// Example:
+//
+//	app.GET("/users/:id", getUser, blaze.WithName("user.show"))
+//
+//	path, _ := app.URL("user.show", "id", 42)                        // "/users/42"
+//	path, _ = app.URL("user.show", blaze.Map{"id": 42, "tab": "posts"}) // "/users/42?tab=posts"
+func (a *App) URL(name string, params ...interface{}) (string, error) {
+	values, err := urlParams(params)
+	if err != nil {
+		return "", fmt.Errorf("route %q: %w", name, err)
+	}
+	return a.rootURL(name, values)
+}
+
+// rootURL builds the path for a named route as seen from the server root,
+// including the prefix the app is mounted under
+func (a *App) rootURL(name string, params Map) (string, error) {
+	path, err := a.routeURL(name, params)
+	if err != nil {
+		return "", err
+	}
+	return joinMountPath(a.mountPath(), path), nil
+}
+
+// routeURL builds the path for a named route registered on the app, any
+// of its virtual hosts or a mounted app, relative to the app
+func (a *App) routeURL(name string, params Map) (string, error) {
+	route, prefix, _, ok := a.namedRoute(name)
+	if !ok {
+		return "", fmt.Errorf("route %q not found", name)
+	}
+
+	path, err := route.URL(params)
+	if err != nil {
+		return "", err
+	}
+	return joinMountPath(prefix, path), nil
+}
+
+// namedRoute finds a named route along with the mount prefix, relative to
+// the app, of the app that registered it and the virtual host it is bound
+// to (nil for routes served on any host)
+func (a *App) namedRoute(name string) (*Route, string, *virtualHost, bool) {
+	if route, ok := a.router.GetRoute(name); ok {
+		return route, "", nil, true
+	}
+	for _, vh := range a.hosts {
+		if route, ok := vh.router.GetRoute(name); ok {
+			return route, "", vh, true
+		}
+	}
+	for _, m := range a.mounts {
+		if route, prefix, vh, ok := m.app.namedRoute(name); ok {
+			return route, m.prefix + prefix, vh, true
+		}
+	}
+	return nil, "", nil, false
+}
+
+// URLFor builds the path for a named route
+// Uses the application serving the current request
+//
+// Parameters:
+//   - name: Route name set with WithName
+//   - params: Parameter values (may be nil)
+//
+// Returns:
+//   - string: Escaped path with optional query string
+//   - error: Unknown route, missing parameter or constraint violation
+//
+// Example:
+//
+//	link, err := c.URLFor("user.show", blaze.Map{"id": user.ID})
+func (c *Context) URLFor(name string, params Map) (string, error) {
+	app, ok := c.Locals("__app__").(*App)
+	if !ok {
+		return "", fmt.Errorf("route %q: no application bound to context", name)
+	}
+	return app.rootURL(name, params)
+}
+
+// AbsoluteURLFor builds an absolute URL for a named route
+// The scheme is taken from the current request. Routes bound to a virtual
+// host use that host, with labels such as :tenant filled from params or,
+// on the same host, from the current request; other routes use the
+// current request's host.
+//
+// Parameters:
+//   - name: Route name set with WithName
+//   - params: Parameter values (may be nil)
+//
+// Returns:
+//   - string: Absolute URL (e.g. "https://example.com/users/42")
+//   - error: Unknown route, missing parameter or constraint violation
+//
+// Example:
+//
+//	link, err := c.AbsoluteURLFor("user.show", blaze.Map{"id": 42})
+//	link, err = c.AbsoluteURLFor("tenant.home", blaze.Map{"tenant": "acme"})
+//	// "https://acme.example.com/"
+func (c *Context) AbsoluteURLFor(name string, params Map) (string, error) {
+	app, ok := c.Locals("__app__").(*App)
+	if !ok {
+		return "", fmt.Errorf("route %q: no application bound to context", name)
+	}
+
+	_, _, vh, ok := app.namedRoute(name)
+	if !ok {
+		return "", fmt.Errorf("route %q not found", name)
+	}
+
+	host := string(c.Host())
+	if vh != nil {
+		var err error
+		host, params, err = vh.buildHost(host, params)
+		if err != nil {
+			return "", fmt.Errorf("route %q: %w", name, err)
+		}
+	}
+
+	path, err := app.rootURL(name, params)
+	if err != nil {
+		return "", err
+	}
+	return c.Scheme() + "://" + host + path, nil
+}
+
+// Scheme returns the request scheme ("http" or "https")
+// Honors X-Forwarded-Proto only when the request came from a proxy listed
+// in Config.TrustedProxies; any client can send the header.
+//
+// Returns:
+//   - string: Request scheme
+func (c *Context) Scheme() string {
+	if proto := c.Header("X-Forwarded-Proto"); proto != "" && c.IsFromTrustedProxy() {
+		if i := strings.IndexByte(proto, ','); i >= 0 {
+			proto = proto[:i]
+		}
+		return strings.ToLower(strings.TrimSpace(proto))
+	}
+	if c.IsTLS() {
+		return "https"
+	}
+	return "http"
+}
+
+// BaseURL returns the scheme and host of the current request
+//
+// Returns:
+//   - string: Base URL without trailing slash (e.g. "https://example.com")
+func (c *Context) BaseURL() string {
+	return c.Scheme() + "://" + string(c.Host())
+}
+
+// RedirectToRoute redirects to a named route
+// Uses 302 Found unless a status is given
+//
+// Parameters:
+//   - name: Route name set with WithName
+//   - params: Parameter values (may be nil)
+//   - status: Optional redirect status code
+//
+// Returns:
+//   - error: Unknown route, missing parameter or constraint violation
+//
+// Example:
+//
+//	return c.RedirectToRoute("user.show", blaze.Map{"id": user.ID})
+//	return c.RedirectToRoute("home", nil, 301)
+func (c *Context) RedirectToRoute(name string, params Map, status ...int) error {
+	path, err := c.URLFor(name, params)
+	if err != nil {
+		return err
+	}
+	c.Redirect(path, status...)
+	return nil
+}
+
+// urlParams converts App.URL arguments into a Map
+func urlParams(params []interface{}) (Map, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	if len(params) == 1 {
+		if m, ok := params[0].(Map); ok {
+			return m, nil
+		}
+		if m, ok := params[0].(map[string]interface{}); ok {
+			return Map(m), nil
+		}
+	}
+
+	if len(params)%2 != 0 {
+		return nil, fmt.Errorf("params must be a Map or key/value pairs, got %d values", len(params))
+	}
+
+	values := make(Map, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		key, ok := params[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("param key at position %d must be a string, got %T", i, params[i])
+		}
+		values[key] = params[i+1]
+	}
+	return values, nil
+}
+
+// buildQuery encodes params not consumed by the pattern, sorted by key
+func buildQuery(params Map, used map[string]bool) string {
+	var keys []string
+	for key := range params {
+		if !used[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	query := url.Values{}
+	for _, key := range keys {
+		switch v := params[key].(type) {
+		case []string:
+			query[key] = append(query[key], v...)
+		case []interface{}:
+			for _, item := range v {
+				query.Add(key, formatURLValue(item))
+			}
+		case []int:
+			for _, item := range v {
+				query.Add(key, formatURLValue(item))
+			}
+		default:
+			query.Add(key, formatURLValue(v))
+		}
+	}
+	return query.Encode()
+}
+
+// formatURLValue converts a parameter value to its string form
+func formatURLValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,138 @@
+package blaze_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+)
+
+func TestURLQuery(t *testing.T) {
+	app := blaze.New()
+	app.GET("/files/*path", param("path"), blaze.WithName("files"))
+	app.GET("/archive/:year?", param("year"), blaze.WithName("archive"))
+
+	tests := []struct {
+		name   string
+		params []interface{}
+		want   string
+	}{
+		// A missing catch-all does not swallow the query
+		{"files", []interface{}{"v", 2}, "/files/?v=2"},
+		{"files", []interface{}{"path", "a b/c", "v", 2}, "/files/a%20b/c?v=2"},
+		{"archive", []interface{}{"page", 3}, "/archive?page=3"},
+		{"archive", []interface{}{"year", "", "page", 3}, "/archive?page=3"},
+	}
+	for _, tt := range tests {
+		got, err := app.URL(tt.name, tt.params...)
+		if err != nil || got != tt.want {
+			t.Errorf("URL(%q, %v) = %q, %v; want %q", tt.name, tt.params, got, err, tt.want)
+		}
+	}
+}
+
+func TestURLCatchAllConstraint(t *testing.T) {
+	app := blaze.New()
+	app.GET("/files/*path", param("path"),
+		blaze.WithName("files"),
+		blaze.WithRegexConstraint("path", `^/docs/`))
+
+	srv := blazetest.New(t, app)
+
+	// Building and matching agree on the value the constraint sees
+	url, err := app.URL("files", "path", "docs/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Get(url).Do().AssertStatus(http.StatusOK)
+
+	if url, err := app.URL("files", "path", "img/a.png"); err == nil {
+		t.Errorf("URL = %q, want a constraint error", url)
+	}
+	srv.Get("/files/img/a.png").Do().AssertStatus(http.StatusNotFound)
+}
+
+func TestURLNamesAcrossHosts(t *testing.T) {
+	captureLog(t)
+
+	app := blaze.New()
+	app.GET("/", text("landing"), blaze.WithName("home"))
+	app.Host("api.example.com").GET("/", text("api"), blaze.WithName("home"))
+	app.Host("admin.example.com").GET("/", text("admin"), blaze.WithName("admin"))
+	app.Host("api.example.com").GET("/admin", text("api admin"), blaze.WithName("admin"))
+
+	err := app.ValidateRoutes()
+	if err == nil {
+		t.Fatal("duplicate names across hosts were accepted")
+	}
+	if n := strings.Count(err.Error(), "is already used on another host"); n != 2 {
+		t.Errorf("ValidateRoutes reported %d duplicate names, want 2:\n%v", n, err)
+	}
+
+	// The first registration keeps the name
+	if url, err := app.URL("admin"); err != nil || url != "/" {
+		t.Errorf("URL(admin) = %q, %v", url, err)
+	}
+
+	// Replacing a route under its own name is not a duplicate
+	err = app.ReplaceRoutes(func(r *blaze.Router) {
+		r.RemoveRoute("GET", "/")
+		r.AddRoute("GET", "/", text("new landing"), blaze.WithName("home"))
+	})
+	if err != nil {
+		t.Errorf("ReplaceRoutes: %v", err)
+	}
+}
+
+func TestAbsoluteURLForHosts(t *testing.T) {
+	link := func(name string, params blaze.Map) blaze.HandlerFunc {
+		return func(c *blaze.Context) error {
+			url, err := c.AbsoluteURLFor(name, params)
+			if err != nil {
+				return c.Status(http.StatusInternalServerError).Text(err.Error())
+			}
+			return c.Text(url)
+		}
+	}
+
+	app := blaze.New()
+	app.GET("/users/:id", param("id"), blaze.WithName("user"))
+	app.GET("/link/user", link("user", blaze.Map{"id": 1}))
+	app.GET("/link/tenant", link("tenant.home", blaze.Map{"tenant": "acme", "tab": "x"}))
+	app.GET("/link/tenant-missing", link("tenant.home", nil))
+	app.GET("/link/api", link("api.status", nil))
+	app.GET("/link/cdn", link("cdn.asset", blaze.Map{"path": "a.css"}))
+
+	tenant := app.Host(":tenant.example.com")
+	tenant.GET("/", param("tenant"), blaze.WithName("tenant.home"))
+	tenant.GET("/link", link("tenant.home", nil))
+
+	app.Host("api.example.com:8443").GET("/status", text("ok"), blaze.WithName("api.status"))
+	cdn := app.Host("*.cdn.example.com")
+	cdn.GET("/assets/*path", param("path"), blaze.WithName("cdn.asset"))
+	cdn.GET("/link", link("cdn.asset", blaze.Map{"path": "a.css"}))
+
+	srv := blazetest.New(t, app)
+
+	tests := []struct {
+		host, path string
+		status     int
+		body       string
+	}{
+		{"example.com:8080", "/link/user", http.StatusOK, "http://example.com:8080/users/1"},
+		{"example.com:8080", "/link/tenant", http.StatusOK, "http://acme.example.com:8080/?tab=x"},
+		{"example.com", "/link/tenant-missing", http.StatusInternalServerError, `missing host parameter "tenant"`},
+		{"globex.example.com", "/link", http.StatusOK, "http://globex.example.com/"},
+		{"example.com:8080", "/link/api", http.StatusOK, "http://api.example.com:8443/status"},
+		{"example.com", "/link/cdn", http.StatusInternalServerError, "wildcard labels"},
+		{"eu.cdn.example.com", "/link", http.StatusOK, "http://eu.cdn.example.com/assets/a.css"},
+	}
+	for _, tt := range tests {
+		res := srv.Get(tt.path).WithHost(tt.host).Do().AssertStatus(tt.status)
+		if !strings.Contains(res.BodyString(), tt.body) {
+			t.Errorf("%s%s = %q, want %q", tt.host, tt.path, res.BodyString(), tt.body)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package blaze
 
 import (
-	"encoding/json"
 	"log"
 	"sync"
 	"time"
@@ -254,6 +253,11 @@ type WebSocketConnection struct {
 
 	// locals stores connection-specific data
 	locals map[string]interface{}
+
+	// jsonEncoder and jsonDecoder are the JSON engine of the app that
+	// accepted the connection
+	jsonEncoder JSONEncoder
+	jsonDecoder JSONDecoder
 }
 
 // WebSocketHandler defines WebSocket handler function signature
@@ -346,14 +350,20 @@ const (
 //	    }
 //	})
 func (wu *WebSocketUpgrader) Upgrade(c *Context, handler WebSocketHandler) error {
+	// The handler runs after the request, when a mounted app may no longer
+	// be bound to the context
+	encoder, decoder := c.jsonEncoder(), c.jsonDecoder()
+
 	err := wu.upgrader.Upgrade(c.RequestCtx, func(conn *websocket.Conn) {
 		wsConn := &WebSocketConnection{
-			conn:    conn,
-			ctx:     c,
-			closed:  false,
-			closeCh: make(chan struct{}),
-			writeCh: make(chan []byte, 256),
-			locals:  make(map[string]interface{}),
+			conn:        conn,
+			ctx:         c,
+			closed:      false,
+			closeCh:     make(chan struct{}),
+			writeCh:     make(chan []byte, 256),
+			locals:      make(map[string]interface{}),
+			jsonEncoder: encoder,
+			jsonDecoder: decoder,
 		}
 
 		// Set connection limits
@@ -523,7 +533,7 @@ func (ws *WebSocketConnection) WriteBinary(data []byte) error {
 }
 
 // WriteJSON writes JSON-encoded message
-// Marshals data to JSON and sends as text
+// Marshals data with the app's JSON encoder and sends it as text
 //
 // Parameters:
 //   - data: Data to encode as JSON
@@ -538,7 +548,7 @@ func (ws *WebSocketConnection) WriteBinary(data []byte) error {
 //	    "message": "New message",
 //	})
 func (ws *WebSocketConnection) WriteJSON(data interface{}) error {
-	jsonData, err := json.Marshal(data)
+	jsonData, err := ws.jsonEncoder.Marshal(data)
 	if err != nil {
 		return err
 	}
@@ -546,7 +556,7 @@ func (ws *WebSocketConnection) WriteJSON(data interface{}) error {
 }
 
 // ReadJSON reads and decodes JSON message
-// Receives message and unmarshals JSON
+// Receives a message and unmarshals it with the app's JSON decoder
 //
 // Parameters:
 //   - v: Pointer to destination struct
@@ -565,7 +575,7 @@ func (ws *WebSocketConnection) ReadJSON(v interface{}) error {
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(data, v)
+	return ws.jsonDecoder.Unmarshal(data, v)
 }
 
 // Ping sends ping message
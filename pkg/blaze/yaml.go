@@ -0,0 +1,963 @@
+package blaze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ==================== YAML Encoding ====================
+
+// yamlNode is a decoded JSON value that keeps the order of object keys
+type yamlNode struct {
+	// keys and values hold object members, values alone array items
+	keys   []string
+	values []*yamlNode
+
+	// scalar is the value of strings, numbers, booleans and null
+	scalar interface{}
+
+	object, array bool
+}
+
+// jsonToYAML converts a JSON document to block-style YAML
+// Object keys keep their JSON order. Strings are quoted when a plain
+// scalar would be read back as another type or is not valid YAML.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	root, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty collections have no block form
+	if (root.object || root.array) && len(root.values) == 0 {
+		var b bytes.Buffer
+		writeYAMLValue(&b, root, 0)
+		return bytes.TrimLeft(b.Bytes(), " "), nil
+	}
+
+	var b bytes.Buffer
+	writeYAMLNode(&b, root, 0)
+	return b.Bytes(), nil
+}
+
+// decodeYAMLNode reads the next JSON value from dec
+func decodeYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return &yamlNode{scalar: token}, nil
+	}
+
+	node := &yamlNode{object: delim == '{', array: delim == '['}
+	for dec.More() {
+		if node.object {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid object key %v", key)
+			}
+			node.keys = append(node.keys, name)
+		}
+
+		value, err := decodeYAMLNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		node.values = append(node.values, value)
+	}
+
+	// Consume the closing delimiter
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// writeYAMLNode writes node as the contents of a block at indent
+func writeYAMLNode(b *bytes.Buffer, node *yamlNode, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch {
+	case node.object:
+		for i, key := range node.keys {
+			b.WriteString(pad)
+			b.WriteString(yamlString(key))
+			b.WriteByte(':')
+			writeYAMLValue(b, node.values[i], indent+1)
+		}
+	case node.array:
+		for _, item := range node.values {
+			b.WriteString(pad)
+			b.WriteByte('-')
+			if item.object && len(item.values) > 0 {
+				// First member on the dash line, the rest aligned below it
+				var nested bytes.Buffer
+				writeYAMLNode(&nested, item, indent+1)
+				b.WriteByte(' ')
+				b.Write(bytes.TrimLeft(nested.Bytes(), " "))
+				continue
+			}
+			writeYAMLValue(b, item, indent+1)
+		}
+	default:
+		b.WriteString(pad)
+		b.WriteString(yamlScalar(node.scalar))
+		b.WriteByte('\n')
+	}
+}
+
+// writeYAMLValue writes the value following a key or list dash
+func writeYAMLValue(b *bytes.Buffer, node *yamlNode, indent int) {
+	switch {
+	case node.object && len(node.values) == 0:
+		b.WriteString(" {}\n")
+	case node.array && len(node.values) == 0:
+		b.WriteString(" []\n")
+	case node.object || node.array:
+		b.WriteByte('\n')
+		writeYAMLNode(b, node, indent)
+	default:
+		b.WriteByte(' ')
+		b.WriteString(yamlScalar(node.scalar))
+		b.WriteByte('\n')
+	}
+}
+
+// yamlScalar formats a JSON scalar
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// yamlString formats a string, quoting it unless it is a safe plain scalar
+// Quoted strings use JSON escapes, which YAML double quotes accept.
+func yamlString(s string) string {
+	if isPlainYAML(s) {
+		return s
+	}
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// isPlainYAML reports whether s can be written unquoted and still be read
+// back as the same string
+func isPlainYAML(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return false
+	}
+
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+		return false
+	}
+
+	// Anything starting like a number could be read as one
+	if c := s[0]; (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' {
+		return false
+	}
+
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_' || r == '-' || r == '.' || r == '/' || r == ' ' || r == '(' || r == ')':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ==================== YAML Decoding ====================
+
+// yamlLine is one line of a YAML document
+type yamlLine struct {
+	// number is the 1-based line number, used in errors
+	number int
+
+	// indent is the number of leading spaces
+	indent int
+
+	// text is the content without indentation, trailing space and comment
+	text string
+
+	// raw is the original line, used for block scalars
+	raw string
+}
+
+// yamlParser decodes YAML line by line
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+
+	// depth is the number of collections being parsed
+	depth int
+}
+
+// parseYAML decodes a YAML document into maps, slices and scalars
+// Supports the subset used by configuration and API description files:
+// block mappings and sequences, flow collections, plain and quoted
+// scalars, literal and folded block scalars, and comments. Anchors,
+// aliases and multiple documents are rejected.
+//
+// Mappings decode to map[string]interface{}, sequences to []interface{}
+// and scalars to string, int64 (uint64 above its range), float64, bool
+// or nil, following the YAML 1.2 core schema.
+func parseYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	content := false
+
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := stripYAMLComment(raw)
+		trimmed := strings.TrimLeft(text, " ")
+		line := yamlLine{
+			number: i + 1,
+			indent: len(text) - len(trimmed),
+			text:   strings.TrimRight(trimmed, " \t"),
+			raw:    raw,
+		}
+
+		// Document markers
+		if line.indent == 0 && (line.text == "---" || line.text == "...") {
+			if content && line.text == "---" {
+				return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", line.number)
+			}
+			line.text = ""
+		}
+		if strings.HasPrefix(line.text, "%") && line.indent == 0 && !content {
+			// Directives (%YAML 1.2)
+			line.text = ""
+		}
+		if strings.HasPrefix(line.text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", line.number)
+		}
+
+		content = content || line.text != ""
+		p.lines = append(p.lines, line)
+	}
+
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+
+	value, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, p.errorf(p.lines[p.pos], "unexpected content")
+	}
+	return value, nil
+}
+
+func (p *yamlParser) errorf(line yamlLine, format string, args ...interface{}) error {
+	return fmt.Errorf("yaml: line %d: %s", line.number, fmt.Sprintf(format, args...))
+}
+
+// skipBlank moves past empty and comment-only lines
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+}
+
+// parseNode parses the node starting at the current line
+func (p *yamlParser) parseNode() (interface{}, error) {
+	line := p.lines[p.pos]
+
+	switch {
+	case isYAMLSequenceItem(line.text):
+		return p.parseSequence(line.indent)
+	case yamlKeyEnd(line.text) >= 0:
+		return p.parseMapping(line.indent)
+	default:
+		p.pos++
+		return p.parseValue(line.text, line.indent-1, line, false)
+	}
+}
+
+// enter counts a nested block collection starting at the current line
+func (p *yamlParser) enter() error {
+	if p.depth >= maxDecodeDepth {
+		return p.errorf(p.lines[p.pos], "maximum nesting depth exceeded")
+	}
+	p.depth++
+	return nil
+}
+
+// parseMapping parses a block mapping whose keys are at indent
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	m := make(map[string]interface{})
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			break
+		}
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf(line, "unexpected indentation")
+		}
+
+		end := yamlKeyEnd(line.text)
+		if end < 0 {
+			if isYAMLSequenceItem(line.text) {
+				return nil, p.errorf(line, "sequence item where a mapping key was expected")
+			}
+			return nil, p.errorf(line, "expected a mapping key")
+		}
+
+		key, err := parseYAMLKey(strings.TrimSpace(line.text[:end]), line)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := m[key]; exists {
+			return nil, p.errorf(line, "duplicate key %q", key)
+		}
+
+		p.pos++
+		value, err := p.parseValue(strings.TrimSpace(line.text[end+1:]), indent, line, true)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+
+	return m, nil
+}
+
+// parseSequence parses a block sequence whose dashes are at indent
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	items := make([]interface{}, 0)
+
+	for {
+		p.skipBlank()
+		if p.pos >= len(p.lines) {
+			break
+		}
+		line := p.lines[p.pos]
+		if line.indent < indent || (line.indent == indent && !isYAMLSequenceItem(line.text)) {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf(line, "unexpected indentation")
+		}
+
+		rest := strings.TrimLeft(line.text[1:], " ")
+		var value interface{}
+		var err error
+
+		if isYAMLSequenceItem(rest) || (yamlKeyEnd(rest) >= 0 && !strings.HasPrefix(rest, "{")) {
+			// A nested collection starting on the dash line: re-read the
+			// line as if the collection started at its own column
+			offset := len(line.text) - len(rest)
+			p.lines[p.pos] = yamlLine{number: line.number, indent: indent + offset, text: rest, raw: line.raw}
+			value, err = p.parseNode()
+		} else {
+			p.pos++
+			value, err = p.parseValue(rest, indent, line, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+
+	return items, nil
+}
+
+// parseValue parses the value following a key or dash
+// parentIndent is the indent of the key or dash; sameIndentSequence allows
+// a mapping value to be a sequence at the key's own indent.
+func (p *yamlParser) parseValue(text string, parentIndent int, line yamlLine, sameIndentSequence bool) (interface{}, error) {
+	switch {
+	case text == "":
+		p.skipBlank()
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > parentIndent || (sameIndentSequence && next.indent == parentIndent && isYAMLSequenceItem(next.text)) {
+				return p.parseNode()
+			}
+		}
+		return nil, nil
+
+	case text[0] == '|' || text[0] == '>':
+		return p.parseBlockScalar(text, parentIndent, line)
+
+	case text[0] == '&' || text[0] == '*':
+		return nil, p.errorf(line, "anchors and aliases are not supported")
+
+	case text[0] == '[' || text[0] == '{':
+		// Flow collections may continue on the following lines
+		for !yamlFlowClosed(text) && p.pos < len(p.lines) {
+			text += " " + p.lines[p.pos].text
+			p.pos++
+		}
+		f := &yamlFlowParser{s: text, line: line, depth: p.depth}
+		value, err := f.parse()
+		if err != nil {
+			return nil, err
+		}
+		if f.skipSpace(); f.i < len(f.s) {
+			return nil, p.errorf(line, "unexpected %q after flow collection", f.s[f.i:])
+		}
+		return value, nil
+
+	case text[0] == '"' || text[0] == '\'':
+		// Quoted scalars may continue on the following lines
+		for !yamlQuoteClosed(text) && p.pos < len(p.lines) {
+			text += " " + strings.TrimSpace(p.lines[p.pos].raw)
+			p.pos++
+		}
+		return parseYAMLScalar(text, line)
+
+	default:
+		// Plain scalars continue on more indented lines
+		for p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.text == "" || next.indent <= parentIndent {
+				break
+			}
+			text += " " + next.text
+			p.pos++
+		}
+		return parseYAMLScalar(text, line)
+	}
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar
+func (p *yamlParser) parseBlockScalar(header string, parentIndent int, line yamlLine) (interface{}, error) {
+	literal := header[0] == '|'
+	chomp := byte(0)
+	blockIndent := -1
+
+	for _, c := range []byte(header[1:]) {
+		switch {
+		case c == '-' || c == '+':
+			chomp = c
+		case c >= '1' && c <= '9':
+			blockIndent = parentIndent + int(c-'0')
+			if parentIndent < 0 {
+				blockIndent = int(c - '0')
+			}
+		case c == ' ':
+		default:
+			return nil, p.errorf(line, "invalid block scalar header %q", header)
+		}
+	}
+
+	var lines []string
+	for p.pos < len(p.lines) {
+		raw := p.lines[p.pos].raw
+		if strings.TrimSpace(raw) == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+		if blockIndent < 0 {
+			if indent <= parentIndent {
+				break
+			}
+			blockIndent = indent
+		}
+		if indent < blockIndent {
+			break
+		}
+		lines = append(lines, strings.TrimRight(raw[blockIndent:], "\r"))
+		p.pos++
+	}
+
+	// Trailing blank lines only matter with keep chomping
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var b strings.Builder
+	for i, l := range lines {
+		switch {
+		case i == 0:
+		case literal:
+			b.WriteByte('\n')
+		case l == "" || lines[i-1] == "" || strings.HasPrefix(l, " ") || strings.HasPrefix(lines[i-1], " "):
+			// Folding keeps line breaks around empty and more indented lines
+			if l != "" || lines[i-1] == "" {
+				b.WriteByte('\n')
+			}
+		default:
+			b.WriteByte(' ')
+		}
+		b.WriteString(l)
+	}
+
+	value := b.String()
+	switch {
+	case len(lines) == 0:
+	case chomp == '-':
+	case chomp == '+':
+		value += "\n" + strings.Repeat("\n", trailing)
+	default:
+		value += "\n"
+	}
+	return value, nil
+}
+
+// yamlFlowParser parses flow collections ([a, b], {k: v})
+type yamlFlowParser struct {
+	s     string
+	i     int
+	line  yamlLine
+	depth int
+}
+
+func (f *yamlFlowParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("yaml: line %d: %s", f.line.number, fmt.Sprintf(format, args...))
+}
+
+func (f *yamlFlowParser) skipSpace() {
+	for f.i < len(f.s) && (f.s[f.i] == ' ' || f.s[f.i] == '\t') {
+		f.i++
+	}
+}
+
+// parse parses the value at the current position
+func (f *yamlFlowParser) parse() (interface{}, error) {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return nil, f.errorf("unexpected end of flow collection")
+	}
+
+	switch f.s[f.i] {
+	case '[', '{':
+		if f.depth >= maxDecodeDepth {
+			return nil, f.errorf("maximum nesting depth exceeded")
+		}
+		f.depth++
+		defer func() { f.depth-- }()
+	}
+
+	switch f.s[f.i] {
+	case '[':
+		f.i++
+		items := make([]interface{}, 0)
+		for {
+			f.skipSpace()
+			if f.i < len(f.s) && f.s[f.i] == ']' {
+				f.i++
+				return items, nil
+			}
+			item, err := f.parse()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+
+	case '{':
+		f.i++
+		m := make(map[string]interface{})
+		for {
+			f.skipSpace()
+			if f.i < len(f.s) && f.s[f.i] == '}' {
+				f.i++
+				return m, nil
+			}
+			key, err := f.scalar(true)
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				name = fmt.Sprint(key)
+			}
+
+			f.skipSpace()
+			var value interface{}
+			if f.i < len(f.s) && f.s[f.i] == ':' {
+				f.i++
+				f.skipSpace()
+				if f.i < len(f.s) && f.s[f.i] != ',' && f.s[f.i] != '}' {
+					if value, err = f.parse(); err != nil {
+						return nil, err
+					}
+				}
+			}
+			m[name] = value
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+
+	default:
+		return f.scalar(false)
+	}
+}
+
+// separator consumes a "," or peeks the closing bracket
+func (f *yamlFlowParser) separator(closing byte) error {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return f.errorf("unterminated flow collection")
+	}
+	switch f.s[f.i] {
+	case ',':
+		f.i++
+		return nil
+	case closing:
+		return nil
+	default:
+		return f.errorf("expected ',' or '%c' in flow collection", closing)
+	}
+}
+
+// scalar parses a quoted or plain scalar inside a flow collection
+func (f *yamlFlowParser) scalar(key bool) (interface{}, error) {
+	f.skipSpace()
+	start := f.i
+
+	if f.i < len(f.s) && (f.s[f.i] == '"' || f.s[f.i] == '\'') {
+		quote := f.s[f.i]
+		for f.i++; f.i < len(f.s); f.i++ {
+			if quote == '"' && f.s[f.i] == '\\' {
+				f.i++
+				continue
+			}
+			if f.s[f.i] == quote {
+				if quote == '\'' && f.i+1 < len(f.s) && f.s[f.i+1] == '\'' {
+					f.i++
+					continue
+				}
+				f.i++
+				return parseYAMLScalar(f.s[start:f.i], f.line)
+			}
+		}
+		return nil, f.errorf("unterminated quoted string")
+	}
+
+	for f.i < len(f.s) {
+		c := f.s[f.i]
+		if c == ',' || c == ']' || c == '}' || c == '[' || c == '{' {
+			break
+		}
+		if c == ':' && (key || f.i+1 == len(f.s) || f.s[f.i+1] == ' ' || f.s[f.i+1] == ',') {
+			break
+		}
+		f.i++
+	}
+	return parseYAMLScalar(strings.TrimSpace(f.s[start:f.i]), f.line)
+}
+
+// isYAMLSequenceItem reports whether text starts a sequence item
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlKeyEnd returns the index of the colon ending a mapping key in text,
+// or -1 when text is not a key
+func yamlKeyEnd(text string) int {
+	if text == "" || text[0] == '[' || text[0] == '{' || isYAMLSequenceItem(text) {
+		return -1
+	}
+
+	i := 0
+	if text[0] == '"' || text[0] == '\'' {
+		// Quoted key: the colon follows the closing quote
+		end := yamlQuoteEnd(text)
+		if end < 0 {
+			return -1
+		}
+		i = end + 1
+		for i < len(text) && text[i] == ' ' {
+			i++
+		}
+		if i < len(text) && text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+		return -1
+	}
+
+	for ; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseYAMLKey parses a mapping key
+func parseYAMLKey(text string, line yamlLine) (string, error) {
+	value, err := parseYAMLScalar(text, line)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	if value == nil {
+		return text, nil
+	}
+	// Keys such as 200 or true are used as strings
+	return text, nil
+}
+
+// parseYAMLScalar parses a quoted or plain scalar
+func parseYAMLScalar(text string, line yamlLine) (interface{}, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	switch text[0] {
+	case '"':
+		end := yamlQuoteEnd(text)
+		if end != len(text)-1 {
+			return nil, fmt.Errorf("yaml: line %d: invalid double-quoted string %s", line.number, text)
+		}
+		return unquoteYAMLDouble(text[1:end], line)
+	case '\'':
+		end := yamlQuoteEnd(text)
+		if end != len(text)-1 {
+			return nil, fmt.Errorf("yaml: line %d: invalid single-quoted string %s", line.number, text)
+		}
+		return strings.ReplaceAll(text[1:end], "''", "'"), nil
+	case '&', '*':
+		return nil, fmt.Errorf("yaml: line %d: anchors and aliases are not supported", line.number)
+	case '!':
+		// Tags: only the standard string tag changes the result
+		tag, rest, _ := strings.Cut(text, " ")
+		if tag == "!!str" {
+			if value, err := parseYAMLScalar(strings.TrimSpace(rest), line); err != nil || value == nil {
+				return "", err
+			} else if s, ok := value.(string); ok {
+				return s, nil
+			}
+			return strings.TrimSpace(rest), nil
+		}
+		return parseYAMLScalar(strings.TrimSpace(rest), line)
+	}
+
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1), nil
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1), nil
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN(), nil
+	}
+
+	if isYAMLNumber(text) {
+		if !strings.ContainsAny(text, ".eE") {
+			if n, err := strconv.ParseInt(text, 0, 64); err == nil {
+				return n, nil
+			}
+			// Integers above the int64 range, as in uint64 values
+			if n, err := strconv.ParseUint(strings.TrimPrefix(text, "+"), 0, 64); err == nil {
+				return n, nil
+			}
+		}
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f, nil
+		}
+	}
+	return text, nil
+}
+
+// isYAMLNumber reports whether a plain scalar is an integer or float in
+// the core schema (decimal, 0x hex, 0o octal, or decimal with exponent)
+func isYAMLNumber(text string) bool {
+	s := strings.TrimLeft(text, "+-")
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0o") {
+		return len(s) > 2 && s == text
+	}
+
+	digits, dot, exp := 0, false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && !dot && !exp:
+			dot = true
+		case (c == 'e' || c == 'E') && digits > 0 && !exp:
+			exp = true
+			if i+1 < len(s) && (s[i+1] == '+' || s[i+1] == '-') {
+				i++
+			}
+		default:
+			return false
+		}
+	}
+	return digits > 0 && len(text)-len(s) <= 1
+}
+
+// unquoteYAMLDouble resolves the escapes of a double-quoted scalar
+func unquoteYAMLDouble(s string, line yamlLine) (string, error) {
+	if !strings.ContainsRune(s, '\\') {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		if i >= len(s) {
+			return "", fmt.Errorf("yaml: line %d: invalid escape at end of string", line.number)
+		}
+
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '0':
+			b.WriteByte(0)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'e':
+			b.WriteByte(0x1b)
+		case ' ', '"', '/', '\\':
+			b.WriteByte(s[i])
+		case 'x', 'u', 'U':
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[s[i]]
+			if i+size >= len(s) {
+				return "", fmt.Errorf("yaml: line %d: short escape sequence", line.number)
+			}
+			code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("yaml: line %d: invalid escape sequence", line.number)
+			}
+			b.WriteRune(rune(code))
+			i += size
+		default:
+			return "", fmt.Errorf("yaml: line %d: unknown escape \\%c", line.number, s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// yamlQuoteEnd returns the index of the quote closing the one at text[0],
+// or -1 when it is not closed
+func yamlQuoteEnd(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// yamlQuoteClosed reports whether the quoted scalar in text is complete
+func yamlQuoteClosed(text string) bool {
+	return yamlQuoteEnd(text) >= 0
+}
+
+// yamlFlowClosed reports whether the brackets of a flow collection balance
+func yamlFlowClosed(text string) bool {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '\'':
+			if i == 0 || strings.IndexByte(" ,[{:", text[i-1]) >= 0 {
+				end := yamlQuoteEnd(text[i:])
+				if end < 0 {
+					return false
+				}
+				i += end
+			}
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// stripYAMLComment removes a trailing comment from a line
+// A "#" starts a comment at the line start or after whitespace, outside
+// quoted scalars.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if quote == '"' && c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t:-[{,", line[i-1]) >= 0):
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,88 @@
+package blaze
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseYAMLScalars(t *testing.T) {
+	tests := []struct {
+		input string
+		want  interface{}
+	}{
+		{"3.0.3", "3.0.3"},
+		{`"1.0"`, "1.0"},
+		{"'200'", "200"},
+		{"200", int64(200)},
+		{"-12", int64(-12)},
+		{"18446744073709551615", uint64(math.MaxUint64)},
+		{"1.5", 1.5},
+		{"1e3", 1000.0},
+		{"true", true},
+		{"False", false},
+		{"~", nil},
+		{"!!str 42", "42"},
+		{"!!str", ""},
+		{"application/json", "application/json"},
+		{`"#/components/schemas/Pet"`, "#/components/schemas/Pet"},
+		{`"line\nbreak é"`, "line\nbreak é"},
+	}
+	for _, tt := range tests {
+		got, err := parseYAML([]byte("value: " + tt.input + "\n"))
+		if err != nil {
+			t.Errorf("%s: %v", tt.input, err)
+			continue
+		}
+		if value := got.(map[string]interface{})["value"]; !reflect.DeepEqual(value, tt.want) {
+			t.Errorf("%s = %#v, want %#v", tt.input, value, tt.want)
+		}
+	}
+}
+
+func TestParseYAMLDocument(t *testing.T) {
+	data, err := os.ReadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := parseYAML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lookup follows keys and indices through the parsed document
+	lookup := func(path ...interface{}) interface{} {
+		node := root
+		for _, key := range path {
+			switch k := key.(type) {
+			case string:
+				node = node.(map[string]interface{})[k]
+			case int:
+				node = node.([]interface{})[k]
+			}
+		}
+		return node
+	}
+
+	tests := []struct {
+		path []interface{}
+		want interface{}
+	}{
+		{[]interface{}{"openapi"}, "3.0.3"},
+		{[]interface{}{"info", "version"}, "1.0"},
+		{[]interface{}{"servers", 0, "url"}, "https://api.example.com/v1"},
+		{[]interface{}{"paths", "/pets", "get", "parameters", 0, "schema", "maximum"}, int64(100)},
+		{[]interface{}{"paths", "/pets", "get", "parameters", 1, "explode"}, false},
+		{[]interface{}{"paths", "/pets", "get", "parameters", 1, "schema", "items", "enum"}, []interface{}{"cat", "dog"}},
+		{[]interface{}{"paths", "/pets/{id}", "get", "responses", "200", "content", "application/json", "schema", "$ref"}, "#/components/schemas/Pet"},
+		{[]interface{}{"components", "schemas", "NewPet", "required"}, []interface{}{"name", "kind"}},
+		{[]interface{}{"components", "schemas", "NewPet", "properties", "owner", "type"}, "object"},
+		{[]interface{}{"components", "schemas", "Pet", "allOf", 1, "properties", "id", "readOnly"}, true},
+	}
+	for _, tt := range tests {
+		if got := lookup(tt.path...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v = %#v, want %#v", tt.path, got, tt.want)
+		}
+	}
+}
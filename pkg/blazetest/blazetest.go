@@ -0,0 +1,238 @@
+// Package blazetest provides an in-memory test harness for Blaze applications.
+//
+// A Server serves an *blaze.App over a fasthttputil.InmemoryListener, so the
+// full request pipeline (routing, global and route middleware, error handling,
+// WebSocket upgrades) runs end-to-end without binding a real port. Requests are
+// built with a fluent client and responses expose chainable assertions that
+// report failures through the test's TB.
+//
+// Example:
+//
+//	func TestUsers(t *testing.T) {
+//	    app := blaze.New()
+//	    app.GET("/users/:id", func(c *blaze.Context) error {
+//	        return c.JSON(blaze.Map{"id": c.Param("id")})
+//	    })
+//
+//	    srv := blazetest.New(t, app)
+//
+//	    srv.Get("/users/42").
+//	        WithHeader("Accept", "application/json").
+//	        Do().
+//	        AssertStatus(200).
+//	        AssertJSONPath("id", "42")
+//	}
+package blazetest
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+// DefaultHost is the Host header sent with requests unless overridden
+const DefaultHost = "example.com"
+
+// TB is the subset of testing.TB used by the harness.
+//
+// *testing.T and *testing.B satisfy this interface.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Cleanup(func())
+}
+
+// Server serves a Blaze application over an in-memory listener
+type Server struct {
+	t        TB
+	app      *blaze.App
+	listener *fasthttputil.InmemoryListener
+	server   *fasthttp.Server
+	client   *fasthttp.Client
+
+	// Host is the default Host header for requests created by this server
+	Host string
+
+	// Timeout bounds each request round trip (default 5 seconds)
+	Timeout time.Duration
+
+	closeOnce sync.Once
+	serveErr  chan error
+}
+
+// New starts serving app over an in-memory listener.
+//
+// The server is closed automatically when the test finishes. Server settings
+// such as MaxRequestBodySize and timeouts are taken from the app's Config so
+// limits behave as they do under ListenAndServe. Like ListenAndServe, New
+// fails the test if any route was rejected at registration.
+//
+// Parameters:
+//   - t: Test or benchmark driving the server
+//   - app: Application under test
+//
+// Returns:
+//   - *Server: Running in-memory server
+func New(t TB, app *blaze.App) *Server {
+	t.Helper()
+
+	if err := app.ValidateRoutes(); err != nil {
+		t.Fatalf("blazetest: %v", err)
+	}
+
+	config := app.GetConfig()
+	ln := fasthttputil.NewInmemoryListener()
+
+	s := &Server{
+		t:        t,
+		app:      app,
+		listener: ln,
+		server: &fasthttp.Server{
+			Handler:            app.Handler(),
+			ReadTimeout:        config.ReadTimeout,
+			WriteTimeout:       config.WriteTimeout,
+			MaxRequestBodySize: config.MaxRequestBodySize,
+			Concurrency:        config.Concurrency,
+			StreamRequestBody:  config.StreamRequestBody,
+
+			DisablePreParseMultipartForm: true,
+		},
+		Host:     DefaultHost,
+		Timeout:  5 * time.Second,
+		serveErr: make(chan error, 1),
+	}
+
+	s.client = &fasthttp.Client{
+		Dial: func(addr string) (net.Conn, error) {
+			return ln.Dial()
+		},
+	}
+
+	go func() {
+		s.serveErr <- s.server.Serve(syncListener{ln})
+	}()
+
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Errorf("blazetest: closing server: %v", err)
+		}
+	})
+
+	return s
+}
+
+// App returns the application being served
+func (s *Server) App() *blaze.App {
+	return s.app
+}
+
+// Listener returns the underlying in-memory listener
+func (s *Server) Listener() *fasthttputil.InmemoryListener {
+	return s.listener
+}
+
+// Dial opens a raw in-memory connection to the server
+func (s *Server) Dial() (net.Conn, error) {
+	return s.listener.Dial()
+}
+
+// Close stops the server and releases the listener.
+// It is safe to call Close multiple times.
+func (s *Server) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.client.CloseIdleConnections()
+		err = s.server.Shutdown()
+		// Shutdown only closes listeners Serve has registered; a server
+		// closed right after New may not have reached that point yet
+		s.listener.Close()
+		<-s.serveErr
+	})
+	return err
+}
+
+// Request creates a request with an arbitrary method
+func (s *Server) Request(method, path string) *Request {
+	return newRequest(s, method, path)
+}
+
+// Get creates a GET request
+func (s *Server) Get(path string) *Request {
+	return s.Request(fasthttp.MethodGet, path)
+}
+
+// Post creates a POST request
+func (s *Server) Post(path string) *Request {
+	return s.Request(fasthttp.MethodPost, path)
+}
+
+// Put creates a PUT request
+func (s *Server) Put(path string) *Request {
+	return s.Request(fasthttp.MethodPut, path)
+}
+
+// Patch creates a PATCH request
+func (s *Server) Patch(path string) *Request {
+	return s.Request(fasthttp.MethodPatch, path)
+}
+
+// Delete creates a DELETE request
+func (s *Server) Delete(path string) *Request {
+	return s.Request(fasthttp.MethodDelete, path)
+}
+
+// Head creates a HEAD request
+func (s *Server) Head(path string) *Request {
+	return s.Request(fasthttp.MethodHead, path)
+}
+
+// Options creates an OPTIONS request
+func (s *Server) Options(path string) *Request {
+	return s.Request(fasthttp.MethodOptions, path)
+}
+
+// syncListener serves connections whose write deadline may be set while
+// a response is written, as streamed responses (SSE) do. Unlike network
+// connections, in-memory pipe connections do not synchronize deadlines
+// with writes.
+type syncListener struct {
+	net.Listener
+}
+
+// Accept waits for the next connection
+func (l syncListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &syncConn{Conn: conn}, nil
+}
+
+// syncConn serializes writes and write deadline changes
+type syncConn struct {
+	net.Conn
+	mu sync.Mutex
+}
+
+func (c *syncConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.Write(p)
+}
+
+func (c *syncConn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *syncConn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.SetWriteDeadline(t)
+}
@@ -0,0 +1,233 @@
+package blazetest_test
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AarambhDevHub/blaze/pkg/blaze"
+	"github.com/AarambhDevHub/blaze/pkg/blazetest"
+	"github.com/fasthttp/websocket"
+)
+
+// recorder is a TB that records assertion failures instead of failing the
+// test, so failing assertions can be checked
+type recorder struct {
+	*testing.T
+
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) failures() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.errors
+}
+
+func newApp() *blaze.App {
+	app := blaze.New()
+
+	app.GET("/users/:id", func(c *blaze.Context) error {
+		return c.JSON(blaze.Map{
+			"id":    c.Param("id"),
+			"tags":  []string{"a", "b"},
+			"owner": blaze.Map{"name": "ada"},
+		})
+	})
+
+	app.POST("/echo", func(c *blaze.Context) error {
+		var body map[string]any
+		if err := c.BindJSON(&body); err != nil {
+			return err
+		}
+		c.SetHeader("X-Content-Type", string(c.Request().Header.ContentType()))
+		return c.Status(http.StatusCreated).JSON(body)
+	})
+
+	return app
+}
+
+func TestGet(t *testing.T) {
+	srv := blazetest.New(t, newApp())
+
+	srv.Get("/users/42").
+		Do().
+		AssertStatus(http.StatusOK).
+		AssertHeaderContains("Content-Type", "application/json").
+		AssertJSONPath("id", "42").
+		AssertJSONPath("tags[1]", "b").
+		AssertJSONPath("owner.name", "ada")
+
+	srv.Get("/missing").Do().AssertStatus(http.StatusNotFound)
+}
+
+func TestPostJSON(t *testing.T) {
+	srv := blazetest.New(t, newApp())
+
+	srv.Post("/echo").
+		WithJSON(map[string]any{"name": "blaze", "count": 3}).
+		Do().
+		AssertStatus(http.StatusCreated).
+		AssertHeaderContains("X-Content-Type", "application/json").
+		AssertJSONPath("name", "blaze").
+		AssertJSONPath("count", 3)
+
+	srv.Post("/echo").
+		WithBody("application/json", []byte(`{"list":[1,2]}`)).
+		Do().
+		AssertStatus(http.StatusCreated).
+		AssertJSONPath("list", []int{1, 2})
+}
+
+func TestWithJSONError(t *testing.T) {
+	srv := blazetest.New(t, newApp())
+
+	_, err := srv.Post("/echo").WithJSON(make(chan int)).Send()
+	if err == nil {
+		t.Fatal("expected an error for a body that cannot be encoded")
+	}
+}
+
+func TestAssertionFailures(t *testing.T) {
+	rec := &recorder{T: t}
+	srv := blazetest.New(rec, newApp())
+
+	res := srv.Get("/users/42").Do()
+	res.AssertStatus(http.StatusTeapot)
+	res.AssertJSONPath("id", "7")
+	res.AssertJSONPath("tags[5]", "a")
+	res.AssertJSONPath("owner.missing", nil)
+	res.AssertHeader("Content-Type", "text/plain")
+
+	failures := rec.failures()
+	if len(failures) != 5 {
+		t.Fatalf("recorded %d failures, want 5: %q", len(failures), failures)
+	}
+	for i, want := range []string{"status", `"id"`, "out of range", `"missing" not found`, "Content-Type"} {
+		if !strings.Contains(failures[i], want) {
+			t.Errorf("failure %d = %q, want it to mention %s", i, failures[i], want)
+		}
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	app := blaze.New()
+	app.WebSocket("/ws", func(ws *blaze.WebSocketConnection) {
+		for {
+			messageType, data, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := ws.WriteMessage(messageType, append([]byte("echo: "), data...)); err != nil {
+				return
+			}
+		}
+	})
+	app.GET("/plain", func(c *blaze.Context) error {
+		return c.Text("not a websocket")
+	})
+
+	srv := blazetest.New(t, app)
+
+	conn := srv.MustDialWebSocket("/ws", nil)
+	for _, msg := range []string{"one", "two"} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "echo: "+msg {
+			t.Errorf("got %q, want %q", data, "echo: "+msg)
+		}
+	}
+
+	_, resp, err := srv.DialWebSocket("/plain", nil)
+	if err == nil {
+		t.Fatal("expected the handshake to fail on a plain route")
+	}
+	if resp == nil || resp.StatusCode == http.StatusSwitchingProtocols {
+		t.Errorf("unexpected handshake response %v", resp)
+	}
+}
+
+func TestSSEStreaming(t *testing.T) {
+	next := make(chan struct{})
+
+	app := blaze.New()
+	app.GET("/events", func(c *blaze.Context) error {
+		config := blaze.DefaultSSEConfig()
+		// Heartbeats move the write deadline while events are written,
+		// which syncConn must serialize
+		config.HeartbeatInterval = time.Millisecond
+
+		return c.SSEWithConfig(config, func(w *blaze.SSEWriter) error {
+			for i := 1; i <= 3; i++ {
+				select {
+				case <-next:
+				case <-w.Done():
+					return nil
+				}
+				if err := w.Send(blaze.SSEEvent{ID: fmt.Sprint(i), Data: fmt.Sprintf("event %d", i)}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+
+	srv := blazetest.New(t, app)
+
+	conn, err := srv.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	fmt.Fprintf(conn, "GET /events HTTP/1.1\r\nHost: %s\r\nAccept: text/event-stream\r\n\r\n", srv.Host)
+	reader := bufio.NewReader(conn)
+
+	// Each event must arrive before the next one is produced
+	for i := 1; i <= 3; i++ {
+		next <- struct{}{}
+
+		want := fmt.Sprintf("data: event %d", i)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("waiting for %q: %v", want, err)
+			}
+			if strings.TrimRight(line, "\r\n") == want {
+				break
+			}
+		}
+	}
+}
+
+func TestCloseIdempotent(t *testing.T) {
+	srv := blazetest.New(t, newApp())
+	srv.Get("/users/1").Do().AssertStatus(http.StatusOK)
+
+	for i := 0; i < 3; i++ {
+		if err := srv.Close(); err != nil {
+			t.Fatalf("Close #%d: %v", i+1, err)
+		}
+	}
+
+	if _, err := srv.Get("/users/1").Send(); err == nil {
+		t.Error("expected requests to fail after Close")
+	}
+}
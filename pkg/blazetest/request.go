@@ -0,0 +1,162 @@
+package blazetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Request is a fluent builder for a single in-memory request
+type Request struct {
+	server  *Server
+	method  string
+	path    string
+	host    string
+	query   url.Values
+	headers [][2]string
+	cookies [][2]string
+	body    []byte
+	err     error
+}
+
+func newRequest(s *Server, method, path string) *Request {
+	return &Request{
+		server: s,
+		method: method,
+		path:   path,
+		host:   s.Host,
+		query:  url.Values{},
+	}
+}
+
+// WithHeader sets a request header, replacing earlier values for the key
+func (r *Request) WithHeader(key, value string) *Request {
+	for i := range r.headers {
+		if strings.EqualFold(r.headers[i][0], key) {
+			r.headers[i][1] = value
+			return r
+		}
+	}
+	r.headers = append(r.headers, [2]string{key, value})
+	return r
+}
+
+// WithHost overrides the Host header and request URI host
+func (r *Request) WithHost(host string) *Request {
+	r.host = host
+	return r
+}
+
+// WithQuery adds a query string parameter
+func (r *Request) WithQuery(key, value string) *Request {
+	r.query.Add(key, value)
+	return r
+}
+
+// WithCookie adds a request cookie
+func (r *Request) WithCookie(name, value string) *Request {
+	r.cookies = append(r.cookies, [2]string{name, value})
+	return r
+}
+
+// WithBasicAuth sets the Authorization header for HTTP basic authentication
+func (r *Request) WithBasicAuth(username, password string) *Request {
+	token := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return r.WithHeader("Authorization", "Basic "+token)
+}
+
+// WithBearerToken sets the Authorization header to a bearer token
+func (r *Request) WithBearerToken(token string) *Request {
+	return r.WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithBody sets a raw request body and its content type
+func (r *Request) WithBody(contentType string, body []byte) *Request {
+	r.body = body
+	if contentType != "" {
+		r.WithHeader("Content-Type", contentType)
+	}
+	return r
+}
+
+// WithJSON encodes v as the JSON request body
+func (r *Request) WithJSON(v any) *Request {
+	data, err := json.Marshal(v)
+	if err != nil {
+		r.err = fmt.Errorf("encoding JSON body: %w", err)
+		return r
+	}
+	return r.WithBody("application/json", data)
+}
+
+// WithForm encodes values as an application/x-www-form-urlencoded body
+func (r *Request) WithForm(values url.Values) *Request {
+	return r.WithBody("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// URI returns the absolute request URI that will be sent
+func (r *Request) URI() string {
+	uri := "http://" + r.host + r.path
+	if len(r.query) > 0 {
+		sep := "?"
+		if strings.Contains(r.path, "?") {
+			sep = "&"
+		}
+		uri += sep + r.query.Encode()
+	}
+	return uri
+}
+
+// Do sends the request and returns the buffered response.
+//
+// Transport failures and builder errors (for example an unencodable JSON
+// body) fail the test immediately.
+func (r *Request) Do() *Response {
+	r.server.t.Helper()
+
+	resp, err := r.Send()
+	if err != nil {
+		r.server.t.Fatalf("blazetest: %s %s: %v", r.method, r.path, err)
+	}
+	return resp
+}
+
+// Send sends the request and returns the response or a transport error
+// without failing the test.
+func (r *Request) Send() (*Response, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	req := fasthttp.AcquireRequest()
+	res := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(res)
+
+	req.Header.SetMethod(r.method)
+	req.SetRequestURI(r.URI())
+	req.Header.SetHost(r.host)
+	for _, h := range r.headers {
+		req.Header.Set(h[0], h[1])
+	}
+	for _, c := range r.cookies {
+		req.Header.SetCookie(c[0], c[1])
+	}
+	if r.body != nil {
+		req.SetBody(r.body)
+	}
+
+	if r.method == fasthttp.MethodHead {
+		res.SkipBody = true
+	}
+
+	if err := r.server.client.DoTimeout(req, res, r.server.Timeout); err != nil {
+		return nil, err
+	}
+
+	return newResponse(r.server.t, res), nil
+}
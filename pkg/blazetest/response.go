@@ -0,0 +1,268 @@
+package blazetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Response is a buffered copy of a server response with chainable assertions.
+//
+// Assertion methods report failures with t.Errorf and return the response so
+// several expectations can be checked in one chain.
+type Response struct {
+	t          TB
+	statusCode int
+	header     http.Header
+	cookies    map[string]*fasthttp.Cookie
+	body       []byte
+}
+
+func newResponse(t TB, res *fasthttp.Response) *Response {
+	r := &Response{
+		t:          t,
+		statusCode: res.StatusCode(),
+		header:     make(http.Header),
+		cookies:    make(map[string]*fasthttp.Cookie),
+		body:       append([]byte(nil), res.Body()...),
+	}
+
+	for key, value := range res.Header.All() {
+		r.header.Add(string(key), string(value))
+	}
+
+	for key, value := range res.Header.Cookies() {
+		cookie := &fasthttp.Cookie{}
+		if err := cookie.ParseBytes(value); err == nil {
+			r.cookies[string(key)] = cookie
+		}
+	}
+
+	return r
+}
+
+// StatusCode returns the response status code
+func (r *Response) StatusCode() int {
+	return r.statusCode
+}
+
+// Header returns the first value of a response header
+func (r *Response) Header(key string) string {
+	return r.header.Get(key)
+}
+
+// Headers returns all response headers
+func (r *Response) Headers() http.Header {
+	return r.header
+}
+
+// Cookie returns a cookie set by the response, or nil
+func (r *Response) Cookie(name string) *fasthttp.Cookie {
+	return r.cookies[name]
+}
+
+// Body returns the raw response body
+func (r *Response) Body() []byte {
+	return r.body
+}
+
+// BodyString returns the response body as a string
+func (r *Response) BodyString() string {
+	return string(r.body)
+}
+
+// JSON decodes the response body into v
+func (r *Response) JSON(v any) error {
+	return json.Unmarshal(r.body, v)
+}
+
+// JSONPath returns the value at a dotted path in the JSON body.
+//
+// Path segments are object keys or array indices, written either as
+// "items.0.id" or "items[0].id". An empty path returns the whole document.
+// Numbers are returned as float64, as encoding/json decodes them.
+func (r *Response) JSONPath(path string) (any, error) {
+	var doc any
+	if err := json.Unmarshal(r.body, &doc); err != nil {
+		return nil, fmt.Errorf("response body is not JSON: %w", err)
+	}
+	return lookupJSONPath(doc, path)
+}
+
+// AssertStatus checks the response status code
+func (r *Response) AssertStatus(want int) *Response {
+	r.t.Helper()
+	if r.statusCode != want {
+		r.t.Errorf("blazetest: status = %d, want %d; body: %s", r.statusCode, want, truncate(r.body))
+	}
+	return r
+}
+
+// AssertHeader checks that a response header equals want
+func (r *Response) AssertHeader(key, want string) *Response {
+	r.t.Helper()
+	if got := r.header.Get(key); got != want {
+		r.t.Errorf("blazetest: header %s = %q, want %q", key, got, want)
+	}
+	return r
+}
+
+// AssertHeaderContains checks that a response header contains substr
+func (r *Response) AssertHeaderContains(key, substr string) *Response {
+	r.t.Helper()
+	if got := r.header.Get(key); !strings.Contains(got, substr) {
+		r.t.Errorf("blazetest: header %s = %q, want it to contain %q", key, got, substr)
+	}
+	return r
+}
+
+// AssertHeaderMissing checks that a response header is absent
+func (r *Response) AssertHeaderMissing(key string) *Response {
+	r.t.Helper()
+	if values, ok := r.header[http.CanonicalHeaderKey(key)]; ok {
+		r.t.Errorf("blazetest: header %s = %q, want it to be absent", key, values)
+	}
+	return r
+}
+
+// AssertCookie checks that the response sets a cookie with the given value
+func (r *Response) AssertCookie(name, want string) *Response {
+	r.t.Helper()
+	cookie, ok := r.cookies[name]
+	if !ok {
+		r.t.Errorf("blazetest: cookie %q not set", name)
+		return r
+	}
+	if got := string(cookie.Value()); got != want {
+		r.t.Errorf("blazetest: cookie %q = %q, want %q", name, got, want)
+	}
+	return r
+}
+
+// AssertCookieExists checks that the response sets a cookie regardless of value
+func (r *Response) AssertCookieExists(name string) *Response {
+	r.t.Helper()
+	if _, ok := r.cookies[name]; !ok {
+		r.t.Errorf("blazetest: cookie %q not set", name)
+	}
+	return r
+}
+
+// AssertBody checks that the response body equals want
+func (r *Response) AssertBody(want string) *Response {
+	r.t.Helper()
+	if got := string(r.body); got != want {
+		r.t.Errorf("blazetest: body = %q, want %q", got, want)
+	}
+	return r
+}
+
+// AssertBodyContains checks that the response body contains substr
+func (r *Response) AssertBodyContains(substr string) *Response {
+	r.t.Helper()
+	if !bytes.Contains(r.body, []byte(substr)) {
+		r.t.Errorf("blazetest: body %s does not contain %q", truncate(r.body), substr)
+	}
+	return r
+}
+
+// AssertJSONPath checks the value at a JSON path.
+//
+// want is compared after a JSON round trip, so Go values such as int, struct
+// or blaze.Map compare naturally against the decoded body.
+func (r *Response) AssertJSONPath(path string, want any) *Response {
+	r.t.Helper()
+
+	got, err := r.JSONPath(path)
+	if err != nil {
+		r.t.Errorf("blazetest: JSON path %q: %v", path, err)
+		return r
+	}
+
+	normalized, err := normalizeJSON(want)
+	if err != nil {
+		r.t.Errorf("blazetest: JSON path %q: encoding expected value: %v", path, err)
+		return r
+	}
+
+	if !reflect.DeepEqual(got, normalized) {
+		r.t.Errorf("blazetest: JSON path %q = %#v, want %#v", path, got, normalized)
+	}
+	return r
+}
+
+// AssertJSONPathExists checks that a JSON path resolves to a value
+func (r *Response) AssertJSONPathExists(path string) *Response {
+	r.t.Helper()
+	if _, err := r.JSONPath(path); err != nil {
+		r.t.Errorf("blazetest: JSON path %q: %v", path, err)
+	}
+	return r
+}
+
+// lookupJSONPath walks a decoded JSON document
+func lookupJSONPath(doc any, path string) (any, error) {
+	current := doc
+	for _, segment := range splitJSONPath(path) {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found", segment)
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, fmt.Errorf("segment %q is not an array index", segment)
+			}
+			if index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("index %d out of range (len %d)", index, len(node))
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("cannot descend into %T at %q", current, segment)
+		}
+	}
+	return current, nil
+}
+
+// splitJSONPath splits "a.b[0].c" into ["a", "b", "0", "c"]
+func splitJSONPath(path string) []string {
+	path = strings.ReplaceAll(path, "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+
+	segments := make([]string, 0, strings.Count(path, ".")+1)
+	for _, segment := range strings.Split(path, ".") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// normalizeJSON converts v into the representation produced by json.Unmarshal
+func normalizeJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
+
+// truncate shortens long bodies in failure messages
+func truncate(body []byte) string {
+	const max = 512
+	if len(body) > max {
+		return string(body[:max]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,64 @@
+package blazetest
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/fasthttp/websocket"
+)
+
+// DialWebSocket performs a WebSocket handshake against a route registered with
+// App.WebSocket and returns the client connection.
+//
+// The connection is closed automatically when the test finishes.
+//
+// Parameters:
+//   - path: Route path including any query string
+//   - header: Optional handshake headers (nil for none)
+//
+// Returns:
+//   - *websocket.Conn: Client side of the WebSocket connection
+//   - *http.Response: Handshake response (useful when the upgrade is rejected)
+//   - error: Handshake error
+//
+// Example:
+//
+//	conn, _, err := srv.DialWebSocket("/ws", nil)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+func (s *Server) DialWebSocket(path string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	dialer := websocket.Dialer{
+		NetDial: func(network, addr string) (net.Conn, error) {
+			return s.listener.Dial()
+		},
+		HandshakeTimeout: s.Timeout,
+	}
+
+	if header == nil {
+		header = http.Header{}
+	}
+
+	conn, resp, err := dialer.Dial("ws://"+s.Host+path, header)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	s.t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn, resp, nil
+}
+
+// MustDialWebSocket is like DialWebSocket but fails the test on error
+func (s *Server) MustDialWebSocket(path string, header http.Header) *websocket.Conn {
+	s.t.Helper()
+
+	conn, _, err := s.DialWebSocket(path, header)
+	if err != nil {
+		s.t.Fatalf("blazetest: dialing WebSocket %s: %v", path, err)
+	}
+	return conn
+}